@@ -0,0 +1,244 @@
+package bidengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/provider/cluster"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+const (
+	// AdmissionObjectiveFIFO reserves queued orders in the order they arrived
+	AdmissionObjectiveFIFO = "fifo"
+	// AdmissionObjectiveRevenue reserves queued orders offering the highest price first.
+	// Prices are compared within denomination only
+	AdmissionObjectiveRevenue = "revenue"
+	// AdmissionObjectiveUtilization reserves queued orders requesting the most GPUs first
+	AdmissionObjectiveUtilization = "utilization"
+)
+
+var errAdmissionObjectiveInvalid = errors.New("invalid admission objective")
+
+// AdmissionConfig controls batching of orders competing for GPUs.
+// Orders requesting GPUs are queued for Window and reserved in the order chosen by Objective,
+// so that small orders arriving first do not starve larger ones. Queue is disabled when Window is zero
+type AdmissionConfig struct {
+	// Window orders are collected for before the batch is reserved
+	Window time.Duration
+	// Objective queued orders are reserved by, fifo when empty
+	Objective string
+}
+
+func (c AdmissionConfig) enabled() bool {
+	return c.Window > 0
+}
+
+func (c AdmissionConfig) validate() error {
+	switch c.Objective {
+	case "", AdmissionObjectiveFIFO, AdmissionObjectiveRevenue, AdmissionObjectiveUtilization:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", errAdmissionObjectiveInvalid, c.Objective)
+}
+
+var (
+	admissionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_order_admission",
+		Help: "The total number of orders passed through admission queue by result",
+	}, []string{"result"})
+
+	admissionQueuedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "provider_order_admission_queued",
+		Help: "The number of orders waiting in admission queue",
+	})
+)
+
+const (
+	admissionResultQueued   = "queued"
+	admissionResultAdmitted = "admitted"
+	admissionResultRejected = "rejected"
+)
+
+type admissionResult struct {
+	reservation ctypes.Reservation
+	err         error
+}
+
+type admissionRequest struct {
+	order    mtypes.OrderID
+	group    *dtypes.Group
+	resultch chan admissionResult
+}
+
+// admissionQueue batches reservations of orders requesting GPUs
+type admissionQueue struct {
+	cfg      AdmissionConfig
+	cluster  cluster.Cluster
+	log      log.Logger
+	submitch chan admissionRequest
+	donech   chan struct{}
+}
+
+func newAdmissionQueue(ctx context.Context, log log.Logger, cluster cluster.Cluster, cfg AdmissionConfig) *admissionQueue {
+	q := &admissionQueue{
+		cfg:      cfg,
+		cluster:  cluster,
+		log:      log.With("cmp", "admission-queue"),
+		submitch: make(chan admissionRequest),
+		donech:   make(chan struct{}),
+	}
+
+	go q.run(ctx)
+
+	return q
+}
+
+// queues reports whether reservation of the group goes through the queue
+func (q *admissionQueue) queues(group *dtypes.Group) bool {
+	return groupGPUs(group) > 0
+}
+
+// reserve queues the order and blocks until its batch is reserved
+func (q *admissionQueue) reserve(order mtypes.OrderID, group *dtypes.Group) (ctypes.Reservation, error) {
+	req := admissionRequest{
+		order:    order,
+		group:    group,
+		resultch: make(chan admissionResult, 1),
+	}
+
+	select {
+	case q.submitch <- req:
+	case <-q.donech:
+		return nil, ErrNotRunning
+	}
+
+	result := <-req.resultch
+
+	return result.reservation, result.err
+}
+
+func (q *admissionQueue) run(ctx context.Context) {
+	defer close(q.donech)
+
+	var (
+		pending []admissionRequest
+		windowc <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			for _, req := range pending {
+				req.resultch <- admissionResult{err: ErrNotRunning}
+			}
+
+			admissionQueuedGauge.Sub(float64(len(pending)))
+
+			return
+		case req := <-q.submitch:
+			pending = append(pending, req)
+
+			admissionCounter.WithLabelValues(admissionResultQueued).Inc()
+			admissionQueuedGauge.Inc()
+
+			if windowc == nil {
+				windowc = time.After(q.cfg.Window)
+			}
+		case <-windowc:
+			windowc = nil
+
+			batch := pending
+			pending = nil
+
+			admissionQueuedGauge.Sub(float64(len(batch)))
+
+			q.admit(batch)
+		}
+	}
+}
+
+// admit reserves the batch one order at a time, in the order objective prefers them
+func (q *admissionQueue) admit(batch []admissionRequest) {
+	sortAdmission(q.cfg.Objective, batch)
+
+	q.log.Debug("admitting orders", "qty", len(batch), "objective", q.cfg.Objective)
+
+	for _, req := range batch {
+		res, err := q.cluster.Reserve(req.order, req.group)
+		if err != nil {
+			admissionCounter.WithLabelValues(admissionResultRejected).Inc()
+			q.log.Debug("order rejected", "order", req.order, "err", err)
+		} else {
+			admissionCounter.WithLabelValues(admissionResultAdmitted).Inc()
+		}
+
+		req.resultch <- admissionResult{reservation: res, err: err}
+	}
+}
+
+// sortAdmission orders the batch by objective, keeping arrival order of equal requests
+func sortAdmission(objective string, batch []admissionRequest) {
+	switch objective {
+	case AdmissionObjectiveRevenue:
+		sortRevenue(batch)
+	case AdmissionObjectiveUtilization:
+		sort.SliceStable(batch, func(i, j int) bool {
+			return groupGPUs(batch[i].group) > groupGPUs(batch[j].group)
+		})
+	}
+}
+
+// sortRevenue orders requests of every denomination by price, highest first.
+// Prices in different denominations are not comparable, so requests of each denomination
+// are reordered among the positions they arrived at
+func sortRevenue(batch []admissionRequest) {
+	positions := make(map[string][]int)
+	for idx, req := range batch {
+		denom := req.group.GroupSpec.Price().Denom
+		positions[denom] = append(positions[denom], idx)
+	}
+
+	result := make([]admissionRequest, len(batch))
+
+	for _, indexes := range positions {
+		reqs := make([]admissionRequest, 0, len(indexes))
+		for _, idx := range indexes {
+			reqs = append(reqs, batch[idx])
+		}
+
+		sort.SliceStable(reqs, func(i, j int) bool {
+			return reqs[j].group.GroupSpec.Price().Amount.LT(reqs[i].group.GroupSpec.Price().Amount)
+		})
+
+		for i, idx := range indexes {
+			result[idx] = reqs[i]
+		}
+	}
+
+	copy(batch, result)
+}
+
+// groupGPUs returns total number of GPUs requested by all replicas of the group
+func groupGPUs(group *dtypes.Group) uint64 {
+	var result uint64
+
+	for _, ru := range group.GroupSpec.GetResourceUnits() {
+		if ru.Resources.GPU != nil {
+			result += ru.Resources.GPU.Units.Value() * uint64(ru.Count)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,173 @@
+package bidengine
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	clustermocks "github.com/akash-network/provider/cluster/mocks"
+	clmocks "github.com/akash-network/provider/cluster/types/v1beta3/mocks"
+)
+
+func admissionTestRequest(t *testing.T, gpus uint64, price int64) admissionRequest {
+	return admissionTestRequestDenom(t, gpus, sdk.NewInt64DecCoin(testutil.CoinDenom, price))
+}
+
+func admissionTestRequestDenom(t *testing.T, gpus uint64, price sdk.DecCoin) admissionRequest {
+	group := &dtypes.Group{
+		GroupID: testutil.GroupID(t),
+		GroupSpec: dtypes.GroupSpec{
+			Name: "group",
+			Resources: dtypes.ResourceUnits{
+				{
+					Resources: atypes.Resources{
+						ID:  1,
+						CPU: &atypes.CPU{Units: atypes.NewResourceValue(1000)},
+						GPU: &atypes.GPU{Units: atypes.NewResourceValue(gpus)},
+					},
+					Count: 1,
+					Price: price,
+				},
+			},
+		},
+	}
+
+	return admissionRequest{
+		order:    mtypes.MakeOrderID(group.GroupID, 1),
+		group:    group,
+		resultch: make(chan admissionResult, 1),
+	}
+}
+
+func Test_AdmissionConfigValidate(t *testing.T) {
+	require.NoError(t, AdmissionConfig{}.validate())
+	require.NoError(t, AdmissionConfig{Objective: AdmissionObjectiveRevenue}.validate())
+	require.ErrorIs(t, AdmissionConfig{Objective: "random"}.validate(), errAdmissionObjectiveInvalid)
+}
+
+func Test_SortAdmission(t *testing.T) {
+	small := admissionTestRequest(t, 1, 50)
+	large := admissionTestRequest(t, 4, 30)
+	medium := admissionTestRequest(t, 2, 50)
+
+	orders := func(batch []admissionRequest) []mtypes.OrderID {
+		result := make([]mtypes.OrderID, 0, len(batch))
+		for _, req := range batch {
+			result = append(result, req.order)
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		objective string
+		expected  []mtypes.OrderID
+	}{
+		{objective: AdmissionObjectiveFIFO, expected: []mtypes.OrderID{small.order, large.order, medium.order}},
+		{objective: AdmissionObjectiveRevenue, expected: []mtypes.OrderID{small.order, medium.order, large.order}},
+		{objective: AdmissionObjectiveUtilization, expected: []mtypes.OrderID{large.order, medium.order, small.order}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.objective, func(t *testing.T) {
+			batch := []admissionRequest{small, large, medium}
+			sortAdmission(test.objective, batch)
+			require.Equal(t, test.expected, orders(batch))
+		})
+	}
+}
+
+func Test_SortAdmissionRevenueWithinDenom(t *testing.T) {
+	cheap := admissionTestRequest(t, 1, 10)
+	other := admissionTestRequestDenom(t, 1, sdk.NewInt64DecCoin("ibc/usdc", 1))
+	expensive := admissionTestRequest(t, 1, 90)
+	otherExpensive := admissionTestRequestDenom(t, 1, sdk.NewInt64DecCoin("ibc/usdc", 5))
+
+	batch := []admissionRequest{cheap, other, expensive, otherExpensive}
+	sortAdmission(AdmissionObjectiveRevenue, batch)
+
+	// each denomination keeps positions it arrived at
+	require.Equal(t, []mtypes.OrderID{expensive.order, otherExpensive.order, cheap.order, other.order}, []mtypes.OrderID{
+		batch[0].order, batch[1].order, batch[2].order, batch[3].order,
+	})
+}
+
+func Test_AdmissionQueueReservesBatchByObjective(t *testing.T) {
+	small := admissionTestRequest(t, 1, 10)
+	large := admissionTestRequest(t, 4, 10)
+
+	var (
+		lock     sync.Mutex
+		reserved []mtypes.OrderID
+	)
+
+	cluster := &clustermocks.Cluster{}
+	cluster.On("Reserve", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		reserved = append(reserved, args.Get(0).(mtypes.OrderID))
+	}).Return(&clmocks.Reservation{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queue := newAdmissionQueue(ctx, testutil.Logger(t), cluster, AdmissionConfig{
+		Window:    100 * time.Millisecond,
+		Objective: AdmissionObjectiveUtilization,
+	})
+
+	require.True(t, queue.queues(small.group))
+	require.False(t, queue.queues(admissionTestRequest(t, 0, 10).group))
+
+	var wg sync.WaitGroup
+	for _, req := range []admissionRequest{small, large} {
+		req := req
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := queue.reserve(req.order, req.group)
+			require.NoError(t, err)
+		}()
+
+		// keep arrival order deterministic
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, []mtypes.OrderID{large.order, small.order}, reserved)
+}
+
+func Test_AdmissionQueueShutdown(t *testing.T) {
+	req := admissionTestRequest(t, 1, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := newAdmissionQueue(ctx, testutil.Logger(t), &clustermocks.Cluster{}, AdmissionConfig{Window: time.Hour})
+
+	errch := make(chan error, 1)
+	go func() {
+		_, err := queue.reserve(req.order, req.group)
+		errch <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	require.ErrorIs(t, <-errch, ErrNotRunning)
+
+	_, err := queue.reserve(req.order, req.group)
+	require.ErrorIs(t, err, ErrNotRunning)
+}
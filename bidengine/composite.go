@@ -0,0 +1,337 @@
+package bidengine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	errChainEmpty              = errors.New("pricing chain must have at least one strategy")
+	errStrategyNil             = errors.New("pricing strategy cannot be nil")
+	errPriceLimitInvalid       = errors.New("price floor cannot be greater than ceiling")
+	errPriceLimitNegative      = errors.New("price floor and ceiling cannot be negative")
+	errMultiplierNegative      = errors.New("price multiplier cannot be negative")
+	errTimeWindowInvalid       = errors.New("time window must be within a day and cannot be empty")
+	errOwnerPricingInvalid     = errors.New("owner pricing must set either price or discount")
+	errOwnerDiscountOutOfRange = errors.New("owner discount must be within [0, 1)")
+	errPreemptibleOutOfRange   = errors.New("preemptible multiplier must be within (0, 1]")
+)
+
+// chainPricing returns price from the first strategy that succeeds
+type chainPricing struct {
+	strategies []BidPricingStrategy
+}
+
+func MakeChainPricing(strategies ...BidPricingStrategy) (BidPricingStrategy, error) {
+	if len(strategies) == 0 {
+		return nil, errChainEmpty
+	}
+
+	for _, strategy := range strategies {
+		if strategy == nil {
+			return nil, errStrategyNil
+		}
+	}
+
+	// chain of one is the strategy itself
+	if len(strategies) == 1 {
+		return strategies[0], nil
+	}
+
+	return chainPricing{strategies: strategies}, nil
+}
+
+func (cp chainPricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	var err error
+
+	for _, strategy := range cp.strategies {
+		var price sdk.DecCoin
+
+		price, err = strategy.CalculatePrice(ctx, r)
+		if err == nil {
+			return price, nil
+		}
+
+		// do not try remaining strategies once request is cancelled
+		if ctx.Err() != nil {
+			return sdk.DecCoin{}, ctx.Err()
+		}
+	}
+
+	return sdk.DecCoin{}, err
+}
+
+// PriceLimit is the range bid price is clamped into. Nil bound is not enforced
+type PriceLimit struct {
+	Floor   *sdk.Dec
+	Ceiling *sdk.Dec
+}
+
+func (l PriceLimit) validate() error {
+	if (l.Floor != nil && l.Floor.IsNegative()) || (l.Ceiling != nil && l.Ceiling.IsNegative()) {
+		return errPriceLimitNegative
+	}
+
+	if l.Floor != nil && l.Ceiling != nil && l.Floor.GT(*l.Ceiling) {
+		return errPriceLimitInvalid
+	}
+
+	return nil
+}
+
+// clampPricing keeps price within floor and ceiling configured for its denomination.
+// Prices in denominations without limits are passed through unchanged
+type clampPricing struct {
+	inner  BidPricingStrategy
+	limits map[string]PriceLimit
+}
+
+func MakeClampPricing(inner BidPricingStrategy, limits map[string]PriceLimit) (BidPricingStrategy, error) {
+	if inner == nil {
+		return nil, errStrategyNil
+	}
+
+	for denom, limit := range limits {
+		if err := limit.validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, denom)
+		}
+	}
+
+	return clampPricing{inner: inner, limits: limits}, nil
+}
+
+func (cp clampPricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	price, err := cp.inner.CalculatePrice(ctx, r)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	limit, exists := cp.limits[price.Denom]
+	if !exists {
+		return price, nil
+	}
+
+	if limit.Floor != nil && price.Amount.LT(*limit.Floor) {
+		price.Amount = *limit.Floor
+	}
+
+	if limit.Ceiling != nil && price.Amount.GT(*limit.Ceiling) {
+		price.Amount = *limit.Ceiling
+	}
+
+	if price.IsZero() {
+		return sdk.DecCoin{}, ErrBidZero
+	}
+
+	return price, nil
+}
+
+// PriceMultiplier returns factor price is multiplied by for given request.
+// Returning false leaves price unchanged
+type PriceMultiplier interface {
+	Multiplier(r Request) (decimal.Decimal, bool)
+}
+
+// multiplierPricing scales price returned by the wrapped strategy
+type multiplierPricing struct {
+	inner       BidPricingStrategy
+	multipliers []PriceMultiplier
+}
+
+func MakeMultiplierPricing(inner BidPricingStrategy, multipliers ...PriceMultiplier) (BidPricingStrategy, error) {
+	if inner == nil {
+		return nil, errStrategyNil
+	}
+
+	if len(multipliers) == 0 {
+		return inner, nil
+	}
+
+	return multiplierPricing{inner: inner, multipliers: multipliers}, nil
+}
+
+func (mp multiplierPricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	price, err := mp.inner.CalculatePrice(ctx, r)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	factor := decimal.NewFromInt(1)
+	for _, multiplier := range mp.multipliers {
+		if val, apply := multiplier.Multiplier(r); apply {
+			factor = factor.Mul(val)
+		}
+	}
+
+	return scalePrice(price, factor, r.PricePrecision)
+}
+
+// scalePrice multiplies price by the factor and rounds it to requested precision
+func scalePrice(price sdk.DecCoin, factor decimal.Decimal, precision int) (sdk.DecCoin, error) {
+	if factor.Equal(decimal.NewFromInt(1)) {
+		return price, nil
+	}
+
+	amount, err := decimal.NewFromString(price.Amount.String())
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	places := int32(sdk.Precision)
+	if precision > 0 && precision < sdk.Precision {
+		places = int32(precision) // nolint: gosec
+	}
+
+	result, err := sdk.NewDecFromStr(amount.Mul(factor).StringFixed(places))
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("%w%w", err, ErrBidQuantityInvalid)
+	}
+
+	if result.IsZero() {
+		return sdk.DecCoin{}, ErrBidZero
+	}
+
+	if result.IsNegative() || !result.LTE(sdk.MaxSortableDec) {
+		return sdk.DecCoin{}, ErrBidQuantityInvalid
+	}
+
+	return sdk.NewDecCoinFromDec(price.Denom, result), nil
+}
+
+// TimeWindow applies Multiplier to bids placed between From and To,
+// both being offsets since midnight. Window with From after To wraps over midnight
+type TimeWindow struct {
+	From       time.Duration
+	To         time.Duration
+	Multiplier decimal.Decimal
+}
+
+type timeOfDayMultiplier struct {
+	windows  []TimeWindow
+	location *time.Location
+	now      func() time.Time
+}
+
+// MakeTimeOfDayMultiplier returns multiplier of the first window current time falls into
+func MakeTimeOfDayMultiplier(windows []TimeWindow, location *time.Location) (PriceMultiplier, error) {
+	for _, window := range windows {
+		if window.From < 0 || window.To < 0 || window.From >= 24*time.Hour || window.To > 24*time.Hour || window.From == window.To {
+			return nil, errTimeWindowInvalid
+		}
+
+		if window.Multiplier.IsNegative() {
+			return nil, errMultiplierNegative
+		}
+	}
+
+	if location == nil {
+		location = time.UTC
+	}
+
+	result := &timeOfDayMultiplier{
+		windows:  windows,
+		location: location,
+		now:      time.Now,
+	}
+
+	return result, nil
+}
+
+func (tm *timeOfDayMultiplier) Multiplier(_ Request) (decimal.Decimal, bool) {
+	now := tm.now().In(tm.location)
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tm.location)
+	offset := now.Sub(midnight)
+
+	for _, window := range tm.windows {
+		var within bool
+		if window.From < window.To {
+			within = offset >= window.From && offset < window.To
+		} else {
+			within = offset >= window.From || offset < window.To
+		}
+
+		if within {
+			return window.Multiplier, true
+		}
+	}
+
+	return decimal.Decimal{}, false
+}
+
+type preemptibleMultiplier struct {
+	multiplier decimal.Decimal
+}
+
+// MakePreemptibleMultiplier returns multiplier applied to orders opted into preemptible leases.
+// Preemptible leases may be evicted, thus multiplier cannot raise the price
+func MakePreemptibleMultiplier(multiplier decimal.Decimal) (PriceMultiplier, error) {
+	if !multiplier.IsPositive() || multiplier.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, errPreemptibleOutOfRange
+	}
+
+	return preemptibleMultiplier{multiplier: multiplier}, nil
+}
+
+func (pm preemptibleMultiplier) Multiplier(r Request) (decimal.Decimal, bool) {
+	return pm.multiplier, r.Preemptible
+}
+
+// OwnerPricing overrides price for a tenant.
+// Price, if set for the order's denomination, replaces calculated price.
+// Otherwise Discount reduces calculated price by given fraction
+type OwnerPricing struct {
+	Price    sdk.DecCoins
+	Discount decimal.Decimal
+}
+
+type ownerPricing struct {
+	inner  BidPricingStrategy
+	owners map[string]OwnerPricing
+}
+
+func MakeOwnerPricing(inner BidPricingStrategy, owners map[string]OwnerPricing) (BidPricingStrategy, error) {
+	if inner == nil {
+		return nil, errStrategyNil
+	}
+
+	for owner, cfg := range owners {
+		if len(cfg.Price) == 0 && cfg.Discount.IsZero() {
+			return nil, fmt.Errorf("%w: %s", errOwnerPricingInvalid, owner)
+		}
+
+		if cfg.Discount.IsNegative() || cfg.Discount.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			return nil, fmt.Errorf("%w: %s", errOwnerDiscountOutOfRange, owner)
+		}
+
+		if err := cfg.Price.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, owner)
+		}
+	}
+
+	return ownerPricing{inner: inner, owners: owners}, nil
+}
+
+func (op ownerPricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	cfg, exists := op.owners[r.Owner]
+	if !exists {
+		return op.inner.CalculatePrice(ctx, r)
+	}
+
+	denom := r.GSpec.Price().Denom
+	if amount := cfg.Price.AmountOf(denom); amount.IsPositive() {
+		return sdk.NewDecCoinFromDec(denom, amount), nil
+	}
+
+	price, err := op.inner.CalculatePrice(ctx, r)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	return scalePrice(price, decimal.NewFromInt(1).Sub(cfg.Discount), r.PricePrecision)
+}
@@ -0,0 +1,394 @@
+package bidengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	provider "github.com/akash-network/akash-api/go/provider/v1"
+	"github.com/akash-network/node/testutil"
+)
+
+func decPtr(t *testing.T, val string) *sdk.Dec {
+	t.Helper()
+
+	res, err := sdk.NewDecFromStr(val)
+	require.NoError(t, err)
+
+	return &res
+}
+
+func utilizationInventory(cpuAllocated, gpuAllocated int64) *provider.Inventory {
+	return &provider.Inventory{
+		Cluster: inventoryV1.Cluster{
+			Nodes: inventoryV1.Nodes{
+				{
+					Name: "node",
+					Resources: inventoryV1.NodeResources{
+						CPU: inventoryV1.CPU{
+							Quantity: inventoryV1.NewResourcePairMilli(10000, 10000, cpuAllocated, resource.DecimalSI),
+						},
+						Memory: inventoryV1.Memory{
+							Quantity: inventoryV1.NewResourcePair(1000, 1000, 0, resource.DecimalSI),
+						},
+						GPU: inventoryV1.GPU{
+							Quantity: inventoryV1.NewResourcePair(4, 4, gpuAllocated, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_ChainPricingRejectsEmpty(t *testing.T) {
+	_, err := MakeChainPricing()
+	require.ErrorIs(t, err, errChainEmpty)
+
+	_, err = MakeChainPricing(testBidPricingStrategy(1), nil)
+	require.ErrorIs(t, err, errStrategyNil)
+}
+
+func Test_ChainPricingReturnsFirstSuccess(t *testing.T) {
+	pricing, err := MakeChainPricing(
+		alwaysFailsBidPricingStrategy{failure: errBidPricingAlwaysFails},
+		testBidPricingStrategy(7),
+		testBidPricingStrategy(9),
+	)
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(7), price.Amount)
+}
+
+func Test_ChainPricingReturnsLastError(t *testing.T) {
+	pricing, err := MakeChainPricing(
+		alwaysFailsBidPricingStrategy{failure: ErrBidZero},
+		alwaysFailsBidPricingStrategy{failure: errBidPricingAlwaysFails},
+	)
+	require.NoError(t, err)
+
+	_, err = pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.ErrorIs(t, err, errBidPricingAlwaysFails)
+}
+
+func Test_ChainPricingStopsByContext(t *testing.T) {
+	pricing, err := MakeChainPricing(
+		alwaysFailsBidPricingStrategy{failure: errBidPricingAlwaysFails},
+		testBidPricingStrategy(7),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = pricing.CalculatePrice(ctx, Request{GSpec: defaultGroupSpec()})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ClampPricingRejectsInvalidLimits(t *testing.T) {
+	_, err := MakeClampPricing(testBidPricingStrategy(1), map[string]PriceLimit{
+		testutil.CoinDenom: {Floor: decPtr(t, "10"), Ceiling: decPtr(t, "1")},
+	})
+	require.ErrorIs(t, err, errPriceLimitInvalid)
+
+	_, err = MakeClampPricing(testBidPricingStrategy(1), map[string]PriceLimit{
+		testutil.CoinDenom: {Floor: decPtr(t, "-1")},
+	})
+	require.ErrorIs(t, err, errPriceLimitNegative)
+}
+
+func Test_ClampPricing(t *testing.T) {
+	limits := map[string]PriceLimit{
+		testutil.CoinDenom: {Floor: decPtr(t, "5"), Ceiling: decPtr(t, "10")},
+		"uother":           {Ceiling: decPtr(t, "1")},
+	}
+
+	cases := []struct {
+		desc     string
+		price    int64
+		expected int64
+	}{
+		{desc: "below floor", price: 1, expected: 5},
+		{desc: "within limits", price: 7, expected: 7},
+		{desc: "above ceiling", price: 100, expected: 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			pricing, err := MakeClampPricing(testBidPricingStrategy(c.price), limits)
+			require.NoError(t, err)
+
+			price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+			require.NoError(t, err)
+			require.Equal(t, testutil.CoinDenom, price.Denom)
+			require.Equal(t, sdk.NewDec(c.expected), price.Amount)
+		})
+	}
+}
+
+func Test_ClampPricingIgnoresOtherDenoms(t *testing.T) {
+	pricing, err := MakeClampPricing(testBidPricingStrategy(100), map[string]PriceLimit{
+		"uother": {Ceiling: decPtr(t, "1")},
+	})
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(100), price.Amount)
+}
+
+func Test_TimeOfDayMultiplier(t *testing.T) {
+	_, err := MakeTimeOfDayMultiplier([]TimeWindow{{From: time.Hour, To: time.Hour, Multiplier: decimal.NewFromInt(1)}}, nil)
+	require.ErrorIs(t, err, errTimeWindowInvalid)
+
+	_, err = MakeTimeOfDayMultiplier([]TimeWindow{{From: time.Hour, To: 2 * time.Hour, Multiplier: decimal.NewFromInt(-1)}}, nil)
+	require.ErrorIs(t, err, errMultiplierNegative)
+
+	res, err := MakeTimeOfDayMultiplier([]TimeWindow{
+		{From: 22 * time.Hour, To: 6 * time.Hour, Multiplier: decimal.RequireFromString("0.5")},
+		{From: 9 * time.Hour, To: 17 * time.Hour, Multiplier: decimal.NewFromInt(2)},
+	}, time.UTC)
+	require.NoError(t, err)
+
+	multiplier := res.(*timeOfDayMultiplier)
+
+	cases := []struct {
+		at       string
+		expected string
+	}{
+		{at: "23:30", expected: "0.5"},
+		{at: "03:00", expected: "0.5"},
+		{at: "06:00", expected: ""},
+		{at: "12:00", expected: "2"},
+		{at: "17:00", expected: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.at, func(t *testing.T) {
+			at, err := time.Parse(timeOfDayLayout, c.at)
+			require.NoError(t, err)
+
+			multiplier.now = func() time.Time {
+				return time.Date(2024, 1, 1, at.Hour(), at.Minute(), 0, 0, time.UTC)
+			}
+
+			val, apply := multiplier.Multiplier(Request{})
+			if c.expected == "" {
+				require.False(t, apply)
+				return
+			}
+
+			require.True(t, apply)
+			require.True(t, decimal.RequireFromString(c.expected).Equal(val))
+		})
+	}
+}
+
+func Test_MultiplierPricing(t *testing.T) {
+	multiplier, err := MakeSurgeMultiplier(map[string][]UtilizationTier{
+		UtilizationResourceCPU: {{Threshold: 0.5, Multiplier: decimal.RequireFromString("1.5")}},
+	})
+	require.NoError(t, err)
+
+	pricing, err := MakeMultiplierPricing(testBidPricingStrategy(11), multiplier)
+	require.NoError(t, err)
+
+	req := Request{
+		GSpec:          defaultGroupSpec(),
+		PricePrecision: DefaultPricePrecision,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(11), price.Amount)
+
+	req.Inventory = utilizationInventory(8000, 0)
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, *decPtr(t, "16.5"), price.Amount)
+	require.Equal(t, testutil.CoinDenom, price.Denom)
+}
+
+func Test_PreemptibleMultiplier(t *testing.T) {
+	_, err := MakePreemptibleMultiplier(decimal.Zero)
+	require.ErrorIs(t, err, errPreemptibleOutOfRange)
+
+	_, err = MakePreemptibleMultiplier(decimal.RequireFromString("1.5"))
+	require.ErrorIs(t, err, errPreemptibleOutOfRange)
+
+	multiplier, err := MakePreemptibleMultiplier(decimal.RequireFromString("0.4"))
+	require.NoError(t, err)
+
+	pricing, err := MakeMultiplierPricing(testBidPricingStrategy(20), multiplier)
+	require.NoError(t, err)
+
+	req := Request{
+		GSpec:          defaultGroupSpec(),
+		PricePrecision: DefaultPricePrecision,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(20), price.Amount)
+
+	req.Preemptible = true
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(8), price.Amount)
+}
+
+func Test_OwnerPricing(t *testing.T) {
+	partner := testutil.AccAddress(t).String()
+	reseller := testutil.AccAddress(t).String()
+
+	_, err := MakeOwnerPricing(testBidPricingStrategy(1), map[string]OwnerPricing{partner: {}})
+	require.ErrorIs(t, err, errOwnerPricingInvalid)
+
+	_, err = MakeOwnerPricing(testBidPricingStrategy(1), map[string]OwnerPricing{partner: {Discount: decimal.NewFromInt(1)}})
+	require.ErrorIs(t, err, errOwnerDiscountOutOfRange)
+
+	pricing, err := MakeOwnerPricing(testBidPricingStrategy(20), map[string]OwnerPricing{
+		partner:  {Discount: decimal.RequireFromString("0.25")},
+		reseller: {Price: sdk.NewDecCoins(sdk.NewInt64DecCoin(testutil.CoinDenom, 3))},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		desc     string
+		owner    string
+		expected int64
+	}{
+		{desc: "other owner", owner: testutil.AccAddress(t).String(), expected: 20},
+		{desc: "discount", owner: partner, expected: 15},
+		{desc: "override", owner: reseller, expected: 3},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			price, err := pricing.CalculatePrice(context.Background(), Request{Owner: c.owner, GSpec: defaultGroupSpec()})
+			require.NoError(t, err)
+			require.Equal(t, testutil.CoinDenom, price.Denom)
+			require.Equal(t, sdk.NewDec(c.expected), price.Amount)
+		})
+	}
+}
+
+func Test_PricingConfigFromProviderConfig(t *testing.T) {
+	partner := testutil.AccAddress(t).String()
+	reseller := testutil.AccAddress(t).String()
+
+	path := filepath.Join(t.TempDir(), "provider.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+host: https://localhost:8443
+attributes:
+  - key: region
+    value: us-west
+pricing:
+  limits:
+    - denom: `+testutil.CoinDenom+`
+      floor: "12"
+      ceiling: "40"
+  surge:
+    cpu:
+      - threshold: 0.5
+        multiplier: "2"
+  owners:
+    `+partner+`:
+      discount: "0.5"
+    `+reseller+`:
+      price: "1`+testutil.CoinDenom+`"
+`), 0o600))
+
+	cfg, err := ReadPricingConfigPath(path)
+	require.NoError(t, err)
+
+	pricing, err := cfg.Wrap(context.Background(), testBidPricingStrategy(15))
+	require.NoError(t, err)
+
+	cases := []struct {
+		desc      string
+		owner     string
+		inventory *provider.Inventory
+		expected  int64
+	}{
+		{desc: "unchanged", owner: testutil.AccAddress(t).String(), expected: 15},
+		{desc: "utilization clamped to ceiling", owner: testutil.AccAddress(t).String(), inventory: utilizationInventory(9000, 0), expected: 30},
+		{desc: "discount after multiplier", owner: partner, inventory: utilizationInventory(9000, 0), expected: 15},
+		{desc: "discount clamped to floor", owner: partner, expected: 12},
+		{desc: "override clamped to floor", owner: reseller, expected: 12},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			req := Request{
+				Owner:          c.owner,
+				GSpec:          defaultGroupSpec(),
+				PricePrecision: DefaultPricePrecision,
+				Inventory:      c.inventory,
+			}
+
+			price, err := pricing.CalculatePrice(context.Background(), req)
+			require.NoError(t, err)
+			require.Equal(t, sdk.NewDec(c.expected), price.Amount)
+		})
+	}
+}
+
+func Test_PricingConfigRejectsInvalid(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  PricingConfig
+	}{
+		{
+			desc: "owner address",
+			cfg:  PricingConfig{Owners: map[string]OwnerPricingConfig{"foo": {Discount: "0.1"}}},
+		},
+		{
+			desc: "owner price",
+			cfg:  PricingConfig{Owners: map[string]OwnerPricingConfig{testutil.AccAddress(t).String(): {Price: "abc"}}},
+		},
+		{
+			desc: "limit denom",
+			cfg:  PricingConfig{Limits: []PriceLimitConfig{{Floor: "1"}}},
+		},
+		{
+			desc: "duplicate limit",
+			cfg:  PricingConfig{Limits: []PriceLimitConfig{{Denom: testutil.CoinDenom}, {Denom: testutil.CoinDenom}}},
+		},
+		{
+			desc: "time of day",
+			cfg:  PricingConfig{Schedule: &ScheduleConfig{Windows: []TimeWindowConfig{{From: "25:00", To: "06:00", Multiplier: "1"}}}},
+		},
+		{
+			desc: "timezone",
+			cfg:  PricingConfig{Schedule: &ScheduleConfig{Timezone: "Nowhere/City", Windows: []TimeWindowConfig{{From: "22:00", To: "06:00", Multiplier: "1"}}}},
+		},
+		{
+			desc: "multiplier",
+			cfg:  PricingConfig{Surge: map[string][]UtilizationTierConfig{UtilizationResourceCPU: {{Threshold: 0.5, Multiplier: "x"}}}},
+		},
+		{
+			desc: "preemptible",
+			cfg:  PricingConfig{Preemptible: "2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			_, err := c.cfg.Wrap(context.Background(), testBidPricingStrategy(1))
+			require.ErrorIs(t, err, errPricingConfigInvalid)
+		})
+	}
+}
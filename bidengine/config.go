@@ -6,6 +6,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
 )
 
 type Config struct {
@@ -14,4 +16,9 @@ type Config struct {
 	BidTimeout      time.Duration
 	Attributes      types.Attributes
 	MaxGroupVolumes int
+	DecisionLogSize int
+	OwnerPolicy     OwnerPolicySource
+	Admission       AdmissionConfig
+	// Preemptible is the attribute class orders opt into preemptible leases with
+	Preemptible ctypes.PreemptibleClass
 }
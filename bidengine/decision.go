@@ -0,0 +1,172 @@
+package bidengine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+)
+
+const (
+	// DefaultDecisionLogSize is the number of decisions kept when not configured
+	DefaultDecisionLogSize = 1000
+)
+
+// DecisionStage is the step of the order lifecycle decision was made at
+type DecisionStage string
+
+const (
+	DecisionStageShouldBid   DecisionStage = "should-bid"
+	DecisionStageReservation DecisionStage = "reservation"
+	DecisionStagePricing     DecisionStage = "pricing"
+	DecisionStageBid         DecisionStage = "bid"
+)
+
+// DecisionReason is the machine-readable code of the decision outcome
+type DecisionReason string
+
+const (
+	DecisionReasonProviderAttributes    DecisionReason = "provider-attributes"
+	DecisionReasonOrderAttributes       DecisionReason = "order-attributes"
+	DecisionReasonResourceCapabilities  DecisionReason = "resource-capabilities"
+	DecisionReasonVolumeCount           DecisionReason = "volume-count"
+	DecisionReasonSignatureRequirements DecisionReason = "signature-requirements"
+	DecisionReasonValidation            DecisionReason = "validation"
+	DecisionReasonOwnerDenied           DecisionReason = "owner-denied"
+	DecisionReasonOwnerNotAllowed       DecisionReason = "owner-not-allowed"
+	DecisionReasonOwnerQuotaLeases      DecisionReason = "owner-quota-leases"
+	DecisionReasonOwnerQuotaCPU         DecisionReason = "owner-quota-cpu"
+	DecisionReasonOwnerQuotaGPU         DecisionReason = "owner-quota-gpu"
+	DecisionReasonOwnerQuotaMemory      DecisionReason = "owner-quota-memory"
+	DecisionReasonCheckFailed           DecisionReason = "check-failed"
+	DecisionReasonAccepted              DecisionReason = "accepted"
+	DecisionReasonReservationFailed     DecisionReason = "reservation-failed"
+	DecisionReasonReserved              DecisionReason = "reserved"
+	DecisionReasonPricingFailed         DecisionReason = "pricing-failed"
+	DecisionReasonUnsupportedDenom      DecisionReason = "unsupported-denomination"
+	DecisionReasonPriceTooHigh          DecisionReason = "price-too-high"
+	DecisionReasonPriced                DecisionReason = "priced"
+	DecisionReasonBidFailed             DecisionReason = "bid-failed"
+	DecisionReasonBidPlaced             DecisionReason = "bid-placed"
+)
+
+// ReservationOutcome is the result of reserving resources for the order
+type ReservationOutcome string
+
+const (
+	ReservationOutcomeNone     ReservationOutcome = ""
+	ReservationOutcomeReserved ReservationOutcome = "reserved"
+	ReservationOutcomeFailed   ReservationOutcome = "failed"
+)
+
+var (
+	decisionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_bid_decision",
+		Help: "The total number of order decisions by stage and reason",
+	}, []string{"stage", "reason"})
+)
+
+// Decision is the record of a single decision made while bidding on an order
+type Decision struct {
+	OrderID     mtypes.OrderID     `json:"order_id"`
+	Timestamp   time.Time          `json:"timestamp"`
+	Stage       DecisionStage      `json:"stage"`
+	Reason      DecisionReason     `json:"reason"`
+	Message     string             `json:"message,omitempty"`
+	Price       *sdk.DecCoin       `json:"price,omitempty"`
+	MaxPrice    *sdk.DecCoin       `json:"max_price,omitempty"`
+	Reservation ReservationOutcome `json:"reservation,omitempty"`
+}
+
+// DecisionFilter selects decisions returned by DecisionsClient.
+// Zero values match everything. Limit caps result to the most recent decisions
+type DecisionFilter struct {
+	Owner string
+	DSeq  uint64
+	Limit int
+}
+
+func (f DecisionFilter) match(d Decision) bool {
+	if f.Owner != "" && f.Owner != d.OrderID.Owner {
+		return false
+	}
+
+	if f.DSeq != 0 && f.DSeq != d.OrderID.DSeq {
+		return false
+	}
+
+	return true
+}
+
+// DecisionsClient queries recent bid decisions
+type DecisionsClient interface {
+	Decisions(context.Context, DecisionFilter) ([]Decision, error)
+}
+
+// decisionLog keeps most recent decisions in a bounded ring buffer
+type decisionLog struct {
+	lock    sync.Mutex
+	entries []Decision
+	next    int
+	full    bool
+}
+
+func newDecisionLog(size int) *decisionLog {
+	if size <= 0 {
+		size = DefaultDecisionLogSize
+	}
+
+	return &decisionLog{
+		entries: make([]Decision, size),
+	}
+}
+
+func (dl *decisionLog) add(d Decision) {
+	if d.Timestamp.IsZero() {
+		d.Timestamp = time.Now().UTC()
+	}
+
+	decisionCounter.WithLabelValues(string(d.Stage), string(d.Reason)).Inc()
+
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	dl.entries[dl.next] = d
+	dl.next = (dl.next + 1) % len(dl.entries)
+	if dl.next == 0 {
+		dl.full = true
+	}
+}
+
+// list returns decisions matching the filter, oldest first
+func (dl *decisionLog) list(filter DecisionFilter) []Decision {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+
+	start := 0
+	count := dl.next
+	if dl.full {
+		start = dl.next
+		count = len(dl.entries)
+	}
+
+	res := make([]Decision, 0)
+	for i := 0; i < count; i++ {
+		d := dl.entries[(start+i)%len(dl.entries)]
+		if filter.match(d) {
+			res = append(res, d)
+		}
+	}
+
+	if filter.Limit > 0 && len(res) > filter.Limit {
+		res = res[len(res)-filter.Limit:]
+	}
+
+	return res
+}
@@ -0,0 +1,64 @@
+package bidengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	"github.com/akash-network/node/testutil"
+)
+
+func testDecision(owner string, dseq uint64, reason DecisionReason) Decision {
+	return Decision{
+		OrderID: mtypes.MakeOrderID(dtypes.MakeGroupID(dtypes.DeploymentID{Owner: owner, DSeq: dseq}, 1), 1),
+		Stage:   DecisionStageShouldBid,
+		Reason:  reason,
+	}
+}
+
+func Test_DecisionLogDefaultSize(t *testing.T) {
+	require.Len(t, newDecisionLog(0).entries, DefaultDecisionLogSize)
+	require.Empty(t, newDecisionLog(0).list(DecisionFilter{}))
+}
+
+func Test_DecisionLogKeepsMostRecent(t *testing.T) {
+	owner := testutil.AccAddress(t).String()
+	dl := newDecisionLog(3)
+
+	for dseq := uint64(1); dseq <= 5; dseq++ {
+		dl.add(testDecision(owner, dseq, DecisionReasonAccepted))
+	}
+
+	decisions := dl.list(DecisionFilter{})
+	require.Len(t, decisions, 3)
+
+	for i, dseq := range []uint64{3, 4, 5} {
+		require.Equal(t, dseq, decisions[i].OrderID.DSeq)
+		require.False(t, decisions[i].Timestamp.IsZero())
+	}
+}
+
+func Test_DecisionLogFilter(t *testing.T) {
+	owner := testutil.AccAddress(t).String()
+	other := testutil.AccAddress(t).String()
+
+	dl := newDecisionLog(10)
+	dl.add(testDecision(owner, 1, DecisionReasonAccepted))
+	dl.add(testDecision(other, 1, DecisionReasonVolumeCount))
+	dl.add(testDecision(owner, 2, DecisionReasonProviderAttributes))
+	dl.add(testDecision(owner, 2, DecisionReasonValidation))
+
+	require.Len(t, dl.list(DecisionFilter{}), 4)
+	require.Len(t, dl.list(DecisionFilter{Owner: owner}), 3)
+	require.Len(t, dl.list(DecisionFilter{DSeq: 1}), 2)
+
+	decisions := dl.list(DecisionFilter{Owner: other})
+	require.Len(t, decisions, 1)
+	require.Equal(t, DecisionReasonVolumeCount, decisions[0].Reason)
+
+	decisions = dl.list(DecisionFilter{Owner: owner, DSeq: 2, Limit: 1})
+	require.Len(t, decisions, 1)
+	require.Equal(t, DecisionReasonValidation, decisions[0].Reason)
+}
@@ -0,0 +1,227 @@
+package bidengine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+)
+
+var (
+	errExchangeBaseEmpty    = errors.New("exchange base denomination cannot be empty")
+	errExchangeRatesNil     = errors.New("exchange rates cannot be nil")
+	errExchangeRateInvalid  = errors.New("exchange rate must be greater than zero")
+	errNoExchangeRate       = errors.New("no exchange rate for denomination")
+	errExchangeDenomInvalid = errors.New("pricing strategy returned price in unexpected denomination")
+)
+
+// ExchangeRates converts prices from the base denomination into denominations orders are placed in
+type ExchangeRates interface {
+	// Rate returns amount of denom equal to one unit of the base denomination
+	Rate(denom string) (decimal.Decimal, bool)
+}
+
+// StaticExchangeRates is a fixed table of exchange rates keyed by denomination
+type StaticExchangeRates map[string]decimal.Decimal
+
+func (sr StaticExchangeRates) Rate(denom string) (decimal.Decimal, bool) {
+	rate, exists := sr[denom]
+	return rate, exists
+}
+
+func (sr StaticExchangeRates) validate() error {
+	for denom, rate := range sr {
+		if !rate.IsPositive() {
+			return fmt.Errorf("%w: %s", errExchangeRateInvalid, denom)
+		}
+	}
+
+	return nil
+}
+
+// ReadExchangeRatesPath reads exchange rates from yaml file holding denom: rate pairs
+//
+//	ibc/170C677610AC31DF0904FFE09CD3B5C657492170E7E52372E48756B71E56F2F1: "0.5"
+func ReadExchangeRatesPath(path string) (StaticExchangeRates, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var val map[string]string
+	if err := yaml.Unmarshal(buf, &val); err != nil {
+		return nil, err
+	}
+
+	return parseExchangeRates(val)
+}
+
+func parseExchangeRates(val map[string]string) (StaticExchangeRates, error) {
+	rates := make(StaticExchangeRates, len(val))
+	for denom, rate := range val {
+		dec, err := decimal.NewFromString(rate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", errExchangeRateInvalid, denom, err)
+		}
+
+		rates[denom] = dec
+	}
+
+	if err := rates.validate(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// fileExchangeRates serves exchange rates from a file reloaded on every change.
+// Rates missing in the file are taken from defaults.
+// File that fails to load keeps previously loaded rates in effect
+type fileExchangeRates struct {
+	path     string
+	defaults StaticExchangeRates
+
+	lock  sync.RWMutex
+	rates StaticExchangeRates
+}
+
+func newFileExchangeRates(path string, defaults StaticExchangeRates) (*fileExchangeRates, error) {
+	rates, err := ReadExchangeRatesPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &fileExchangeRates{
+		path:     path,
+		defaults: defaults,
+		rates:    rates,
+	}
+
+	return res, nil
+}
+
+func (fr *fileExchangeRates) Rate(denom string) (decimal.Decimal, bool) {
+	fr.lock.RLock()
+	rate, exists := fr.rates[denom]
+	fr.lock.RUnlock()
+
+	if exists {
+		return rate, true
+	}
+
+	return fr.defaults.Rate(denom)
+}
+
+func (fr *fileExchangeRates) reload() error {
+	rates, err := ReadExchangeRatesPath(fr.path)
+	if err != nil {
+		return err
+	}
+
+	fr.lock.Lock()
+	fr.rates = rates
+	fr.lock.Unlock()
+
+	return nil
+}
+
+// WatchExchangeRatesPath loads exchange rates from file and keeps them updated until ctx is done
+func WatchExchangeRatesPath(ctx context.Context, path string, defaults StaticExchangeRates) (ExchangeRates, error) {
+	rates, err := newFileExchangeRates(path, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = watchFile(ctx, "exchange-rates", path, rates.reload); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// exchangePricing lets wrapped strategy price orders in the base denomination only.
+// Order's max price is converted into the base denomination before pricing,
+// and calculated price converted back into denomination of the order
+type exchangePricing struct {
+	inner BidPricingStrategy
+	base  string
+	rates ExchangeRates
+}
+
+func MakeExchangePricing(inner BidPricingStrategy, base string, rates ExchangeRates) (BidPricingStrategy, error) {
+	if inner == nil {
+		return nil, errStrategyNil
+	}
+
+	if base == "" {
+		return nil, errExchangeBaseEmpty
+	}
+
+	if rates == nil {
+		return nil, errExchangeRatesNil
+	}
+
+	return exchangePricing{inner: inner, base: base, rates: rates}, nil
+}
+
+func (ep exchangePricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	denom := r.GSpec.Price().Denom
+	if denom == ep.base {
+		return ep.inner.CalculatePrice(ctx, r)
+	}
+
+	rate, exists := ep.rates.Rate(denom)
+	if !exists {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %s", errNoExchangeRate, denom)
+	}
+
+	if !rate.IsPositive() {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %s", errExchangeRateInvalid, denom)
+	}
+
+	rateDec, err := sdk.NewDecFromStr(rate.StringFixed(sdk.Precision))
+	if err != nil || rateDec.IsZero() {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %s", errExchangeRateInvalid, denom)
+	}
+
+	gspec := *r.GSpec
+	gspec.Resources = ep.toBase(r.GSpec.Resources, rateDec)
+
+	req := r
+	req.GSpec = &gspec
+	req.AllocatedResources = ep.toBase(r.AllocatedResources, rateDec)
+
+	price, err := ep.inner.CalculatePrice(ctx, req)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	if price.Denom != ep.base {
+		return sdk.DecCoin{}, fmt.Errorf("%w: expected %s, got %s", errExchangeDenomInvalid, ep.base, price.Denom)
+	}
+
+	return scalePrice(sdk.NewDecCoinFromDec(denom, price.Amount), rate, r.PricePrecision)
+}
+
+// toBase returns copy of resources with prices converted into the base denomination
+func (ep exchangePricing) toBase(resources dtypes.ResourceUnits, rate sdk.Dec) dtypes.ResourceUnits {
+	if resources == nil {
+		return nil
+	}
+
+	res := make(dtypes.ResourceUnits, 0, len(resources))
+	for _, unit := range resources {
+		unit.Price = sdk.NewDecCoinFromDec(ep.base, unit.Price.Amount.Quo(rate))
+		res = append(res, unit)
+	}
+
+	return res
+}
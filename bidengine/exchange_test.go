@@ -0,0 +1,178 @@
+package bidengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/node/sdl"
+	"github.com/akash-network/node/testutil"
+)
+
+const (
+	testStableDenom = "ibc/170C677610AC31DF0904FFE09CD3B5C657492170E7E52372E48756B71E56F2F1"
+)
+
+func stableGroupSpec() *dtypes.GroupSpec {
+	gspec := defaultGroupSpec()
+	for i := range gspec.Resources {
+		gspec.Resources[i].Price = sdk.NewDecCoinFromDec(testStableDenom, gspec.Resources[i].Price.Amount)
+	}
+
+	return gspec
+}
+
+func Test_ExchangePricingRejectsInvalidConfig(t *testing.T) {
+	_, err := MakeExchangePricing(nil, testutil.CoinDenom, StaticExchangeRates{})
+	require.ErrorIs(t, err, errStrategyNil)
+
+	_, err = MakeExchangePricing(testBidPricingStrategy(1), "", StaticExchangeRates{})
+	require.ErrorIs(t, err, errExchangeBaseEmpty)
+
+	_, err = MakeExchangePricing(testBidPricingStrategy(1), testutil.CoinDenom, nil)
+	require.ErrorIs(t, err, errExchangeRatesNil)
+}
+
+func Test_ExchangePricingInBaseDenom(t *testing.T) {
+	pricing, err := MakeExchangePricing(testBidPricingStrategy(7), testutil.CoinDenom, StaticExchangeRates{})
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64DecCoin(testutil.CoinDenom, 7), price)
+}
+
+func Test_ExchangePricingConvertsScalePricing(t *testing.T) {
+	scale, err := MakeScalePricing(decimal.NewFromInt(3), decimal.Zero, make(GPU), Storage{
+		sdl.StorageEphemeral: decimal.Zero,
+	}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	pricing, err := MakeExchangePricing(scale, testutil.CoinDenom, StaticExchangeRates{
+		testStableDenom: decimal.RequireFromString("0.5"),
+	})
+	require.NoError(t, err)
+
+	req := Request{
+		GSpec:          stableGroupSpec(),
+		PricePrecision: DefaultPricePrecision,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, testStableDenom, price.Denom)
+	require.Equal(t, *decPtr(t, "16.5"), price.Amount)
+}
+
+func Test_ExchangePricingConvertsMaxPrice(t *testing.T) {
+	expression, err := MakeExpressionPricing(`denom == "`+testutil.CoinDenom+`" ? max_price : 0`, testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	pricing, err := MakeExchangePricing(expression, testutil.CoinDenom, StaticExchangeRates{
+		testStableDenom: decimal.RequireFromString("0.25"),
+	})
+	require.NoError(t, err)
+
+	// max price of 23 in stable denom is 92 in base, and bid converts back into 23
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: stableGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, testStableDenom, price.Denom)
+	decNearly(t, price.Amount, 23)
+}
+
+func Test_ExchangePricingFailsWithoutRate(t *testing.T) {
+	pricing, err := MakeExchangePricing(testBidPricingStrategy(7), testutil.CoinDenom, StaticExchangeRates{})
+	require.NoError(t, err)
+
+	_, err = pricing.CalculatePrice(context.Background(), Request{GSpec: stableGroupSpec()})
+	require.ErrorIs(t, err, errNoExchangeRate)
+}
+
+func Test_ExchangePricingRejectsInnerDenomMismatch(t *testing.T) {
+	// test strategy always prices in the test denom regardless of the request
+	pricing, err := MakeExchangePricing(testBidPricingStrategy(7), "uother", StaticExchangeRates{
+		testStableDenom: decimal.NewFromInt(1),
+	})
+	require.NoError(t, err)
+
+	_, err = pricing.CalculatePrice(context.Background(), Request{GSpec: stableGroupSpec()})
+	require.ErrorIs(t, err, errExchangeDenomInvalid)
+}
+
+func Test_ExchangeRatesFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.yaml")
+
+	_, err := ReadExchangeRatesPath(path)
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(testStableDenom+`: "-1"`), 0o600))
+	_, err = ReadExchangeRatesPath(path)
+	require.ErrorIs(t, err, errExchangeRateInvalid)
+
+	require.NoError(t, os.WriteFile(path, []byte(testStableDenom+`: "0.5"`), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rates, err := WatchExchangeRatesPath(ctx, path, StaticExchangeRates{"uother": decimal.NewFromInt(2)})
+	require.NoError(t, err)
+
+	rate, exists := rates.Rate(testStableDenom)
+	require.True(t, exists)
+	require.True(t, decimal.RequireFromString("0.5").Equal(rate))
+
+	rate, exists = rates.Rate("uother")
+	require.True(t, exists, "falls back to defaults")
+	require.True(t, decimal.NewFromInt(2).Equal(rate))
+
+	require.NoError(t, os.WriteFile(path, []byte(testStableDenom+`: "0.75"`), 0o600))
+
+	require.Eventually(t, func() bool {
+		rate, _ := rates.Rate(testStableDenom)
+		return decimal.RequireFromString("0.75").Equal(rate)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// invalid content keeps rates in effect
+	require.NoError(t, os.WriteFile(path, []byte(`{garbage`), 0o600))
+	time.Sleep(100 * time.Millisecond)
+
+	rate, exists = rates.Rate(testStableDenom)
+	require.True(t, exists)
+	require.True(t, decimal.RequireFromString("0.75").Equal(rate))
+}
+
+func Test_PricingConfigExchange(t *testing.T) {
+	cfg := PricingConfig{
+		Exchange: &ExchangeConfig{
+			Base: testutil.CoinDenom,
+			Rates: map[string]string{
+				testStableDenom: "2",
+			},
+		},
+		Limits: []PriceLimitConfig{
+			{Denom: testStableDenom, Ceiling: "15"},
+		},
+	}
+
+	pricing, err := cfg.Wrap(context.Background(), testBidPricingStrategy(5))
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: stableGroupSpec(), PricePrecision: DefaultPricePrecision})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64DecCoin(testStableDenom, 10), price)
+
+	price, err = pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec(), PricePrecision: DefaultPricePrecision})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64DecCoin(testutil.CoinDenom, 5), price)
+
+	cfg.Exchange.Rates[testStableDenom] = "abc"
+	_, err = cfg.Wrap(context.Background(), testBidPricingStrategy(5))
+	require.ErrorIs(t, err, errPricingConfigInvalid)
+}
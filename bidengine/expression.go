@@ -0,0 +1,178 @@
+package bidengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	errExpressionEmpty            = errors.New("pricing expression cannot be the empty string")
+	errExpressionInvalid          = errors.New("invalid pricing expression")
+	errExpressionFailure          = errors.New("pricing expression failure")
+	errExpressionRuntimeLimitZero = errors.New("pricing expression runtime limit must be greater than zero")
+)
+
+const (
+	exprKeyResources      = "resources"
+	exprKeyPrice          = "price"
+	exprKeyPricePrecision = "price_precision"
+	exprKeyMaxPrice       = "max_price"
+	exprKeyOwner          = "owner"
+	exprKeyDenom          = "denom"
+	exprKeyInventory      = "inventory"
+)
+
+// expressionPricing evaluates bid price in-process with the expr language (https://expr-lang.org).
+// Expression is given the same document as shell script pricing receives on stdin,
+// extended with owner, denom and max_price (order's max price as a number).
+// Inventory is an empty map until the inventory service reports cluster snapshot
+type expressionPricing struct {
+	program      *vm.Program
+	runtimeLimit time.Duration
+}
+
+func MakeExpressionPricing(expression string, runtimeLimit time.Duration) (BidPricingStrategy, error) {
+	if len(expression) == 0 {
+		return nil, errExpressionEmpty
+	}
+	if runtimeLimit == 0 {
+		return nil, errExpressionRuntimeLimitZero
+	}
+
+	program, err := expr.Compile(expression, expr.Env(expressionEnvTemplate()), expr.AsFloat64())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errExpressionInvalid, err)
+	}
+
+	result := expressionPricing{
+		program:      program,
+		runtimeLimit: runtimeLimit,
+	}
+
+	return result, nil
+}
+
+// expressionEnvTemplate declares all top level variables visible to expression
+// so compile step can reject references to unknown ones
+func expressionEnvTemplate() map[string]interface{} {
+	return map[string]interface{}{
+		exprKeyResources:      []interface{}{},
+		exprKeyPrice:          map[string]interface{}{},
+		exprKeyPricePrecision: float64(0),
+		exprKeyMaxPrice:       float64(0),
+		exprKeyOwner:          "",
+		exprKeyDenom:          "",
+		exprKeyInventory:      map[string]interface{}{},
+	}
+}
+
+func newExpressionEnv(r Request) (map[string]interface{}, error) {
+	d := newDataForScript(r)
+
+	buf := &bytes.Buffer{}
+	if err := json.NewEncoder(buf).Encode(&d); err != nil {
+		return nil, err
+	}
+
+	env := expressionEnvTemplate()
+	if err := json.NewDecoder(buf).Decode(&env); err != nil {
+		return nil, err
+	}
+
+	maxPrice, err := d.Price.Amount.Float64()
+	if err != nil {
+		return nil, err
+	}
+
+	env[exprKeyMaxPrice] = maxPrice
+	env[exprKeyOwner] = r.Owner
+	env[exprKeyDenom] = d.Price.Denom
+
+	return env, nil
+}
+
+// run evaluates program until ctx is done. Expression cannot be interrupted,
+// evaluation abandoned on timeout completes in background bounded by the vm memory budget
+func (ep expressionPricing) run(ctx context.Context, env map[string]interface{}) (interface{}, error) {
+	type result struct {
+		out interface{}
+		err error
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	resultch := make(chan result, 1)
+
+	go func() {
+		out, err := expr.Run(ep.program, env)
+		resultch <- result{out: out, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-resultch:
+		return res.out, res.err
+	}
+}
+
+func (ep expressionPricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	env, err := newExpressionEnv(r)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, ep.runtimeLimit)
+	defer cancel()
+
+	out, err := ep.run(runCtx, env)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %w", errExpressionFailure, err)
+	}
+
+	value, valid := out.(float64)
+	if !valid || math.IsNaN(value) || math.IsInf(value, 0) {
+		return sdk.DecCoin{}, ErrBidQuantityInvalid
+	}
+
+	if value == 0 {
+		return sdk.DecCoin{}, ErrBidZero
+	}
+
+	if value < 0 {
+		return sdk.DecCoin{}, ErrBidQuantityInvalid
+	}
+
+	precision := int32(sdk.Precision)
+	if r.PricePrecision > 0 && r.PricePrecision < sdk.Precision {
+		precision = int32(r.PricePrecision) // nolint: gosec
+	}
+
+	price, err := sdk.NewDecFromStr(decimal.NewFromFloat(value).StringFixed(precision))
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("%w: %w", ErrBidQuantityInvalid, err)
+	}
+
+	if price.IsZero() {
+		return sdk.DecCoin{}, ErrBidZero
+	}
+
+	if !price.LTE(sdk.MaxSortableDec) {
+		return sdk.DecCoin{}, ErrBidQuantityInvalid
+	}
+
+	return sdk.NewDecCoinFromDec(r.GSpec.Price().Denom, price), nil
+}
@@ -0,0 +1,314 @@
+package bidengine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
+	"github.com/akash-network/node/testutil"
+)
+
+const testExpressionRuntimeLimit = time.Second
+
+func Test_ExpressionPricingRejectsEmptyExpression(t *testing.T) {
+	pricing, err := MakeExpressionPricing("", testExpressionRuntimeLimit)
+	require.ErrorIs(t, err, errExpressionEmpty)
+	require.Nil(t, pricing)
+}
+
+func Test_ExpressionPricingRejectsInvalidSyntax(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1 +", testExpressionRuntimeLimit)
+	require.ErrorIs(t, err, errExpressionInvalid)
+	require.Nil(t, pricing)
+}
+
+func Test_ExpressionPricingRejectsUnknownVariable(t *testing.T) {
+	pricing, err := MakeExpressionPricing("cpu_total * 2", testExpressionRuntimeLimit)
+	require.ErrorIs(t, err, errExpressionInvalid)
+	require.Nil(t, pricing)
+}
+
+func Test_ExpressionPricingRejectsNonNumericResult(t *testing.T) {
+	pricing, err := MakeExpressionPricing(`owner == "foo"`, testExpressionRuntimeLimit)
+	require.ErrorIs(t, err, errExpressionInvalid)
+	require.Nil(t, pricing)
+}
+
+func Test_ExpressionPricingRejectsZeroRuntimeLimit(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1.5", 0)
+	require.ErrorIs(t, err, errExpressionRuntimeLimitZero)
+	require.Nil(t, pricing)
+}
+
+func Test_ExpressionPricingHonoursContext(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1.5", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = pricing.CalculatePrice(ctx, req)
+	require.ErrorIs(t, err, errExpressionFailure)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_ExpressionPricingFailsWhenExpressionErrors(t *testing.T) {
+	pricing, err := MakeExpressionPricing(`resources[100].cpu`, testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	_, err = pricing.CalculatePrice(context.Background(), req)
+	require.Error(t, err)
+}
+
+func Test_ExpressionPricingFailsWhenResultIsZero(t *testing.T) {
+	pricing, err := MakeExpressionPricing("0", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.Equal(t, sdk.DecCoin{}, price)
+	require.ErrorIs(t, err, ErrBidZero)
+}
+
+func Test_ExpressionPricingFailsWhenResultIsNegative(t *testing.T) {
+	pricing, err := MakeExpressionPricing("-1.5", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.Equal(t, sdk.DecCoin{}, price)
+	require.ErrorIs(t, err, ErrBidQuantityInvalid)
+}
+
+func Test_ExpressionPricingFailsWhenResultOverflows(t *testing.T) {
+	pricing, err := MakeExpressionPricing("10.0 ** 300", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.Equal(t, sdk.DecCoin{}, price)
+	require.ErrorIs(t, err, ErrBidQuantityInvalid)
+}
+
+func Test_ExpressionPricingFailsWhenResultIsInf(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1 / 0", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.Equal(t, sdk.DecCoin{}, price)
+	require.ErrorIs(t, err, ErrBidQuantityInvalid)
+}
+
+func Test_ExpressionPricingFractionalResult(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1.5", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+
+	expected, err := sdk.NewDecFromStr("1.5")
+	require.NoError(t, err)
+	require.Equal(t, testutil.CoinDenom, price.Denom)
+	require.Equal(t, expected, price.Amount)
+}
+
+func Test_ExpressionPricingRespectsPrecision(t *testing.T) {
+	pricing, err := MakeExpressionPricing("1 / 3", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner:          testutil.AccAddress(t).String(),
+		GSpec:          defaultGroupSpec(),
+		PricePrecision: DefaultPricePrecision,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+
+	expected, err := sdk.NewDecFromStr("0.333333")
+	require.NoError(t, err)
+	require.Equal(t, expected, price.Amount)
+}
+
+func Test_ExpressionPricingOnResources(t *testing.T) {
+	gspec := defaultGroupSpec()
+	gspec.Resources[0].Count = 3
+	gspec.Resources[0].Resources.Endpoints = make(atypes.Endpoints, 2)
+	gspec.Resources[0].Resources.Endpoints = append(gspec.Resources[0].Resources.Endpoints, atypes.Endpoint{
+		Kind:           atypes.Endpoint_LEASED_IP,
+		SequenceNumber: 1,
+	})
+
+	cases := []struct {
+		desc     string
+		expr     string
+		expected int64
+	}{
+		{
+			desc:     "cpu",
+			expr:     "sum(resources, {#.cpu * #.count})",
+			expected: 11 * 3,
+		},
+		{
+			desc:     "memory",
+			expr:     "sum(resources, {#.memory * #.count})",
+			expected: 10000 * 3,
+		},
+		{
+			desc:     "storage",
+			expr:     `sum(resources, {sum(filter(#.storage, {.class == "` + sdl.StorageEphemeral + `"}), {.size}) * #.count})`,
+			expected: 4096 * 3,
+		},
+		{
+			desc:     "endpoints",
+			expr:     "sum(resources, {#.endpoint_quantity})",
+			expected: 3,
+		},
+		{
+			desc:     "leased ips",
+			expr:     "sum(resources, {#.ip_lease_quantity})",
+			expected: 1,
+		},
+		{
+			desc:     "max price",
+			expr:     "max_price - 1",
+			expected: 23*3 - 1,
+		},
+		{
+			desc:     "max price from document",
+			expr:     "float(price.amount)",
+			expected: 23 * 3,
+		},
+		{
+			desc:     "tiered",
+			expr:     "let cpu = sum(resources, {#.cpu * #.count}); cpu > 30 ? cpu * 2 : cpu",
+			expected: 11 * 3 * 2,
+		},
+		{
+			desc:     "denom",
+			expr:     `denom == "` + testutil.CoinDenom + `" ? 7 : 1`,
+			expected: 7,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			pricing, err := MakeExpressionPricing(c.expr, testExpressionRuntimeLimit)
+			require.NoError(t, err)
+
+			req := Request{
+				Owner: testutil.AccAddress(t).String(),
+				GSpec: gspec,
+			}
+
+			price, err := pricing.CalculatePrice(context.Background(), req)
+			require.NoError(t, err)
+			require.Equal(t, testutil.CoinDenom, price.Denom)
+			decNearly(t, price.Amount, c.expected)
+		})
+	}
+}
+
+func Test_ExpressionPricingOnOwner(t *testing.T) {
+	owner := testutil.AccAddress(t).String()
+
+	pricing, err := MakeExpressionPricing(`owner == "`+owner+`" ? 5 : 10`, testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: owner,
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 5)
+
+	req.Owner = testutil.AccAddress(t).String()
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 10)
+}
+
+func Test_ExpressionPricingOnGPU(t *testing.T) {
+	pricing, err := MakeExpressionPricing(`sum(resources, {
+		let model = #.gpu.attributes.vendor?.nvidia?.model ?? "";
+		#.gpu.units * #.count * (model == "a100" ? 100 : 10)
+	})`, testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	gspec := gpuGroupSpec(2, "vendor/nvidia/model/*")
+	allocated := gpuGroupSpec(2, "vendor/nvidia/model/a100")
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: gspec,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 20)
+
+	req.AllocatedResources = allocated.Resources
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 200)
+}
+
+func Test_ExpressionPricingIsReusable(t *testing.T) {
+	pricing, err := MakeExpressionPricing("sum(resources, {#.cpu})", testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	for i := uint64(1); i < 10; i++ {
+		gspec := defaultGroupSpec()
+		gspec.Resources[0].Resources.CPU.Units = atypes.NewResourceValue(i)
+
+		req := Request{
+			Owner: testutil.AccAddress(t).String(),
+			GSpec: gspec,
+		}
+
+		price, err := pricing.CalculatePrice(context.Background(), req)
+		require.NoError(t, err)
+		decNearly(t, price.Amount, int64(i)) // nolint: gosec
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sync/atomic"
 	"time"
 
 	aclient "github.com/akash-network/akash-api/go/node/client/v1beta2"
@@ -17,6 +18,8 @@ import (
 	atypes "github.com/akash-network/akash-api/go/node/audit/v1beta3"
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	provider "github.com/akash-network/akash-api/go/provider/v1"
 	"github.com/akash-network/node/pubsub"
 	metricsutils "github.com/akash-network/node/util/metrics"
 	"github.com/akash-network/node/util/runner"
@@ -37,6 +40,9 @@ type order struct {
 	bus                        pubsub.Bus
 	sub                        pubsub.Subscriber
 	reservationFulfilledNotify chan<- int
+	inventory                  *atomic.Pointer[provider.Inventory]
+	decisions                  *decisionLog
+	admission                  *admissionQueue
 
 	log  log.Logger
 	lc   lifecycle.Lifecycle
@@ -104,6 +110,9 @@ func newOrderInternal(svc *service, oid mtypes.OrderID, cfg Config, pass Provide
 		log:                        log,
 		lc:                         lifecycle.New(),
 		reservationFulfilledNotify: reservationFulfilledNotify, // Normally nil in production
+		inventory:                  &svc.inventory,
+		decisions:                  svc.decisions,
+		admission:                  svc.admission,
 		pass:                       pass,
 	}
 
@@ -173,6 +182,18 @@ func (o *order) run(checkForExistingBid bool) {
 		msg *mtypes.MsgCreateBid
 	)
 
+	calculatePrice := metricsutils.ObserveRunner(func() runner.Result {
+		priceReq := Request{
+			Owner:              group.GroupID.Owner,
+			GSpec:              &group.GroupSpec,
+			PricePrecision:     DefaultPricePrecision,
+			AllocatedResources: reservation.GetAllocatedResources(),
+			Inventory:          o.inventory.Load(),
+			Preemptible:        o.cfg.Preemptible.Matches(group.GroupSpec.Requirements.Attributes),
+		}
+		return runner.NewResult(o.cfg.PricingStrategy.CalculatePrice(ctx, priceReq))
+	}, pricingDuration)
+
 	// Begin fetching group details immediately.
 	groupch = runner.Do(func() runner.Result {
 		res, err := o.session.Client().Query().Group(ctx, &dtypes.QueryGroupRequest{ID: o.orderID.GroupID()})
@@ -315,7 +336,7 @@ loop:
 			group = &res
 
 			shouldBidCh = runner.Do(func() runner.Result {
-				return runner.NewResult(o.shouldBid(group))
+				return runner.NewResult(o.shouldBid(ctx, group))
 			})
 
 		case result := <-shouldBidCh:
@@ -323,14 +344,17 @@ loop:
 
 			if result.Error() != nil {
 				shouldBidCounter.WithLabelValues(metricsutils.FailLabel).Inc()
+				o.decide(DecisionStageShouldBid, DecisionReasonCheckFailed, result.Error().Error())
 				o.log.Error("failure during checking should bid", "err", result.Error())
 				break loop
 			}
 
-			shouldBid := result.Value().(bool)
-			if !shouldBid {
+			reason := result.Value().(DecisionReason)
+			o.decide(DecisionStageShouldBid, reason, "")
+
+			if reason != DecisionReasonAccepted {
 				shouldBidCounter.WithLabelValues("decline").Inc()
-				o.log.Debug("declined to bid")
+				o.log.Debug("declined to bid", "reason", reason)
 				break loop
 			}
 
@@ -338,7 +362,7 @@ loop:
 			o.log.Info("requesting reservation")
 			// Begin reserving resources from cluster.
 			clusterch = runner.Do(metricsutils.ObserveRunner(func() runner.Result {
-				return runner.NewResult(o.cluster.Reserve(o.orderID, group))
+				return runner.NewResult(o.reserve(group))
 			}, reservationDuration))
 
 		case result := <-clusterch:
@@ -346,11 +370,22 @@ loop:
 
 			if result.Error() != nil {
 				reservationCounter.WithLabelValues(metricsutils.OpenLabel, metricsutils.FailLabel)
+				o.record(Decision{
+					Stage:       DecisionStageReservation,
+					Reason:      DecisionReasonReservationFailed,
+					Message:     result.Error().Error(),
+					Reservation: ReservationOutcomeFailed,
+				})
 				o.log.Error("reserving resources", "err", result.Error())
 				break loop
 			}
 
 			reservationCounter.WithLabelValues(metricsutils.OpenLabel, metricsutils.SuccessLabel)
+			o.record(Decision{
+				Stage:       DecisionStageReservation,
+				Reason:      DecisionReasonReserved,
+				Reservation: ReservationOutcomeReserved,
+			})
 
 			o.log.Info("Reservation fulfilled")
 
@@ -369,36 +404,50 @@ loop:
 				// fulfillment already created (state recovered via queryExistingOrders)
 				break
 			}
-			pricech = runner.Do(metricsutils.ObserveRunner(func() runner.Result {
-				// Calculate price & bid
-				priceReq := Request{
-					Owner:              group.GroupID.Owner,
-					GSpec:              &group.GroupSpec,
-					PricePrecision:     DefaultPricePrecision,
-					AllocatedResources: reservation.GetAllocatedResources(),
-				}
-				return runner.NewResult(o.cfg.PricingStrategy.CalculatePrice(ctx, priceReq))
-			}, pricingDuration))
+			// Calculate price & bid
+			pricech = runner.Do(calculatePrice)
 		case result := <-pricech:
 			pricech = nil
+			maxPrice := group.GroupSpec.Price()
+
 			if result.Error() != nil {
+				o.record(Decision{
+					Stage:       DecisionStagePricing,
+					Reason:      DecisionReasonPricingFailed,
+					Message:     result.Error().Error(),
+					MaxPrice:    &maxPrice,
+					Reservation: ReservationOutcomeReserved,
+				})
 				o.log.Error("error calculating price", "err", result.Error())
 				break loop
 			}
 
 			price := result.Value().(sdk.DecCoin)
-			maxPrice := group.GroupSpec.Price()
+
+			pricingDecision := Decision{
+				Stage:       DecisionStagePricing,
+				Reason:      DecisionReasonPriced,
+				Price:       &price,
+				MaxPrice:    &maxPrice,
+				Reservation: ReservationOutcomeReserved,
+			}
 
 			if maxPrice.GetDenom() != price.GetDenom() {
+				pricingDecision.Reason = DecisionReasonUnsupportedDenom
+				o.record(pricingDecision)
 				o.log.Error("Unsupported Denomination", "calculated", price.String(), "max-price", maxPrice.String())
 				break loop
 			}
 
 			if maxPrice.IsLT(price) {
+				pricingDecision.Reason = DecisionReasonPriceTooHigh
+				o.record(pricingDecision)
 				o.log.Info("Price too high, not bidding", "price", price.String(), "max-price", maxPrice.String())
 				break loop
 			}
 
+			o.record(pricingDecision)
+
 			o.log.Debug("submitting fulfillment", "price", price)
 
 			offer := mtypes.ResourceOfferFromRU(reservation.GetAllocatedResources())
@@ -411,14 +460,25 @@ loop:
 
 		case result := <-bidch:
 			bidch = nil
+			bidDecision := Decision{
+				Stage:       DecisionStageBid,
+				Reason:      DecisionReasonBidPlaced,
+				Price:       &msg.Price,
+				Reservation: ReservationOutcomeReserved,
+			}
+
 			if result.Error() != nil {
 				bidCounter.WithLabelValues(metricsutils.OpenLabel, metricsutils.FailLabel).Inc()
+				bidDecision.Reason = DecisionReasonBidFailed
+				bidDecision.Message = result.Error().Error()
+				o.record(bidDecision)
 				o.log.Error("bid failed", "err", result.Error())
 				break loop
 			}
 
 			o.log.Info("bid complete")
 			bidCounter.WithLabelValues(metricsutils.OpenLabel, metricsutils.SuccessLabel).Inc()
+			o.record(bidDecision)
 
 			// Fulfillment placed.
 			bidPlaced = true
@@ -489,49 +549,69 @@ loop:
 	}
 }
 
-func (o *order) shouldBid(group *dtypes.Group) (bool, error) {
+// reserve reserves resources of the group, through admission queue if the group competes for GPUs
+func (o *order) reserve(group *dtypes.Group) (ctypes.Reservation, error) {
+	if o.admission != nil && o.admission.queues(group) {
+		return o.admission.reserve(o.orderID, group)
+	}
+
+	return o.cluster.Reserve(o.orderID, group)
+}
+
+// shouldBid checks if provider is able to bid on the group, returning reason of the decision
+func (o *order) shouldBid(ctx context.Context, group *dtypes.Group) (DecisionReason, error) {
+	reason, err := checkGroupSpec(o.log, o.session.Provider(), o.cfg, o.pass, &group.GroupSpec)
+	if err != nil || reason != DecisionReasonAccepted {
+		return reason, err
+	}
+
+	return checkOwnerPolicy(ctx, o.log, o.cfg.OwnerPolicy, o.cluster, group.GroupID.Owner, &group.GroupSpec)
+}
+
+// checkGroupSpec runs provider and order requirements checks bidding on group spec is subject to
+func checkGroupSpec(log log.Logger, prov *ptypes.Provider, cfg Config, pass ProviderAttrSignatureService, gspec *dtypes.GroupSpec) (DecisionReason, error) {
 	// does provider have required attributes?
-	if !group.GroupSpec.MatchAttributes(o.session.Provider().Attributes) {
-		o.log.Debug("unable to fulfill: incompatible provider attributes")
-		return false, nil
+	if !gspec.MatchAttributes(prov.Attributes) {
+		log.Debug("unable to fulfill: incompatible provider attributes")
+		return DecisionReasonProviderAttributes, nil
 	}
 
 	// does order have required attributes?
-	if !o.cfg.Attributes.SubsetOf(group.GroupSpec.Requirements.Attributes) {
-		o.log.Debug("unable to fulfill: incompatible order attributes")
-		return false, nil
+	if !cfg.Attributes.SubsetOf(gspec.Requirements.Attributes) {
+		log.Debug("unable to fulfill: incompatible order attributes")
+		return DecisionReasonOrderAttributes, nil
 	}
 
-	attr, err := o.pass.GetAttributes()
+	attr, err := pass.GetAttributes()
 	if err != nil {
-		return false, err
+		return "", err
 	}
 
 	// does provider have required capabilities?
-	if !group.GroupSpec.MatchResourcesRequirements(attr) {
-		o.log.Debug("unable to fulfill: incompatible attributes for resources requirements", "wanted", group.GroupSpec, "have", attr)
-		return false, nil
+	if !gspec.MatchResourcesRequirements(attr) {
+		log.Debug("unable to fulfill: incompatible attributes for resources requirements", "wanted", gspec, "have", attr)
+		return DecisionReasonResourceCapabilities, nil
 	}
 
-	for _, resources := range group.GroupSpec.GetResourceUnits() {
-		if len(resources.Resources.Storage) > o.cfg.MaxGroupVolumes {
-			o.log.Info(fmt.Sprintf("unable to fulfill: group volumes count exceeds (%d > %d)", len(resources.Resources.Storage), o.cfg.MaxGroupVolumes))
-			return false, nil
+	for _, resources := range gspec.GetResourceUnits() {
+		if len(resources.Resources.Storage) > cfg.MaxGroupVolumes {
+			log.Info(fmt.Sprintf("unable to fulfill: group volumes count exceeds (%d > %d)", len(resources.Resources.Storage), cfg.MaxGroupVolumes))
+			return DecisionReasonVolumeCount, nil
 		}
 	}
-	signatureRequirements := group.GroupSpec.Requirements.SignedBy
+	signatureRequirements := gspec.Requirements.SignedBy
 	if signatureRequirements.Size() != 0 {
 		// Check that the signature requirements are met for each attribute
 		var provAttr []atypes.Provider
 		ownAttrs := atypes.Provider{
-			Owner:      o.session.Provider().Owner,
+			Owner:      prov.Owner,
 			Auditor:    "",
-			Attributes: o.session.Provider().Attributes,
+			Attributes: prov.Attributes,
 		}
 		provAttr = append(provAttr, ownAttrs)
 		auditors := make([]string, 0)
-		auditors = append(auditors, group.GroupSpec.Requirements.SignedBy.AllOf...)
-		auditors = append(auditors, group.GroupSpec.Requirements.SignedBy.AnyOf...)
+		auditors = append(auditors, gspec.Requirements.SignedBy.AllOf...)
+		auditors = append(auditors, gspec.Requirements.SignedBy.AnyOf...)
 
 		gotten := make(map[string]struct{})
 		for _, auditor := range auditors {
@@ -539,25 +619,39 @@ func (o *order) shouldBid(group *dtypes.Group) (bool, error) {
 			if done {
 				continue
 			}
-			result, err := o.pass.GetAuditorAttributeSignatures(auditor)
+			result, err := pass.GetAuditorAttributeSignatures(auditor)
 			if err != nil {
-				return false, err
+				return "", err
 			}
 			provAttr = append(provAttr, result...)
 			gotten[auditor] = struct{}{}
 		}
 
-		ok := group.GroupSpec.MatchRequirements(provAttr)
+		ok := gspec.MatchRequirements(provAttr)
 		if !ok {
-			o.log.Debug("attribute signature requirements not met")
-			return false, nil
+			log.Debug("attribute signature requirements not met")
+			return DecisionReasonSignatureRequirements, nil
 		}
 	}
 
-	if err := group.GroupSpec.ValidateBasic(); err != nil {
-		o.log.Error("unable to fulfill: group validation error",
+	if err := gspec.ValidateBasic(); err != nil {
+		log.Error("unable to fulfill: group validation error",
 			"err", err)
-		return false, nil
+		return DecisionReasonValidation, nil
 	}
-	return true, nil
+	return DecisionReasonAccepted, nil
+}
+
+func (o *order) decide(stage DecisionStage, reason DecisionReason, message string) {
+	o.record(Decision{
+		Stage:   stage,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// record adds decision made on the order into the decision log
+func (o *order) record(d Decision) {
+	d.OrderID = o.orderID
+	o.decisions.add(d)
 }
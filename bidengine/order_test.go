@@ -323,6 +323,19 @@ func Test_BidOrderPriceTooHigh(t *testing.T) {
 	// Should have called unreserve once, nothing happened after the bid
 	scaffold.cluster.AssertCalled(t, "Unreserve", scaffold.orderID, mock.Anything)
 
+	decisions := order.decisions.list(DecisionFilter{})
+	require.Len(t, decisions, 3)
+	require.Equal(t, DecisionReasonAccepted, decisions[0].Reason)
+	require.Equal(t, DecisionReasonReserved, decisions[1].Reason)
+
+	decision := decisions[2]
+	require.Equal(t, scaffold.orderID, decision.OrderID)
+	require.Equal(t, DecisionStagePricing, decision.Stage)
+	require.Equal(t, DecisionReasonPriceTooHigh, decision.Reason)
+	require.Equal(t, ReservationOutcomeReserved, decision.Reservation)
+	require.NotNil(t, decision.Price)
+	require.NotNil(t, decision.MaxPrice)
+	require.True(t, decision.MaxPrice.IsLT(*decision.Price))
 }
 
 func Test_BidOrderAndThenClosedUnreserve(t *testing.T) {
@@ -719,6 +732,12 @@ func Test_ShouldntBidIfOrderAttrsDontMatch(t *testing.T) {
 
 	// Should not have called unreserve ever, as nothing was ever reserved
 	scaffold.cluster.AssertNotCalled(t, "Unreserve", scaffold.orderID, mock.Anything)
+
+	decisions := order.decisions.list(DecisionFilter{})
+	require.Len(t, decisions, 1)
+	require.Equal(t, DecisionStageShouldBid, decisions[0].Stage)
+	require.Equal(t, DecisionReasonOrderAttributes, decisions[0].Reason)
+	require.Equal(t, ReservationOutcomeNone, decisions[0].Reservation)
 }
 
 // TODO - add test failing the call to Broadcast on TxClient and
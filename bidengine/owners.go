@@ -0,0 +1,309 @@
+package bidengine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/libs/log"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	"github.com/akash-network/provider/cluster"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+var (
+	errOwnerPolicyInvalid = errors.New("invalid owner policy")
+	errOwnerPolicyEmpty   = errors.New("owner policy file is empty")
+)
+
+// OwnerPolicyConfig is the yaml representation of OwnerPolicy.
+// Quota of the owner listed in quotas replaces the default one entirely
+//
+//	allow:
+//	  - akash1...
+//	deny:
+//	  - akash1...
+//	quota:
+//	  leases: 10
+//	  cpu: "32"
+//	  gpu: 4
+//	  memory: 64Gi
+//	quotas:
+//	  akash1...:
+//	    leases: 100
+type OwnerPolicyConfig struct {
+	Allow  []string                    `yaml:"allow"`
+	Deny   []string                    `yaml:"deny"`
+	Quota  OwnerQuotaConfig            `yaml:"quota"`
+	Quotas map[string]OwnerQuotaConfig `yaml:"quotas"`
+}
+
+// OwnerQuotaConfig holds cpu and memory as kubernetes quantities, e.g. "500m" and "4Gi"
+type OwnerQuotaConfig struct {
+	Leases uint64 `yaml:"leases"`
+	CPU    string `yaml:"cpu"`
+	GPU    uint64 `yaml:"gpu"`
+	Memory string `yaml:"memory"`
+}
+
+// OwnerQuota caps resources a single tenant may hold with the provider.
+// Zero value of a field means no limit
+type OwnerQuota struct {
+	// Leases counts both deployed and pending reservations, as every pending one may turn into a lease
+	Leases uint64
+	// CPU in millicpu
+	CPU uint64
+	GPU uint64
+	// Memory in bytes
+	Memory uint64
+}
+
+func (q OwnerQuota) empty() bool {
+	return q == OwnerQuota{}
+}
+
+// OwnerPolicy decides which owners provider bids on orders of and how much each one of them may hold.
+// Empty allow list allows every owner which is not denied
+type OwnerPolicy struct {
+	Allow  map[string]struct{}
+	Deny   map[string]struct{}
+	Quota  OwnerQuota
+	Quotas map[string]OwnerQuota
+}
+
+// OwnerPolicySource provides owner policy currently in effect
+type OwnerPolicySource interface {
+	OwnerPolicy() *OwnerPolicy
+}
+
+var _ OwnerPolicySource = (*OwnerPolicy)(nil)
+
+// OwnerPolicy makes policy a static source of itself
+func (p *OwnerPolicy) OwnerPolicy() *OwnerPolicy {
+	return p
+}
+
+func (p *OwnerPolicy) quota(owner string) OwnerQuota {
+	if quota, exists := p.Quotas[owner]; exists {
+		return quota
+	}
+
+	return p.Quota
+}
+
+// NewOwnerPolicy parses owner policy config
+func NewOwnerPolicy(cfg OwnerPolicyConfig) (*OwnerPolicy, error) {
+	res := &OwnerPolicy{
+		Allow:  make(map[string]struct{}, len(cfg.Allow)),
+		Deny:   make(map[string]struct{}, len(cfg.Deny)),
+		Quotas: make(map[string]OwnerQuota, len(cfg.Quotas)),
+	}
+
+	for _, owner := range cfg.Allow {
+		if owner == "" {
+			return nil, fmt.Errorf("%w: empty owner in allow list", errOwnerPolicyInvalid)
+		}
+
+		res.Allow[owner] = struct{}{}
+	}
+
+	for _, owner := range cfg.Deny {
+		if owner == "" {
+			return nil, fmt.Errorf("%w: empty owner in deny list", errOwnerPolicyInvalid)
+		}
+
+		res.Deny[owner] = struct{}{}
+	}
+
+	var err error
+	if res.Quota, err = cfg.Quota.quota(); err != nil {
+		return nil, err
+	}
+
+	for owner, qcfg := range cfg.Quotas {
+		if res.Quotas[owner], err = qcfg.quota(); err != nil {
+			return nil, fmt.Errorf("%w: owner %s", err, owner)
+		}
+	}
+
+	return res, nil
+}
+
+func (cfg OwnerQuotaConfig) quota() (OwnerQuota, error) {
+	res := OwnerQuota{
+		Leases: cfg.Leases,
+		GPU:    cfg.GPU,
+	}
+
+	if cfg.CPU != "" {
+		val, err := resource.ParseQuantity(cfg.CPU)
+		if err != nil || val.Sign() < 0 {
+			return OwnerQuota{}, fmt.Errorf("%w: cpu quota %q", errOwnerPolicyInvalid, cfg.CPU)
+		}
+
+		res.CPU = uint64(val.MilliValue())
+	}
+
+	if cfg.Memory != "" {
+		val, err := resource.ParseQuantity(cfg.Memory)
+		if err != nil || val.Sign() < 0 {
+			return OwnerQuota{}, fmt.Errorf("%w: memory quota %q", errOwnerPolicyInvalid, cfg.Memory)
+		}
+
+		res.Memory = uint64(val.Value())
+	}
+
+	return res, nil
+}
+
+// ReadOwnerPolicyPath reads owner policy from yaml file.
+// Empty file is refused as it is likely caught in the middle of being rewritten, use {} for no policy
+func ReadOwnerPolicyPath(path string) (*OwnerPolicy, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(buf)) == 0 {
+		return nil, errOwnerPolicyEmpty
+	}
+
+	var cfg OwnerPolicyConfig
+	if err = yaml.Unmarshal(buf, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewOwnerPolicy(cfg)
+}
+
+// fileOwnerPolicy serves owner policy from a file reloaded on every change.
+// File that fails to load keeps previously loaded policy in effect
+type fileOwnerPolicy struct {
+	path   string
+	policy atomic.Pointer[OwnerPolicy]
+}
+
+func (fp *fileOwnerPolicy) OwnerPolicy() *OwnerPolicy {
+	return fp.policy.Load()
+}
+
+func (fp *fileOwnerPolicy) reload() error {
+	policy, err := ReadOwnerPolicyPath(fp.path)
+	if err != nil {
+		return err
+	}
+
+	fp.policy.Store(policy)
+
+	return nil
+}
+
+// WatchOwnerPolicyPath loads owner policy from file and keeps it updated until ctx is done
+func WatchOwnerPolicyPath(ctx context.Context, path string) (OwnerPolicySource, error) {
+	res := &fileOwnerPolicy{
+		path: path,
+	}
+
+	if err := res.reload(); err != nil {
+		return nil, err
+	}
+
+	if err := watchFile(ctx, "owner-policy", path, res.reload); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// groupUsage returns resources requested by the group spec
+func groupUsage(gspec *dtypes.GroupSpec) ctypes.OwnerUsage {
+	usage := ctypes.OwnerUsage{}
+
+	for _, resource := range gspec.GetResourceUnits() {
+		count := uint64(resource.Count)
+
+		if resource.CPU != nil {
+			usage.CPU += resource.CPU.Units.Val.Uint64() * count
+		}
+
+		if resource.GPU != nil {
+			usage.GPU += resource.GPU.Units.Val.Uint64() * count
+		}
+
+		if resource.Memory != nil {
+			usage.Memory += resource.Memory.Quantity.Val.Uint64() * count
+		}
+	}
+
+	return usage
+}
+
+// checkOwnerPolicy checks if provider bids on orders of the owner and if group fits into owner's quota
+// on top of resources the owner already holds with the cluster
+func checkOwnerPolicy(ctx context.Context, log log.Logger, source OwnerPolicySource, cl cluster.Cluster, owner string, gspec *dtypes.GroupSpec) (DecisionReason, error) {
+	if source == nil {
+		return DecisionReasonAccepted, nil
+	}
+
+	policy := source.OwnerPolicy()
+	if policy == nil {
+		return DecisionReasonAccepted, nil
+	}
+
+	if _, denied := policy.Deny[owner]; denied {
+		log.Info("unable to fulfill: owner denied", "owner", owner)
+		return DecisionReasonOwnerDenied, nil
+	}
+
+	if len(policy.Allow) > 0 {
+		if _, allowed := policy.Allow[owner]; !allowed {
+			log.Info("unable to fulfill: owner not allowed", "owner", owner)
+			return DecisionReasonOwnerNotAllowed, nil
+		}
+	}
+
+	quota := policy.quota(owner)
+	if quota.empty() {
+		return DecisionReasonAccepted, nil
+	}
+
+	usage, err := cl.OwnerUsage(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	requested := groupUsage(gspec)
+
+	var reason DecisionReason
+
+	switch {
+	case quota.Leases > 0 && usage.Leases+usage.Pending+1 > quota.Leases:
+		reason = DecisionReasonOwnerQuotaLeases
+	case quota.CPU > 0 && usage.CPU+requested.CPU > quota.CPU:
+		reason = DecisionReasonOwnerQuotaCPU
+	case quota.GPU > 0 && usage.GPU+requested.GPU > quota.GPU:
+		reason = DecisionReasonOwnerQuotaGPU
+	case quota.Memory > 0 && usage.Memory+requested.Memory > quota.Memory:
+		reason = DecisionReasonOwnerQuotaMemory
+	default:
+		return DecisionReasonAccepted, nil
+	}
+
+	log.Info("unable to fulfill: owner quota exceeded",
+		"owner", owner,
+		"reason", reason,
+		"leases", usage.Leases,
+		"pending", usage.Pending,
+		"cpu", usage.CPU,
+		"gpu", usage.GPU,
+		"memory", usage.Memory)
+
+	return reason, nil
+}
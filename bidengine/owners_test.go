@@ -0,0 +1,201 @@
+package bidengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	clustermocks "github.com/akash-network/provider/cluster/mocks"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+const (
+	testOwnerPolicy = `
+allow:
+  - akash1allowed
+  - akash1vip
+deny:
+  - akash1denied
+quota:
+  leases: 2
+  cpu: "4"
+  gpu: 1
+  memory: 8Gi
+quotas:
+  akash1vip:
+    leases: 10
+`
+)
+
+func Test_OwnerPolicyFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testOwnerPolicy), 0o600))
+
+	policy, err := ReadOwnerPolicyPath(path)
+	require.NoError(t, err)
+
+	require.Contains(t, policy.Allow, "akash1allowed")
+	require.Contains(t, policy.Deny, "akash1denied")
+	require.Equal(t, OwnerQuota{
+		Leases: 2,
+		CPU:    4000,
+		GPU:    1,
+		Memory: 8 * 1024 * 1024 * 1024,
+	}, policy.quota("akash1allowed"))
+	require.Equal(t, OwnerQuota{Leases: 10}, policy.quota("akash1vip"))
+
+	_, err = NewOwnerPolicy(OwnerPolicyConfig{Quota: OwnerQuotaConfig{CPU: "lots"}})
+	require.ErrorIs(t, err, errOwnerPolicyInvalid)
+
+	_, err = NewOwnerPolicy(OwnerPolicyConfig{Quotas: map[string]OwnerQuotaConfig{"akash1vip": {Memory: "-1Gi"}}})
+	require.ErrorIs(t, err, errOwnerPolicyInvalid)
+
+	_, err = NewOwnerPolicy(OwnerPolicyConfig{Deny: []string{""}})
+	require.ErrorIs(t, err, errOwnerPolicyInvalid)
+
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	_, err = ReadOwnerPolicyPath(path)
+	require.ErrorIs(t, err, errOwnerPolicyEmpty)
+}
+
+func Test_OwnerPolicyLists(t *testing.T) {
+	log := testutil.Logger(t)
+	gspec := defaultGroupSpec()
+	cl := &clustermocks.Cluster{}
+
+	policy, err := NewOwnerPolicy(OwnerPolicyConfig{
+		Allow: []string{"akash1allowed", "akash1denied"},
+		Deny:  []string{"akash1denied"},
+	})
+	require.NoError(t, err)
+
+	reason, err := checkOwnerPolicy(context.Background(), log, policy, cl, "akash1allowed", gspec)
+	require.NoError(t, err)
+	require.Equal(t, DecisionReasonAccepted, reason)
+
+	reason, err = checkOwnerPolicy(context.Background(), log, policy, cl, "akash1denied", gspec)
+	require.NoError(t, err)
+	require.Equal(t, DecisionReasonOwnerDenied, reason)
+
+	reason, err = checkOwnerPolicy(context.Background(), log, policy, cl, "akash1other", gspec)
+	require.NoError(t, err)
+	require.Equal(t, DecisionReasonOwnerNotAllowed, reason)
+
+	reason, err = checkOwnerPolicy(context.Background(), log, nil, cl, "akash1other", gspec)
+	require.NoError(t, err)
+	require.Equal(t, DecisionReasonAccepted, reason)
+
+	// quota is not configured, so usage is never queried
+	cl.AssertNotCalled(t, "OwnerUsage", mock.Anything, mock.Anything)
+}
+
+func Test_OwnerPolicyQuota(t *testing.T) {
+	log := testutil.Logger(t)
+	gspec := defaultGroupSpec()
+	gspec.Resources[0].Resources.GPU = &atypes.GPU{
+		Units: atypes.NewResourceValue(1),
+	}
+	requested := groupUsage(gspec)
+
+	tests := []struct {
+		name   string
+		quota  OwnerQuota
+		usage  ctypes.OwnerUsage
+		reason DecisionReason
+	}{
+		{
+			name:   "fits",
+			quota:  OwnerQuota{Leases: 2, CPU: requested.CPU * 2},
+			usage:  ctypes.OwnerUsage{Leases: 1, CPU: requested.CPU},
+			reason: DecisionReasonAccepted,
+		},
+		{
+			name:   "leases",
+			quota:  OwnerQuota{Leases: 2},
+			usage:  ctypes.OwnerUsage{Leases: 1, Pending: 1},
+			reason: DecisionReasonOwnerQuotaLeases,
+		},
+		{
+			name:   "cpu",
+			quota:  OwnerQuota{CPU: requested.CPU * 2},
+			usage:  ctypes.OwnerUsage{CPU: requested.CPU + 1},
+			reason: DecisionReasonOwnerQuotaCPU,
+		},
+		{
+			name:   "gpu",
+			quota:  OwnerQuota{GPU: requested.GPU},
+			usage:  ctypes.OwnerUsage{GPU: 1},
+			reason: DecisionReasonOwnerQuotaGPU,
+		},
+		{
+			name:   "memory",
+			quota:  OwnerQuota{Memory: requested.Memory},
+			usage:  ctypes.OwnerUsage{Memory: 1},
+			reason: DecisionReasonOwnerQuotaMemory,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cl := &clustermocks.Cluster{}
+			cl.On("OwnerUsage", mock.Anything, "akash1owner").Return(test.usage, nil)
+
+			policy := &OwnerPolicy{Quota: test.quota}
+
+			reason, err := checkOwnerPolicy(context.Background(), log, policy, cl, "akash1owner", gspec)
+			require.NoError(t, err)
+			require.Equal(t, test.reason, reason)
+		})
+	}
+}
+
+func Test_OwnerPolicyWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("deny: [akash1denied]"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source, err := WatchOwnerPolicyPath(ctx, path)
+	require.NoError(t, err)
+	require.Contains(t, source.OwnerPolicy().Deny, "akash1denied")
+
+	require.NoError(t, os.WriteFile(path, []byte("deny: [akash1other]"), 0o600))
+
+	require.Eventually(t, func() bool {
+		_, denied := source.OwnerPolicy().Deny["akash1other"]
+		return denied
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// invalid content keeps policy in effect
+	require.NoError(t, os.WriteFile(path, []byte(`{garbage`), 0o600))
+	time.Sleep(100 * time.Millisecond)
+
+	require.Contains(t, source.OwnerPolicy().Deny, "akash1other")
+}
+
+func Test_ShouldntBidIfOwnerDenied(t *testing.T) {
+	policy := &OwnerPolicy{
+		Allow: map[string]struct{}{"akash1allowed": {}},
+	}
+
+	order, scaffold, _ := makeOrderForTest(t, false, mtypes.BidStateInvalid, nil, &Config{OwnerPolicy: policy}, testBidCreatedAt)
+
+	<-order.lc.Done() // Stops whenever it figures it shouldn't bid
+
+	scaffold.cluster.AssertNotCalled(t, "Reserve", scaffold.orderID, mock.Anything)
+
+	decisions := order.decisions.list(DecisionFilter{})
+	require.Len(t, decisions, 1)
+	require.Equal(t, DecisionStageShouldBid, decisions[0].Stage)
+	require.Equal(t, DecisionReasonOwnerNotAllowed, decisions[0].Reason)
+}
@@ -3,6 +3,7 @@ package bidengine
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
 	"math/big"
 
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	"github.com/akash-network/akash-api/go/node/types/unit"
 	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	provider "github.com/akash-network/akash-api/go/provider/v1"
 	"github.com/akash-network/node/sdl"
 
 	"github.com/akash-network/provider/cluster/util"
@@ -23,6 +25,10 @@ type Request struct {
 	GSpec              *dtypes.GroupSpec
 	AllocatedResources dtypes.ResourceUnits
 	PricePrecision     int
+	// Inventory is the latest cluster inventory snapshot, nil until the inventory service reports one
+	Inventory *provider.Inventory
+	// Preemptible is set for orders opted into preemptible leases
+	Preemptible bool
 }
 
 const (
@@ -36,6 +42,7 @@ type BidPricingStrategy interface {
 var (
 	errAllScalesZero               = errors.New("at least one bid price must be a non-zero number")
 	errNoPriceScaleForStorageClass = errors.New("no pricing configured for storage class")
+	errNoPriceScaleForGPUModel     = errors.New("no pricing configured for gpu model")
 	errScaleNegative               = errors.New("scale price cannot be negative")
 )
 
@@ -77,9 +84,106 @@ func (ss Storage) AllLessThenOrEqual(val decimal.Decimal) bool {
 	return true
 }
 
+// GPUModelWildcard is the GPU price key matching any vendor and model
+const GPUModelWildcard = "*"
+
+// GPU maps GPU attribute keys to price per unit.
+// Keys follow the SDL attribute format vendor/<vendor>/model/<model>[/ram/<ram>][/interface/<interface>],
+// where model may be a wildcard. GPUModelWildcard key acts as default for any GPU.
+type GPU map[string]decimal.Decimal
+
+func (gs GPU) IsAnyZero() bool {
+	if len(gs) == 0 {
+		return true
+	}
+
+	for _, val := range gs {
+		if val.IsZero() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (gs GPU) IsAnyNegative() bool {
+	for _, val := range gs {
+		if val.IsNegative() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PriceOf looks up price for given GPU vendor and model attributes starting from the most specific key
+// down to the vendor's model wildcard and finally GPUModelWildcard
+func (gs GPU) PriceOf(vendor string, attrs gpuVendorAttributes) (decimal.Decimal, bool) {
+	model := fmt.Sprintf("vendor/%s/model/%s", vendor, attrs.Model)
+
+	keys := make([]string, 0, 6)
+
+	if attrs.RAM != nil && attrs.Interface != nil {
+		keys = append(keys, fmt.Sprintf("%s/ram/%s/interface/%s", model, *attrs.RAM, *attrs.Interface))
+	}
+
+	if attrs.RAM != nil {
+		keys = append(keys, fmt.Sprintf("%s/ram/%s", model, *attrs.RAM))
+	}
+
+	if attrs.Interface != nil {
+		keys = append(keys, fmt.Sprintf("%s/interface/%s", model, *attrs.Interface))
+	}
+
+	keys = append(keys, model, fmt.Sprintf("vendor/%s/model/%s", vendor, GPUModelWildcard), GPUModelWildcard)
+
+	for _, key := range keys {
+		if val, exists := gs[key]; exists {
+			return val, true
+		}
+	}
+
+	return decimal.Decimal{}, false
+}
+
+// unitPrice returns price of a single GPU of the resource.
+// When resource references multiple models (order has not been matched against inventory)
+// the highest price among the models is used
+func (gs GPU) unitPrice(gpu *atypes.GPU) (decimal.Decimal, error) {
+	price := decimal.Zero
+	priced := false
+
+	for _, attr := range gpu.Attributes {
+		vendor, attrs, valid := parseGPUAttribute(attr.Key)
+		if !valid {
+			continue
+		}
+
+		val, exists := gs.PriceOf(vendor, attrs)
+		if !exists {
+			return decimal.Decimal{}, errors.Wrapf(errNoPriceScaleForGPUModel, "vendor/%s/model/%s", vendor, attrs.Model)
+		}
+
+		price = decimal.Max(price, val)
+		priced = true
+	}
+
+	if !priced {
+		val, exists := gs[GPUModelWildcard]
+		if !exists {
+			return decimal.Decimal{}, errors.Wrapf(errNoPriceScaleForGPUModel, "%s", GPUModelWildcard)
+		}
+
+		return val, nil
+	}
+
+	return price, nil
+}
+
 type scalePricing struct {
 	cpuScale      decimal.Decimal
 	memoryScale   decimal.Decimal
+	gpuScale      GPU
 	storageScale  Storage
 	endpointScale decimal.Decimal
 	ipScale       decimal.Decimal
@@ -88,22 +192,25 @@ type scalePricing struct {
 func MakeScalePricing(
 	cpuScale decimal.Decimal,
 	memoryScale decimal.Decimal,
+	gpuScale GPU,
 	storageScale Storage,
 	endpointScale decimal.Decimal,
 	ipScale decimal.Decimal,
 ) (BidPricingStrategy, error) {
-	if cpuScale.IsZero() && memoryScale.IsZero() && storageScale.IsAnyZero() && endpointScale.IsZero() && ipScale.IsZero() {
+	if cpuScale.IsZero() && memoryScale.IsZero() && gpuScale.IsAnyZero() && storageScale.IsAnyZero() &&
+		endpointScale.IsZero() && ipScale.IsZero() {
 		return nil, errAllScalesZero
 	}
 
-	if cpuScale.IsNegative() || memoryScale.IsNegative() || storageScale.IsAnyNegative() || endpointScale.IsNegative() ||
-		ipScale.IsNegative() {
+	if cpuScale.IsNegative() || memoryScale.IsNegative() || gpuScale.IsAnyNegative() || storageScale.IsAnyNegative() ||
+		endpointScale.IsNegative() || ipScale.IsNegative() {
 		return nil, errScaleNegative
 	}
 
 	result := scalePricing{
 		cpuScale:      cpuScale,
 		memoryScale:   memoryScale,
+		gpuScale:      gpuScale,
 		storageScale:  storageScale,
 		endpointScale: endpointScale,
 		ipScale:       ipScale,
@@ -135,6 +242,7 @@ func (fp scalePricing) CalculatePrice(_ context.Context, req Request) (sdk.DecCo
 	// a possible configuration
 	cpuTotal := decimal.NewFromInt(0)
 	memoryTotal := decimal.NewFromInt(0)
+	gpuTotal := decimal.NewFromInt(0)
 	storageTotal := make(Storage)
 	denom := req.GSpec.Price().Denom
 
@@ -186,6 +294,28 @@ func (fp scalePricing) CalculatePrice(_ context.Context, req Request) (sdk.DecCo
 		endpointTotal = endpointTotal.Add(endpointQuantity)
 	}
 
+	// price GPUs from allocated resources if present,
+	// so the bid reflects the exact model the reservation landed on
+	gpuResources := req.GSpec.Resources
+	if len(req.AllocatedResources) > 0 {
+		gpuResources = req.AllocatedResources
+	}
+
+	for _, group := range gpuResources {
+		if group.Resources.GPU == nil || group.Resources.GPU.Units.Value() == 0 {
+			continue
+		}
+
+		unitPrice, err := fp.gpuScale.unitPrice(group.Resources.GPU)
+		if err != nil {
+			return sdk.DecCoin{}, err
+		}
+
+		gpuQuantity := decimal.NewFromBigInt(group.Resources.GPU.Units.Val.BigInt(), 0)
+		gpuQuantity = gpuQuantity.Mul(decimal.NewFromInt(int64(group.Count))) // nolint: gosec
+		gpuTotal = gpuTotal.Add(gpuQuantity.Mul(unitPrice))
+	}
+
 	cpuTotal = cpuTotal.Mul(fp.cpuScale)
 
 	mebibytes := decimal.NewFromInt(unit.Mi)
@@ -208,6 +338,7 @@ func (fp scalePricing) CalculatePrice(_ context.Context, req Request) (sdk.DecCo
 	// and fit into an Int64
 	if cpuTotal.IsNegative() ||
 		memoryTotal.IsNegative() ||
+		gpuTotal.IsNegative() ||
 		storageTotal.IsAnyNegative() ||
 		endpointTotal.IsNegative() ||
 		ipTotal.IsNegative() {
@@ -216,6 +347,7 @@ func (fp scalePricing) CalculatePrice(_ context.Context, req Request) (sdk.DecCo
 
 	totalCost := cpuTotal
 	totalCost = totalCost.Add(memoryTotal)
+	totalCost = totalCost.Add(gpuTotal)
 	for _, total := range storageTotal {
 		totalCost = totalCost.Add(total)
 	}
@@ -358,4 +490,5 @@ type dataForScript struct {
 	Resources      []dataForScriptElement `json:"resources"`
 	Price          sdk.DecCoin            `json:"price"`
 	PricePrecision *int                   `json:"price_precision,omitempty"`
+	Inventory      *inventorySnapshot     `json:"inventory,omitempty"`
 }
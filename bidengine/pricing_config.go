@@ -0,0 +1,364 @@
+package bidengine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	timeOfDayLayout = "15:04"
+)
+
+var (
+	errPricingConfigInvalid = errors.New("invalid pricing config")
+)
+
+// PricingConfig composes configured bid pricing strategy with exchange rates, multipliers,
+// per-owner overrides and price limits. It is read from the "pricing" section of the provider config file
+//
+//	pricing:
+//	  exchange:
+//	    base: uakt
+//	    rates:
+//	      ibc/170C677610AC31DF0904FFE09CD3B5C657492170E7E52372E48756B71E56F2F1: "0.5"
+//	    rates_path: /config/exchange-rates.yaml
+//	  limits:
+//	    - denom: uakt
+//	      floor: "0.5"
+//	      ceiling: "1000"
+//	  schedule:
+//	    timezone: Europe/Berlin
+//	    windows:
+//	      - from: "22:00"
+//	        to: "06:00"
+//	        multiplier: "0.8"
+//	  surge:
+//	    gpu:
+//	      - threshold: 0
+//	        multiplier: "0.7"
+//	      - threshold: 0.3
+//	        multiplier: "1"
+//	      - threshold: 0.9
+//	        multiplier: "2"
+//	  preemptible: "0.6"
+//	  owners:
+//	    akash1...:
+//	      discount: "0.2"
+//	    akash1...:
+//	      price: "10uakt"
+type PricingConfig struct {
+	Exchange *ExchangeConfig                    `yaml:"exchange"`
+	Limits   []PriceLimitConfig                 `yaml:"limits"`
+	Schedule *ScheduleConfig                    `yaml:"schedule"`
+	Surge    map[string][]UtilizationTierConfig `yaml:"surge"`
+	// Preemptible is the multiplier of orders opted into preemptible leases
+	Preemptible string                        `yaml:"preemptible"`
+	Owners      map[string]OwnerPricingConfig `yaml:"owners"`
+}
+
+// ExchangeConfig sets denomination pricing strategies calculate prices in.
+// Rates is the static table of amounts of denomination equal to one unit of Base.
+// RatesPath, if set, is the file with rates in the same format, reloaded on change,
+// which take precedence over the static table
+type ExchangeConfig struct {
+	Base      string            `yaml:"base"`
+	Rates     map[string]string `yaml:"rates"`
+	RatesPath string            `yaml:"rates_path"`
+}
+
+type PriceLimitConfig struct {
+	Denom   string `yaml:"denom"`
+	Floor   string `yaml:"floor"`
+	Ceiling string `yaml:"ceiling"`
+}
+
+type ScheduleConfig struct {
+	Timezone string             `yaml:"timezone"`
+	Windows  []TimeWindowConfig `yaml:"windows"`
+}
+
+type TimeWindowConfig struct {
+	From       string `yaml:"from"`
+	To         string `yaml:"to"`
+	Multiplier string `yaml:"multiplier"`
+}
+
+type UtilizationTierConfig struct {
+	Threshold  float64 `yaml:"threshold"`
+	Multiplier string  `yaml:"multiplier"`
+}
+
+type OwnerPricingConfig struct {
+	Price    string `yaml:"price"`
+	Discount string `yaml:"discount"`
+}
+
+type providerConfigPricing struct {
+	Pricing PricingConfig `yaml:"pricing"`
+}
+
+// ReadPricingConfigPath reads pricing section of the provider config file
+func ReadPricingConfigPath(path string) (PricingConfig, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return PricingConfig{}, err
+	}
+
+	var val providerConfigPricing
+	if err := yaml.Unmarshal(buf, &val); err != nil {
+		return PricingConfig{}, err
+	}
+
+	return val.Pricing, nil
+}
+
+// Wrap returns inner strategy converted into order denomination, scaled by multipliers,
+// then adjusted by owner overrides and finally clamped into price limits.
+// Empty config returns inner strategy as is. Exchange rates file is watched until ctx is done
+func (cfg PricingConfig) Wrap(ctx context.Context, inner BidPricingStrategy) (BidPricingStrategy, error) {
+	if cfg.Exchange != nil {
+		var err error
+		if inner, err = cfg.Exchange.wrap(ctx, inner); err != nil {
+			return nil, err
+		}
+	}
+
+	var multipliers []PriceMultiplier
+
+	if cfg.Schedule != nil && len(cfg.Schedule.Windows) > 0 {
+		multiplier, err := cfg.Schedule.multiplier()
+		if err != nil {
+			return nil, err
+		}
+
+		multipliers = append(multipliers, multiplier)
+	}
+
+	if len(cfg.Surge) > 0 {
+		surge := make(map[string][]UtilizationTier, len(cfg.Surge))
+		for name, tcfg := range cfg.Surge {
+			tiers, err := parseUtilizationTiers(tcfg)
+			if err != nil {
+				return nil, err
+			}
+
+			surge[name] = tiers
+		}
+
+		multiplier, err := MakeSurgeMultiplier(surge)
+		if err != nil {
+			return nil, err
+		}
+
+		multipliers = append(multipliers, multiplier)
+	}
+
+	if cfg.Preemptible != "" {
+		val, err := decimal.NewFromString(cfg.Preemptible)
+		if err != nil {
+			return nil, fmt.Errorf("%w: preemptible multiplier: %w", errPricingConfigInvalid, err)
+		}
+
+		multiplier, err := MakePreemptibleMultiplier(val)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errPricingConfigInvalid, err)
+		}
+
+		multipliers = append(multipliers, multiplier)
+	}
+
+	result, err := MakeMultiplierPricing(inner, multipliers...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Owners) > 0 {
+		owners := make(map[string]OwnerPricing, len(cfg.Owners))
+		for owner, ocfg := range cfg.Owners {
+			if _, err := sdk.AccAddressFromBech32(owner); err != nil {
+				return nil, fmt.Errorf("%w: owner %q: %w", errPricingConfigInvalid, owner, err)
+			}
+
+			val, err := ocfg.ownerPricing()
+			if err != nil {
+				return nil, fmt.Errorf("%w: owner %q: %w", errPricingConfigInvalid, owner, err)
+			}
+
+			owners[owner] = val
+		}
+
+		result, err = MakeOwnerPricing(result, owners)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.Limits) > 0 {
+		limits := make(map[string]PriceLimit, len(cfg.Limits))
+		for _, lcfg := range cfg.Limits {
+			if lcfg.Denom == "" {
+				return nil, fmt.Errorf("%w: price limit denom cannot be empty", errPricingConfigInvalid)
+			}
+
+			if _, exists := limits[lcfg.Denom]; exists {
+				return nil, fmt.Errorf("%w: duplicate price limit for %s", errPricingConfigInvalid, lcfg.Denom)
+			}
+
+			limit, err := lcfg.priceLimit()
+			if err != nil {
+				return nil, fmt.Errorf("%w: price limit for %s: %w", errPricingConfigInvalid, lcfg.Denom, err)
+			}
+
+			limits[lcfg.Denom] = limit
+		}
+
+		result, err = MakeClampPricing(result, limits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (cfg ExchangeConfig) wrap(ctx context.Context, inner BidPricingStrategy) (BidPricingStrategy, error) {
+	static, err := parseExchangeRates(cfg.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errPricingConfigInvalid, err)
+	}
+
+	var rates ExchangeRates = static
+
+	if cfg.RatesPath != "" {
+		if rates, err = WatchExchangeRatesPath(ctx, cfg.RatesPath, static); err != nil {
+			return nil, fmt.Errorf("%w: %w", errPricingConfigInvalid, err)
+		}
+	}
+
+	return MakeExchangePricing(inner, cfg.Base, rates)
+}
+
+func (cfg ScheduleConfig) multiplier() (PriceMultiplier, error) {
+	location := time.UTC
+	if cfg.Timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: %w", errPricingConfigInvalid, err)
+		}
+	}
+
+	windows := make([]TimeWindow, 0, len(cfg.Windows))
+	for _, wcfg := range cfg.Windows {
+		from, err := parseTimeOfDay(wcfg.From)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := parseTimeOfDay(wcfg.To)
+		if err != nil {
+			return nil, err
+		}
+
+		// midnight as the end of the window means end of the day
+		if to == 0 {
+			to = 24 * time.Hour
+		}
+
+		multiplier, err := parseMultiplier(wcfg.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, TimeWindow{From: from, To: to, Multiplier: multiplier})
+	}
+
+	return MakeTimeOfDayMultiplier(windows, location)
+}
+
+func (cfg OwnerPricingConfig) ownerPricing() (OwnerPricing, error) {
+	result := OwnerPricing{}
+
+	if cfg.Price != "" {
+		price, err := sdk.ParseDecCoins(cfg.Price)
+		if err != nil {
+			return OwnerPricing{}, err
+		}
+
+		result.Price = price
+	}
+
+	if cfg.Discount != "" {
+		discount, err := decimal.NewFromString(cfg.Discount)
+		if err != nil {
+			return OwnerPricing{}, err
+		}
+
+		result.Discount = discount
+	}
+
+	return result, nil
+}
+
+func (cfg PriceLimitConfig) priceLimit() (PriceLimit, error) {
+	result := PriceLimit{}
+
+	if cfg.Floor != "" {
+		floor, err := sdk.NewDecFromStr(cfg.Floor)
+		if err != nil {
+			return PriceLimit{}, err
+		}
+
+		result.Floor = &floor
+	}
+
+	if cfg.Ceiling != "" {
+		ceiling, err := sdk.NewDecFromStr(cfg.Ceiling)
+		if err != nil {
+			return PriceLimit{}, err
+		}
+
+		result.Ceiling = &ceiling
+	}
+
+	return result, nil
+}
+
+func parseUtilizationTiers(cfg []UtilizationTierConfig) ([]UtilizationTier, error) {
+	tiers := make([]UtilizationTier, 0, len(cfg))
+	for _, tier := range cfg {
+		val, err := parseMultiplier(tier.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+
+		tiers = append(tiers, UtilizationTier{Threshold: tier.Threshold, Multiplier: val})
+	}
+
+	return tiers, nil
+}
+
+func parseTimeOfDay(val string) (time.Duration, error) {
+	tm, err := time.Parse(timeOfDayLayout, val)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time of day %q must be in HH:MM format", errPricingConfigInvalid, val)
+	}
+
+	return time.Duration(tm.Hour())*time.Hour + time.Duration(tm.Minute())*time.Minute, nil
+}
+
+func parseMultiplier(val string) (decimal.Decimal, error) {
+	multiplier, err := decimal.NewFromString(val)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("%w: multiplier %q: %w", errPricingConfigInvalid, val, err)
+	}
+
+	return multiplier, nil
+}
@@ -32,28 +32,28 @@ import (
 )
 
 func Test_ScalePricingRejectsAllZero(t *testing.T) {
-	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NotNil(t, err)
 	require.Nil(t, pricing)
 }
 
 func Test_ScalePricingAcceptsOneForASingleScale(t *testing.T) {
-	pricing, err := MakeScalePricing(decimal.NewFromInt(1), decimal.Zero, make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.NewFromInt(1), decimal.Zero, make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
-	pricing, err = MakeScalePricing(decimal.Zero, decimal.NewFromInt(1), make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err = MakeScalePricing(decimal.Zero, decimal.NewFromInt(1), make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
 	storageScale := Storage{
 		"": decimal.NewFromInt(1),
 	}
-	pricing, err = MakeScalePricing(decimal.Zero, decimal.Zero, storageScale, decimal.Zero, decimal.Zero)
+	pricing, err = MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), storageScale, decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
-	pricing, err = MakeScalePricing(decimal.Zero, decimal.Zero, make(Storage), decimal.NewFromInt(1), decimal.Zero)
+	pricing, err = MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), make(Storage), decimal.NewFromInt(1), decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 }
@@ -128,7 +128,7 @@ func Test_ScalePricingFailsOnOverflow(t *testing.T) {
 		sdl.StorageEphemeral: decimal.NewFromInt(1),
 	}
 
-	pricing, err := MakeScalePricing(decimal.New(math.MaxInt64, 2), decimal.Zero, storageScale, decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.New(math.MaxInt64, 2), decimal.Zero, make(GPU), storageScale, decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -146,7 +146,7 @@ func Test_ScalePricingFailsOnOverflow(t *testing.T) {
 func Test_ScalePricingOnCpu(t *testing.T) {
 	cpuScale := decimal.NewFromInt(22)
 
-	pricing, err := MakeScalePricing(cpuScale, decimal.Zero, make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(cpuScale, decimal.Zero, make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -170,7 +170,7 @@ func Test_ScalePricingOnCpu(t *testing.T) {
 func Test_ScalePricingOnMemory(t *testing.T) {
 	memoryScale := uint64(23)
 	memoryPrice := decimal.NewFromInt(int64(memoryScale)).Mul(decimal.NewFromInt(unit.Mi))
-	pricing, err := MakeScalePricing(decimal.Zero, memoryPrice, make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.Zero, memoryPrice, make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -193,7 +193,7 @@ func Test_ScalePricingOnMemory(t *testing.T) {
 func Test_ScalePricingOnMemoryLessThanOne(t *testing.T) {
 	memoryScale := uint64(1) // 1 uakt per megabyte
 	memoryPrice := decimal.NewFromInt(int64(memoryScale))
-	pricing, err := MakeScalePricing(decimal.Zero, memoryPrice, make(Storage), decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.Zero, memoryPrice, make(GPU), make(Storage), decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -244,7 +244,7 @@ func Test_ScalePricingOnStorage(t *testing.T) {
 		sdl.StorageEphemeral: decimal.NewFromInt(int64(storageScale)).Mul(decimal.NewFromInt(unit.Mi)),
 	}
 
-	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, storagePrice, decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), storagePrice, decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -267,7 +267,7 @@ func Test_ScalePricingByCountOfResources(t *testing.T) {
 		sdl.StorageEphemeral: decimal.NewFromInt(int64(storageScale)).Mul(decimal.NewFromInt(unit.Mi)),
 	}
 
-	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, storagePrice, decimal.Zero, decimal.Zero)
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), storagePrice, decimal.Zero, decimal.Zero)
 	require.NoError(t, err)
 	require.NotNil(t, pricing)
 
@@ -294,7 +294,7 @@ func Test_ScalePricingForIPs(t *testing.T) {
 	ipPriceInt := int64(testutil.RandRangeInt(100, 1000))
 	ipPrice := decimal.NewFromInt(ipPriceInt)
 
-	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, Storage{
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, make(GPU), Storage{
 		sdl.StorageEphemeral: decimal.Zero,
 	}, decimal.Zero, ipPrice)
 	require.NoError(t, err)
@@ -334,6 +334,162 @@ func Test_ScalePricingForIPs(t *testing.T) {
 	decNearly(t, price.Amount, 2*ipPriceInt)
 }
 
+func gpuGroupSpec(units uint64, attrs ...string) *dtypes.GroupSpec {
+	gspec := defaultGroupSpec()
+	gspec.Resources[0].Resources.GPU.Units = atypes.NewResourceValue(units)
+
+	for _, attr := range attrs {
+		gspec.Resources[0].Resources.GPU.Attributes = append(gspec.Resources[0].Resources.GPU.Attributes, atypes.Attribute{
+			Key:   attr,
+			Value: "true",
+		})
+	}
+
+	return gspec
+}
+
+func Test_ScalePricingOnGPU(t *testing.T) {
+	gpuScale := GPU{
+		"vendor/nvidia/model/a100":               decimal.NewFromInt(100),
+		"vendor/nvidia/model/a100/ram/80Gi":      decimal.NewFromInt(150),
+		"vendor/nvidia/model/*":                  decimal.NewFromInt(70),
+		"vendor/nvidia/model/h100/interface/sxm": decimal.NewFromInt(300),
+		GPUModelWildcard:                         decimal.NewFromInt(10),
+	}
+
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, gpuScale, Storage{
+		sdl.StorageEphemeral: decimal.Zero,
+	}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	require.NotNil(t, pricing)
+
+	cases := []struct {
+		desc     string
+		attrs    []string
+		expected int64
+	}{
+		{
+			desc:     "exact model",
+			attrs:    []string{"vendor/nvidia/model/a100"},
+			expected: 100,
+		},
+		{
+			desc:     "model with ram",
+			attrs:    []string{"vendor/nvidia/model/a100/ram/80Gi"},
+			expected: 150,
+		},
+		{
+			desc:     "model with unknown ram falls back to model",
+			attrs:    []string{"vendor/nvidia/model/a100/ram/40Gi"},
+			expected: 100,
+		},
+		{
+			desc:     "model with interface",
+			attrs:    []string{"vendor/nvidia/model/h100/interface/sxm"},
+			expected: 300,
+		},
+		{
+			desc:     "vendor wildcard",
+			attrs:    []string{"vendor/nvidia/model/t4"},
+			expected: 70,
+		},
+		{
+			desc:     "global wildcard",
+			attrs:    []string{"vendor/amd/model/mi100"},
+			expected: 10,
+		},
+		{
+			desc:     "no attributes",
+			expected: 10,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			req := Request{
+				Owner: testutil.AccAddress(t).String(),
+				GSpec: gpuGroupSpec(2, c.attrs...),
+			}
+
+			price, err := pricing.CalculatePrice(context.Background(), req)
+			require.NoError(t, err)
+			decNearly(t, price.Amount, 2*c.expected)
+
+			req.GSpec.Resources[0].Count = 3
+			price, err = pricing.CalculatePrice(context.Background(), req)
+			require.NoError(t, err)
+			decNearly(t, price.Amount, 6*c.expected)
+		})
+	}
+}
+
+func Test_ScalePricingOnGPUUsesAllocatedResources(t *testing.T) {
+	gpuScale := GPU{
+		"vendor/nvidia/model/a100": decimal.NewFromInt(100),
+		"vendor/nvidia/model/t4":   decimal.NewFromInt(20),
+	}
+
+	pricing, err := MakeScalePricing(decimal.Zero, decimal.Zero, gpuScale, Storage{
+		sdl.StorageEphemeral: decimal.Zero,
+	}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	require.NotNil(t, pricing)
+
+	gspec := gpuGroupSpec(1, "vendor/nvidia/model/a100", "vendor/nvidia/model/t4")
+
+	allocated := gpuGroupSpec(1, "vendor/nvidia/model/t4")
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: gspec,
+	}
+
+	// highest price among requested models applies until order is matched against inventory
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 100)
+
+	req.AllocatedResources = allocated.Resources
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 20)
+}
+
+func Test_ScalePricingOnGPUFailsWithoutPrice(t *testing.T) {
+	gpuScale := GPU{
+		"vendor/nvidia/model/a100": decimal.NewFromInt(100),
+	}
+
+	pricing, err := MakeScalePricing(decimal.NewFromInt(1), decimal.Zero, gpuScale, Storage{
+		sdl.StorageEphemeral: decimal.Zero,
+	}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+	require.NotNil(t, pricing)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: gpuGroupSpec(1, "vendor/nvidia/model/h100"),
+	}
+
+	_, err = pricing.CalculatePrice(context.Background(), req)
+	require.ErrorIs(t, err, errNoPriceScaleForGPUModel)
+
+	// orders without GPUs are still priced
+	req.GSpec = defaultGroupSpec()
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 11)
+}
+
+func Test_ScalePricingRejectsNegativeGPU(t *testing.T) {
+	pricing, err := MakeScalePricing(decimal.NewFromInt(1), decimal.Zero, GPU{
+		GPUModelWildcard: decimal.NewFromInt(-1),
+	}, make(Storage), decimal.Zero, decimal.Zero)
+	require.ErrorIs(t, err, errScaleNegative)
+	require.Nil(t, pricing)
+}
+
 func Test_ScriptPricingRejectsEmptyStringForPath(t *testing.T) {
 	pricing, err := MakeShellScriptPricing("", 1, 30000*time.Millisecond)
 	require.NotNil(t, err)
@@ -649,8 +805,9 @@ func Test_ScriptPricingWritesJsonToStdin(t *testing.T) {
 
 	gspec := defaultGroupSpec()
 	req := Request{
-		Owner: testutil.AccAddress(t).String(),
-		GSpec: gspec,
+		Owner:     testutil.AccAddress(t).String(),
+		GSpec:     gspec,
+		Inventory: utilizationInventory(6000, 1),
 	}
 
 	price, err := pricing.CalculatePrice(context.Background(), req)
@@ -678,6 +835,11 @@ func Test_ScriptPricingWritesJsonToStdin(t *testing.T) {
 		require.Equal(t, len(r.Resources.Endpoints), data.Resources[i].EndpointQuantity)
 		require.Equal(t, util.GetEndpointQuantityOfResourceUnits(r.Resources, atypes.Endpoint_LEASED_IP), data.Resources[i].IPLeaseQuantity)
 	}
+
+	require.NotNil(t, data.Inventory)
+	require.Equal(t, 1, data.Inventory.Nodes)
+	require.Equal(t, inventoryResource{Allocatable: 10000, Allocated: 6000, Utilization: 0.6}, data.Inventory.CPU)
+	require.Equal(t, inventoryResource{Allocatable: 4, Allocated: 1, Utilization: 0.25}, data.Inventory.GPU)
 }
 
 func Test_ScriptPricingFromScript(t *testing.T) {
@@ -0,0 +1,319 @@
+package bidengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+)
+
+var (
+	errEndpointInvalid           = errors.New("pricing service endpoint must be a valid http(s) url")
+	errRequestTimeoutZero        = errors.New("pricing service request timeout must be greater than zero")
+	errConcurrencyLimitZero      = errors.New("pricing service concurrency limit must be greater than zero")
+	errBreakerCooldownZero       = errors.New("pricing service circuit breaker cooldown must be greater than zero")
+	errPricingServiceUnavailable = errors.New("pricing service unavailable")
+	errPricingServiceResponse    = errors.New("pricing service invalid response")
+	errCircuitOpen               = errors.New("circuit breaker is open")
+)
+
+const (
+	remotePricingMaxResponseSize = 64 * 1024
+)
+
+var (
+	remotePricingCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_bid_pricing_remote",
+		Help: "The total number of requests to the remote pricing service",
+	}, []string{"result"})
+)
+
+// RemotePricingConfig configures pricing strategy querying external pricing service
+type RemotePricingConfig struct {
+	// Endpoint is URL pricing requests are POSTed to
+	Endpoint string
+	// Timeout limits duration of each single request
+	Timeout time.Duration
+	// Retries is the number of additional attempts made when the service is unavailable
+	Retries uint
+	// RetryDelay is the initial delay between attempts, doubled with each retry
+	RetryDelay time.Duration
+	// ConcurrencyLimit limits number of requests in flight
+	ConcurrencyLimit uint
+	// BreakerThreshold is the number of consecutive failed requests after which circuit opens
+	// and no requests are made until BreakerCooldown elapses. Zero disables circuit breaker
+	BreakerThreshold uint
+	BreakerCooldown  time.Duration
+	// Fallback, if set, calculates price when the service is unavailable
+	Fallback BidPricingStrategy
+}
+
+type remotePricingRequest struct {
+	dataForScript
+	Owner              string               `json:"owner"`
+	Denom              string               `json:"denom"`
+	AllocatedResources dtypes.ResourceUnits `json:"allocated_resources,omitempty"`
+}
+
+type remotePricingResponse struct {
+	Price json.Number `json:"price"`
+}
+
+type remotePricing struct {
+	endpoint         string
+	timeout          time.Duration
+	retries          uint
+	retryDelay       time.Duration
+	concurrencyLimit chan struct{}
+	breaker          *circuitBreaker
+	fallback         BidPricingStrategy
+	client           *http.Client
+}
+
+func MakeRemotePricing(cfg RemotePricingConfig) (BidPricingStrategy, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, errEndpointInvalid
+	}
+
+	if cfg.Timeout == 0 {
+		return nil, errRequestTimeoutZero
+	}
+
+	if cfg.ConcurrencyLimit == 0 {
+		return nil, errConcurrencyLimitZero
+	}
+
+	if cfg.BreakerThreshold > 0 && cfg.BreakerCooldown == 0 {
+		return nil, errBreakerCooldownZero
+	}
+
+	result := &remotePricing{
+		endpoint:         endpoint.String(),
+		timeout:          cfg.Timeout,
+		retries:          cfg.Retries,
+		retryDelay:       cfg.RetryDelay,
+		concurrencyLimit: make(chan struct{}, cfg.ConcurrencyLimit),
+		breaker:          newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
+		fallback:         cfg.Fallback,
+		client:           &http.Client{},
+	}
+
+	return result, nil
+}
+
+func (rp *remotePricing) CalculatePrice(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	price, err := rp.query(ctx, r)
+	if err == nil {
+		remotePricingCounter.WithLabelValues("success").Inc()
+		return price, nil
+	}
+
+	if !errors.Is(err, errPricingServiceUnavailable) {
+		remotePricingCounter.WithLabelValues("invalid").Inc()
+		return sdk.DecCoin{}, err
+	}
+
+	remotePricingCounter.WithLabelValues("unavailable").Inc()
+
+	if rp.fallback == nil || ctx.Err() != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	remotePricingCounter.WithLabelValues("fallback").Inc()
+
+	return rp.fallback.CalculatePrice(ctx, r)
+}
+
+func (rp *remotePricing) query(ctx context.Context, r Request) (sdk.DecCoin, error) {
+	denom := r.GSpec.Price().Denom
+
+	body, err := json.Marshal(&remotePricingRequest{
+		dataForScript:      newDataForScript(r),
+		Owner:              r.Owner,
+		Denom:              denom,
+		AllocatedResources: r.AllocatedResources,
+	})
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	// Limit number of concurrent requests to the service
+	select {
+	case rp.concurrencyLimit <- struct{}{}:
+	case <-ctx.Done():
+		return sdk.DecCoin{}, ctx.Err()
+	}
+
+	defer func() {
+		<-rp.concurrencyLimit
+	}()
+
+	var price sdk.Dec
+
+	err = retry.Do(func() error {
+		if !rp.breaker.allow() {
+			return fmt.Errorf("%w: %w", errPricingServiceUnavailable, errCircuitOpen)
+		}
+
+		var err error
+		price, err = rp.do(ctx, body)
+
+		// only service availability issues count towards opening the circuit
+		rp.breaker.done(!errors.Is(err, errPricingServiceUnavailable))
+
+		return err
+	},
+		retry.Attempts(rp.retries+1),
+		retry.Delay(rp.retryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			return errors.Is(err, errPricingServiceUnavailable) && !errors.Is(err, errCircuitOpen)
+		}),
+		retry.Context(ctx),
+	)
+	if err != nil {
+		return sdk.DecCoin{}, err
+	}
+
+	return sdk.NewDecCoinFromDec(denom, price), nil
+}
+
+func (rp *remotePricing) do(ctx context.Context, body []byte) (sdk.Dec, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, rp.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, rp.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := rp.client.Do(req)
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("%w: %w", errPricingServiceUnavailable, err)
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return sdk.Dec{}, fmt.Errorf("%w: status %s", errPricingServiceUnavailable, resp.Status)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return sdk.Dec{}, fmt.Errorf("%w: status %s", errPricingServiceResponse, resp.Status)
+	}
+
+	result := remotePricingResponse{}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, remotePricingMaxResponseSize)).Decode(&result); err != nil {
+		if reqCtx.Err() != nil {
+			return sdk.Dec{}, fmt.Errorf("%w: %w", errPricingServiceUnavailable, err)
+		}
+
+		return sdk.Dec{}, fmt.Errorf("%w: %w", errPricingServiceResponse, err)
+	}
+
+	if result.Price == "" {
+		return sdk.Dec{}, fmt.Errorf("%w: price must be set: %w", errPricingServiceResponse, ErrBidQuantityInvalid)
+	}
+
+	price, err := sdk.NewDecFromStr(result.Price.String())
+	if err != nil {
+		return sdk.Dec{}, fmt.Errorf("%w: %w%w", errPricingServiceResponse, err, ErrBidQuantityInvalid)
+	}
+
+	if price.IsZero() {
+		return sdk.Dec{}, ErrBidZero
+	}
+
+	if price.IsNegative() || !price.LTE(sdk.MaxSortableDec) {
+		return sdk.Dec{}, ErrBidQuantityInvalid
+	}
+
+	return price, nil
+}
+
+// circuitBreaker stops requests to the failing service for a cooldown period
+// once the number of consecutive failures reaches the threshold.
+// After cooldown a single trial request is let through (half-open state),
+// success closes circuit and failure re-opens it for another cooldown period.
+type circuitBreaker struct {
+	threshold uint
+	cooldown  time.Duration
+
+	lock     sync.Mutex
+	failures uint
+	openedAt time.Time
+	trial    bool
+	now      func() time.Time
+}
+
+func newCircuitBreaker(threshold uint, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+func (cb *circuitBreaker) allow() bool {
+	if cb.threshold == 0 {
+		return true
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if cb.failures < cb.threshold {
+		return true
+	}
+
+	if cb.trial || cb.now().Sub(cb.openedAt) < cb.cooldown {
+		return false
+	}
+
+	cb.trial = true
+
+	return true
+}
+
+func (cb *circuitBreaker) done(success bool) {
+	if cb.threshold == 0 {
+		return
+	}
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	cb.trial = false
+
+	if success {
+		cb.failures = 0
+		return
+	}
+
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		cb.openedAt = cb.now()
+	}
+}
@@ -0,0 +1,359 @@
+package bidengine
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akash-network/node/sdl"
+	"github.com/akash-network/node/testutil"
+)
+
+func testRemotePricingConfig(endpoint string) RemotePricingConfig {
+	return RemotePricingConfig{
+		Endpoint:         endpoint,
+		Timeout:          time.Second,
+		Retries:          0,
+		RetryDelay:       time.Millisecond,
+		ConcurrencyLimit: 1,
+	}
+}
+
+func newRemotePricingServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func Test_RemotePricingRejectsInvalidConfig(t *testing.T) {
+	cfg := testRemotePricingConfig("")
+	_, err := MakeRemotePricing(cfg)
+	require.ErrorIs(t, err, errEndpointInvalid)
+
+	cfg = testRemotePricingConfig("ftp://localhost")
+	_, err = MakeRemotePricing(cfg)
+	require.ErrorIs(t, err, errEndpointInvalid)
+
+	cfg = testRemotePricingConfig("http://localhost")
+	cfg.Timeout = 0
+	_, err = MakeRemotePricing(cfg)
+	require.ErrorIs(t, err, errRequestTimeoutZero)
+
+	cfg = testRemotePricingConfig("http://localhost")
+	cfg.ConcurrencyLimit = 0
+	_, err = MakeRemotePricing(cfg)
+	require.ErrorIs(t, err, errConcurrencyLimitZero)
+
+	cfg = testRemotePricingConfig("http://localhost")
+	cfg.BreakerThreshold = 1
+	_, err = MakeRemotePricing(cfg)
+	require.ErrorIs(t, err, errBreakerCooldownZero)
+}
+
+func Test_RemotePricingReturnsResultFromService(t *testing.T) {
+	gspec := defaultGroupSpec()
+	owner := testutil.AccAddress(t).String()
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		data := remotePricingRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+
+		require.Equal(t, owner, data.Owner)
+		require.Equal(t, testutil.CoinDenom, data.Denom)
+		require.Len(t, data.Resources, len(gspec.Resources))
+		require.Len(t, data.AllocatedResources, len(gspec.Resources))
+
+		for i, r := range gspec.Resources {
+			require.Equal(t, r.Resources.CPU.Units.Val.Uint64(), data.Resources[i].CPU)
+			require.Equal(t, r.Resources.Memory.Quantity.Val.Uint64(), data.Resources[i].Memory)
+			require.Equal(t, r.Count, data.Resources[i].Count)
+			require.Equal(t, len(r.Resources.Endpoints), data.Resources[i].EndpointQuantity)
+		}
+
+		_, _ = io.WriteString(w, `{"price":"13.5"}`)
+	})
+
+	pricing, err := MakeRemotePricing(testRemotePricingConfig(server.URL))
+	require.NoError(t, err)
+
+	req := Request{
+		Owner:              owner,
+		GSpec:              gspec,
+		AllocatedResources: gspec.Resources,
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+
+	expected, err := sdk.NewDecFromStr("13.5")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoinFromDec(testutil.CoinDenom, expected), price)
+}
+
+func Test_RemotePricingAcceptsNumericPrice(t *testing.T) {
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"price":7}`)
+	})
+
+	pricing, err := MakeRemotePricing(testRemotePricingConfig(server.URL))
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(7), price.Amount)
+}
+
+func Test_RemotePricingFailsOnMalformedResponse(t *testing.T) {
+	cases := []struct {
+		desc     string
+		status   int
+		response string
+		err      error
+	}{
+		{
+			desc:     "not json",
+			status:   http.StatusOK,
+			response: "100",
+			err:      errPricingServiceResponse,
+		},
+		{
+			desc:     "garbage",
+			status:   http.StatusOK,
+			response: "{price",
+			err:      errPricingServiceResponse,
+		},
+		{
+			desc:     "missing price",
+			status:   http.StatusOK,
+			response: `{}`,
+			err:      ErrBidQuantityInvalid,
+		},
+		{
+			desc:     "non numeric price",
+			status:   http.StatusOK,
+			response: `{"price":"abc"}`,
+			err:      errPricingServiceResponse,
+		},
+		{
+			desc:     "zero price",
+			status:   http.StatusOK,
+			response: `{"price":"0"}`,
+			err:      ErrBidZero,
+		},
+		{
+			desc:     "negative price",
+			status:   http.StatusOK,
+			response: `{"price":"-1"}`,
+			err:      ErrBidQuantityInvalid,
+		},
+		{
+			desc:     "client error",
+			status:   http.StatusBadRequest,
+			response: `{"price":"1"}`,
+			err:      errPricingServiceResponse,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(c.status)
+				_, _ = io.WriteString(w, c.response)
+			})
+
+			cfg := testRemotePricingConfig(server.URL)
+			cfg.Fallback = randomRangePricing(0)
+
+			pricing, err := MakeRemotePricing(cfg)
+			require.NoError(t, err)
+
+			price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+			require.ErrorIs(t, err, c.err)
+			require.Equal(t, sdk.DecCoin{}, price)
+		})
+	}
+}
+
+func Test_RemotePricingTimesOut(t *testing.T) {
+	done := make(chan struct{})
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+		_, _ = io.WriteString(w, `{"price":"1"}`)
+	})
+
+	// unblock handlers before server shuts down
+	t.Cleanup(func() { close(done) })
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.Timeout = 50 * time.Millisecond
+
+	pricing, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.ErrorIs(t, err, errPricingServiceUnavailable)
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func Test_RemotePricingRetriesWhenUnavailable(t *testing.T) {
+	var calls atomic.Int32
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"price":"2"}`)
+	})
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.Retries = 2
+
+	pricing, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(2), price.Amount)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func Test_RemotePricingDoesNotRetryMalformedResponse(t *testing.T) {
+	var calls atomic.Int32
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = io.WriteString(w, `{"price":"abc"}`)
+	})
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.Retries = 5
+
+	pricing, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	_, err = pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.ErrorIs(t, err, errPricingServiceResponse)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func Test_RemotePricingFallsBackWhenUnavailable(t *testing.T) {
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	fallback, err := MakeScalePricing(decimal.NewFromInt(3), decimal.Zero, make(GPU), Storage{
+		sdl.StorageEphemeral: decimal.Zero,
+	}, decimal.Zero, decimal.Zero)
+	require.NoError(t, err)
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.Fallback = fallback
+
+	pricing, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	price, err := pricing.CalculatePrice(context.Background(), Request{GSpec: defaultGroupSpec()})
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 3*11)
+}
+
+func Test_RemotePricingCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, `{"price":"1"}`)
+	})
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.BreakerThreshold = 2
+	cfg.BreakerCooldown = time.Hour
+
+	res, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	pricing := res.(*remotePricing)
+
+	now := time.Now()
+	pricing.breaker.now = func() time.Time { return now }
+
+	req := Request{GSpec: defaultGroupSpec()}
+
+	for i := 0; i < 2; i++ {
+		_, err = pricing.CalculatePrice(context.Background(), req)
+		require.ErrorIs(t, err, errPricingServiceUnavailable)
+	}
+	require.Equal(t, int32(2), calls.Load())
+
+	// circuit is open, service is not called
+	_, err = pricing.CalculatePrice(context.Background(), req)
+	require.ErrorIs(t, err, errCircuitOpen)
+	require.Equal(t, int32(2), calls.Load())
+
+	// after cooldown trial request is let through and closes circuit on success
+	healthy.Store(true)
+	now = now.Add(cfg.BreakerCooldown)
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(1), price.Amount)
+	require.Equal(t, int32(3), calls.Load())
+
+	_, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), calls.Load())
+}
+
+func Test_RemotePricingStopsByContext(t *testing.T) {
+	done := make(chan struct{})
+
+	server := newRemotePricingServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+		}
+		_, _ = io.WriteString(w, `{"price":"1"}`)
+	})
+
+	// unblock handlers before server shuts down
+	t.Cleanup(func() { close(done) })
+
+	cfg := testRemotePricingConfig(server.URL)
+	cfg.Timeout = time.Minute
+	cfg.Fallback = randomRangePricing(0)
+
+	pricing, err := MakeRemotePricing(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = pricing.CalculatePrice(ctx, Request{GSpec: defaultGroupSpec()})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync/atomic"
 
 	sclient "github.com/akash-network/akash-api/go/node/client/v1beta2"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
@@ -55,6 +56,8 @@ var (
 // Service handles bidding on orders.
 type Service interface {
 	StatusClient
+	DecisionsClient
+	SimulateClient
 	Close() error
 	Done() <-chan struct{}
 }
@@ -63,6 +66,10 @@ type Service interface {
 func NewService(pctx context.Context, aqc sclient.QueryClient, session session.Session, cluster cluster.Cluster, bus pubsub.Bus, waiter waiter.OperatorWaiter, cfg Config) (Service, error) {
 	session = session.ForModule("bidengine-service")
 
+	if err := cfg.Admission.validate(); err != nil {
+		return nil, err
+	}
+
 	sub, err := bus.Subscribe()
 	if err != nil {
 		return nil, err
@@ -93,6 +100,12 @@ func NewService(pctx context.Context, aqc sclient.QueryClient, session session.S
 		waiter:   waiter,
 	}
 
+	s.decisions = newDecisionLog(cfg.DecisionLogSize)
+
+	if cfg.Admission.enabled() {
+		s.admission = newAdmissionQueue(ctx, session.Log(), cluster, cfg.Admission)
+	}
+
 	go s.lc.WatchContext(ctx)
 	go s.run(pctx)
 	group.Go(func() error {
@@ -125,6 +138,13 @@ type service struct {
 	pass   *providerAttrSignatureService
 
 	waiter waiter.OperatorWaiter
+
+	// latest inventory snapshot handed to pricing strategies
+	inventory atomic.Pointer[provider.Inventory]
+
+	decisions *decisionLog
+	// orders competing for GPUs are reserved through it, nil if disabled
+	admission *admissionQueue
 }
 
 func (s *service) Close() error {
@@ -166,6 +186,14 @@ func (s *service) StatusV1(ctx context.Context) (*provider.BidEngineStatus, erro
 	return &provider.BidEngineStatus{Orders: res.Orders}, nil
 }
 
+func (s *service) Decisions(ctx context.Context, filter DecisionFilter) ([]Decision, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.decisions.list(filter), nil
+}
+
 func (s *service) updateOrderManagerGauge() {
 	orderManagerGauge.Set(float64(len(s.orders)))
 }
@@ -266,6 +294,9 @@ func (s *service) run(ctx context.Context) {
 
 	bus := fromctx.MustPubSubFromCtx(ctx)
 
+	inventorych := bus.Sub(ptypes.PubSubTopicInventoryStatus)
+	defer bus.Unsub(inventorych)
+
 	signalch := make(chan struct{}, 1)
 	trySignal := func() {
 		select {
@@ -321,6 +352,10 @@ loop:
 				s.orders[key] = order
 				trySignal()
 			}
+		case update := <-inventorych:
+			if inv, valid := update.(*provider.Inventory); valid {
+				s.inventory.Store(inv)
+			}
 		case ch := <-s.statusch:
 			ch <- &Status{
 				Orders: uint32(len(s.orders)), // nolint: gosec
@@ -68,42 +68,47 @@ func parseGPU(resource *atypes.GPU) gpuElement {
 	}
 
 	for _, attr := range resource.Attributes {
-		tokens := strings.Split(attr.Key, "/")
+		if vendor, attrs, valid := parseGPUAttribute(attr.Key); valid {
+			res.Attributes.Vendor[vendor] = attrs
+		}
+	}
 
-		// vendor/nvidia/model/a100
-		switch tokens[0] {
-		case "vendor":
-			vendor := tokens[1]
-			model := tokens[3]
+	return res
+}
 
-			tokens = tokens[4:]
+// parseGPUAttribute reads vendor and model of GPU attribute key, e.g. vendor/nvidia/model/a100/ram/80Gi
+func parseGPUAttribute(key string) (string, gpuVendorAttributes, bool) {
+	tokens := strings.Split(key, "/")
 
-			attrs := gpuVendorAttributes{
-				Model: model,
-			}
+	if len(tokens) < 4 || tokens[0] != "vendor" {
+		return "", gpuVendorAttributes{}, false
+	}
 
-			for i := 0; i < len(tokens); i += 2 {
-				key := tokens[i]
-				val := tokens[i+1]
-
-				switch key {
-				case "ram":
-					attrs.RAM = new(string)
-					*attrs.RAM = val
-				case "interface":
-					attrs.Interface = new(string)
-					*attrs.Interface = val
-				default:
-					continue
-				}
-			}
+	vendor := tokens[1]
 
-			res.Attributes.Vendor[vendor] = attrs
+	attrs := gpuVendorAttributes{
+		Model: tokens[3],
+	}
+
+	tokens = tokens[4:]
+
+	for i := 0; i+1 < len(tokens); i += 2 {
+		key := tokens[i]
+		val := tokens[i+1]
+
+		switch key {
+		case "ram":
+			attrs.RAM = new(string)
+			*attrs.RAM = val
+		case "interface":
+			attrs.Interface = new(string)
+			*attrs.Interface = val
 		default:
+			continue
 		}
 	}
 
-	return res
+	return vendor, attrs, true
 }
 
 func parseStorage(resource atypes.Volumes) []storageElement {
@@ -193,6 +198,7 @@ func newDataForScript(r Request) dataForScript {
 	d := dataForScript{
 		Resources: make([]dataForScriptElement, len(r.GSpec.Resources)),
 		Price:     r.GSpec.Price(),
+		Inventory: newInventorySnapshot(r.Inventory),
 	}
 
 	if r.PricePrecision > 0 {
@@ -0,0 +1,148 @@
+package bidengine
+
+import (
+	"context"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+// Simulation is the outcome of bidding on a group spec without reserving resources or placing the bid.
+// Stage and Reason carry the decision simulation stopped at, DecisionReasonPriced means provider would bid
+type Simulation struct {
+	Bid        bool                 `json:"bid"`
+	Stage      DecisionStage        `json:"stage"`
+	Reason     DecisionReason       `json:"reason"`
+	Message    string               `json:"message,omitempty"`
+	Price      *sdk.DecCoin         `json:"price,omitempty"`
+	MaxPrice   sdk.DecCoin          `json:"max_price"`
+	Placements []SimulatedPlacement `json:"placements,omitempty"`
+	Resources  dtypes.ResourceUnits `json:"resources,omitempty"`
+}
+
+// SimulatedPlacement describes where replicas of the resource unit would be deployed
+type SimulatedPlacement struct {
+	ResourceID uint32   `json:"resource_id"`
+	Nodes      []string `json:"nodes,omitempty"`
+	GPUVendor  string   `json:"gpu_vendor,omitempty"`
+	GPUModel   string   `json:"gpu_model,omitempty"`
+}
+
+// SimulateClient runs the bidding process on a group spec as a dry run
+type SimulateClient interface {
+	Simulate(ctx context.Context, owner string, gspec dtypes.GroupSpec) (Simulation, error)
+}
+
+func (s *service) Simulate(ctx context.Context, owner string, gspec dtypes.GroupSpec) (Simulation, error) {
+	log := s.session.Log().With("cmp", "bid-simulation", "owner", owner)
+
+	res := Simulation{
+		Stage:    DecisionStageShouldBid,
+		MaxPrice: gspec.Price(),
+	}
+
+	reason, err := checkGroupSpec(log, s.session.Provider(), s.cfg, s.pass, &gspec)
+	if err != nil {
+		return Simulation{}, err
+	}
+
+	if reason == DecisionReasonAccepted {
+		if reason, err = checkOwnerPolicy(ctx, log, s.cfg.OwnerPolicy, s.cluster, owner, &gspec); err != nil {
+			return Simulation{}, err
+		}
+	}
+
+	res.Reason = reason
+	if reason != DecisionReasonAccepted {
+		return res, nil
+	}
+
+	res.Stage = DecisionStageReservation
+
+	reservation, err := s.cluster.ReserveDryRun(mtypes.OrderID{Owner: owner}, &gspec)
+	if err != nil {
+		res.Reason = DecisionReasonReservationFailed
+		res.Message = err.Error()
+		return res, nil
+	}
+
+	res.Resources = reservation.GetAllocatedResources()
+	res.Placements = simulatedPlacements(reservation)
+
+	res.Stage = DecisionStagePricing
+
+	price, err := s.cfg.PricingStrategy.CalculatePrice(ctx, Request{
+		Owner:              owner,
+		GSpec:              &gspec,
+		AllocatedResources: res.Resources,
+		PricePrecision:     DefaultPricePrecision,
+		Inventory:          s.inventory.Load(),
+		Preemptible:        s.cfg.Preemptible.Matches(gspec.Requirements.Attributes),
+	})
+	if err != nil {
+		res.Reason = DecisionReasonPricingFailed
+		res.Message = err.Error()
+		return res, nil
+	}
+
+	res.Price = &price
+
+	switch {
+	case res.MaxPrice.GetDenom() != price.GetDenom():
+		res.Reason = DecisionReasonUnsupportedDenom
+	case res.MaxPrice.IsLT(price):
+		res.Reason = DecisionReasonPriceTooHigh
+	default:
+		res.Reason = DecisionReasonPriced
+		res.Bid = true
+	}
+
+	return res, nil
+}
+
+func simulatedPlacements(reservation ctypes.ReservationGroup) []SimulatedPlacement {
+	placements := make(map[uint32]*SimulatedPlacement)
+
+	get := func(id uint32) *SimulatedPlacement {
+		if _, exists := placements[id]; !exists {
+			placements[id] = &SimulatedPlacement{ResourceID: id}
+		}
+
+		return placements[id]
+	}
+
+	if rp, valid := reservation.(ctypes.ReservationPlacement); valid {
+		for id, nodes := range rp.Placement() {
+			get(id).Nodes = nodes
+		}
+	}
+
+	if cparams, valid := reservation.ClusterParams().(crd.ReservationClusterSettings); valid {
+		for id, sparams := range cparams {
+			if sparams == nil || sparams.Resources == nil || sparams.Resources.GPU == nil {
+				continue
+			}
+
+			placement := get(id)
+			placement.GPUVendor = sparams.Resources.GPU.Vendor
+			placement.GPUModel = sparams.Resources.GPU.Model
+		}
+	}
+
+	res := make([]SimulatedPlacement, 0, len(placements))
+	for _, placement := range placements {
+		res = append(res, *placement)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ResourceID < res[j].ResourceID
+	})
+
+	return res
+}
@@ -0,0 +1,144 @@
+package bidengine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	tpubsub "github.com/troian/pubsub"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	"github.com/akash-network/akash-api/go/node/types/constants"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	"github.com/akash-network/node/pubsub"
+	"github.com/akash-network/node/testutil"
+
+	clustermocks "github.com/akash-network/provider/cluster/mocks"
+	clmocks "github.com/akash-network/provider/cluster/types/v1beta3/mocks"
+	"github.com/akash-network/provider/operator/waiter"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+	"github.com/akash-network/provider/session"
+	"github.com/akash-network/provider/tools/fromctx"
+)
+
+func makeServiceForSimulate(t *testing.T, pricing BidPricingStrategy) (*service, orderTestScaffold) {
+	var scaffold orderTestScaffold
+	makeMocks(&scaffold)
+
+	scaffold.testAddr = testutil.AccAddress(t)
+	scaffold.cluster = &clustermocks.Cluster{}
+
+	myProvider := &ptypes.Provider{
+		Owner: scaffold.testAddr.String(),
+	}
+	mySession := session.New(testutil.Logger(t), scaffold.client, myProvider, testBidCreatedAt)
+
+	cfg := Config{
+		PricingStrategy: pricing,
+		MaxGroupVolumes: constants.DefaultMaxGroupVolumes,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = context.WithValue(ctx, fromctx.CtxKeyPubSub, tpubsub.New(ctx, 1000))
+
+	svc, err := NewService(ctx, scaffold.queryClient, mySession, scaffold.cluster, pubsub.NewBus(), waiter.NewNullWaiter(), cfg)
+	require.NoError(t, err)
+
+	t.Cleanup(cancel)
+
+	return svc.(*service), scaffold
+}
+
+func simulateGroupSpec(t *testing.T, scaffold orderTestScaffold) dtypes.GroupSpec {
+	res, err := scaffold.queryClient.Group(context.Background(), &dtypes.QueryGroupRequest{})
+	require.NoError(t, err)
+
+	return res.Group.GroupSpec
+}
+
+func simulateReservation(gspec dtypes.GroupSpec) *clmocks.Reservation {
+	reservation := &clmocks.Reservation{}
+	reservation.On("GetAllocatedResources").Return(gspec.Resources)
+	reservation.On("ClusterParams").Return(crd.ReservationClusterSettings{
+		gspec.Resources[0].ID: &crd.SchedulerParams{
+			Resources: &crd.SchedulerResources{
+				GPU: &crd.SchedulerResourceGPU{
+					Vendor: "nvidia",
+					Model:  "a100",
+				},
+			},
+		},
+	})
+
+	return reservation
+}
+
+func Test_SimulateBid(t *testing.T) {
+	svc, scaffold := makeServiceForSimulate(t, testBidPricingStrategy(1))
+	gspec := simulateGroupSpec(t, scaffold)
+
+	scaffold.cluster.On("ReserveDryRun", mock.Anything, mock.Anything).Return(simulateReservation(gspec), nil)
+
+	res, err := svc.Simulate(context.Background(), scaffold.testAddr.String(), gspec)
+	require.NoError(t, err)
+	require.True(t, res.Bid)
+	require.Equal(t, DecisionStagePricing, res.Stage)
+	require.Equal(t, DecisionReasonPriced, res.Reason)
+	require.NotNil(t, res.Price)
+	require.Equal(t, gspec.Resources, res.Resources)
+	require.Equal(t, []SimulatedPlacement{
+		{
+			ResourceID: gspec.Resources[0].ID,
+			GPUVendor:  "nvidia",
+			GPUModel:   "a100",
+		},
+	}, res.Placements)
+
+	scaffold.cluster.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+}
+
+func Test_SimulateBidPriceTooHigh(t *testing.T) {
+	svc, scaffold := makeServiceForSimulate(t, testBidPricingStrategy(9999999999))
+	gspec := simulateGroupSpec(t, scaffold)
+
+	scaffold.cluster.On("ReserveDryRun", mock.Anything, mock.Anything).Return(simulateReservation(gspec), nil)
+
+	res, err := svc.Simulate(context.Background(), scaffold.testAddr.String(), gspec)
+	require.NoError(t, err)
+	require.False(t, res.Bid)
+	require.Equal(t, DecisionReasonPriceTooHigh, res.Reason)
+	require.True(t, res.MaxPrice.IsLT(*res.Price))
+}
+
+func Test_SimulateBidReservationFailed(t *testing.T) {
+	svc, scaffold := makeServiceForSimulate(t, testBidPricingStrategy(1))
+	gspec := simulateGroupSpec(t, scaffold)
+
+	scaffold.cluster.On("ReserveDryRun", mock.Anything, mock.Anything).Return(nil, errors.New("insufficient capacity"))
+
+	res, err := svc.Simulate(context.Background(), scaffold.testAddr.String(), gspec)
+	require.NoError(t, err)
+	require.False(t, res.Bid)
+	require.Equal(t, DecisionStageReservation, res.Stage)
+	require.Equal(t, DecisionReasonReservationFailed, res.Reason)
+	require.Equal(t, "insufficient capacity", res.Message)
+	require.Nil(t, res.Price)
+}
+
+func Test_SimulateBidProviderAttributes(t *testing.T) {
+	svc, scaffold := makeServiceForSimulate(t, testBidPricingStrategy(1))
+	gspec := simulateGroupSpec(t, scaffold)
+	gspec.Requirements.Attributes = append(gspec.Requirements.Attributes, atypes.Attribute{Key: "region", Value: "nowhere"})
+
+	res, err := svc.Simulate(context.Background(), scaffold.testAddr.String(), gspec)
+	require.NoError(t, err)
+	require.False(t, res.Bid)
+	require.Equal(t, DecisionStageShouldBid, res.Stage)
+	require.Equal(t, DecisionReasonProviderAttributes, res.Reason)
+
+	scaffold.cluster.AssertNotCalled(t, "ReserveDryRun", mock.Anything, mock.Anything)
+}
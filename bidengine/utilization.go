@@ -0,0 +1,226 @@
+package bidengine
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	provider "github.com/akash-network/akash-api/go/provider/v1"
+)
+
+const (
+	UtilizationResourceCPU    = "cpu"
+	UtilizationResourceMemory = "memory"
+	UtilizationResourceGPU    = "gpu"
+)
+
+var (
+	errSurgeResourceUnknown   = errors.New("surge pricing resource must be one of cpu, memory or gpu")
+	errUtilizationTierInvalid = errors.New("utilization threshold must be within [0, 1]")
+)
+
+type inventoryResource struct {
+	Allocatable uint64  `json:"allocatable"`
+	Allocated   uint64  `json:"allocated"`
+	Utilization float64 `json:"utilization"`
+}
+
+type inventoryReservations struct {
+	Pending uint32 `json:"pending"`
+	Active  uint32 `json:"active"`
+}
+
+// inventorySnapshot summarizes cluster inventory for pricing.
+// Units match resources of the order: cpu in millicpu, memory in bytes, gpu in units.
+// Allocated amounts include resources of pending reservations, inventory service adjusts them into nodes
+type inventorySnapshot struct {
+	Nodes        int                   `json:"nodes"`
+	CPU          inventoryResource     `json:"cpu"`
+	Memory       inventoryResource     `json:"memory"`
+	GPU          inventoryResource     `json:"gpu"`
+	Reservations inventoryReservations `json:"reservations"`
+}
+
+func newInventorySnapshot(inv *provider.Inventory) *inventorySnapshot {
+	if inv == nil {
+		return nil
+	}
+
+	value := func(val *resource.Quantity, milli bool) uint64 {
+		if val == nil || val.Sign() < 0 {
+			return 0
+		}
+
+		if milli {
+			return uint64(val.MilliValue()) // nolint: gosec
+		}
+
+		return uint64(val.Value()) // nolint: gosec
+	}
+
+	res := &inventorySnapshot{
+		Nodes: len(inv.Cluster.Nodes),
+		Reservations: inventoryReservations{
+			Pending: inv.Reservations.Pending.Count,
+			Active:  inv.Reservations.Active.Count,
+		},
+	}
+
+	for _, node := range inv.Cluster.Nodes {
+		res.CPU.Allocatable += value(node.Resources.CPU.Quantity.Allocatable, true)
+		res.CPU.Allocated += value(node.Resources.CPU.Quantity.Allocated, true)
+		res.Memory.Allocatable += value(node.Resources.Memory.Quantity.Allocatable, false)
+		res.Memory.Allocated += value(node.Resources.Memory.Quantity.Allocated, false)
+		res.GPU.Allocatable += value(node.Resources.GPU.Quantity.Allocatable, false)
+		res.GPU.Allocated += value(node.Resources.GPU.Quantity.Allocated, false)
+	}
+
+	for _, rs := range []*inventoryResource{&res.CPU, &res.Memory, &res.GPU} {
+		if rs.Allocatable == 0 {
+			continue
+		}
+
+		rs.Utilization = float64(rs.Allocated) / float64(rs.Allocatable)
+		if rs.Utilization > 1 {
+			rs.Utilization = 1
+		}
+	}
+
+	return res
+}
+
+// utilization returns utilization of resources cluster has capacity of
+func (s *inventorySnapshot) utilization() map[string]float64 {
+	res := make(map[string]float64)
+
+	if s == nil {
+		return res
+	}
+
+	if s.CPU.Allocatable > 0 {
+		res[UtilizationResourceCPU] = s.CPU.Utilization
+	}
+
+	if s.Memory.Allocatable > 0 {
+		res[UtilizationResourceMemory] = s.Memory.Utilization
+	}
+
+	if s.GPU.Allocatable > 0 {
+		res[UtilizationResourceGPU] = s.GPU.Utilization
+	}
+
+	return res
+}
+
+// UtilizationTier applies Multiplier once utilization of the resource reaches Threshold
+type UtilizationTier struct {
+	Threshold  float64
+	Multiplier decimal.Decimal
+}
+
+// surgeMultiplier prices order by utilization of the resources it requests.
+// Each resource has its own tiers, the highest tier reached applies. Tier with threshold 0
+// and multiplier below 1 lowers prices while the resource is idle.
+// When order requests several resources, the highest of their multipliers applies,
+// so order is discounted only when all requested resources are idle
+type surgeMultiplier struct {
+	tiers map[string][]UtilizationTier
+}
+
+func MakeSurgeMultiplier(tiers map[string][]UtilizationTier) (PriceMultiplier, error) {
+	for name, rtiers := range tiers {
+		switch name {
+		case UtilizationResourceCPU, UtilizationResourceMemory, UtilizationResourceGPU:
+		default:
+			return nil, fmt.Errorf("%w: %q", errSurgeResourceUnknown, name)
+		}
+
+		for _, tier := range rtiers {
+			if tier.Threshold < 0 || tier.Threshold > 1 {
+				return nil, fmt.Errorf("%w: %s", errUtilizationTierInvalid, name)
+			}
+
+			if tier.Multiplier.IsNegative() {
+				return nil, fmt.Errorf("%w: %s", errMultiplierNegative, name)
+			}
+		}
+	}
+
+	return surgeMultiplier{tiers: tiers}, nil
+}
+
+func (sm surgeMultiplier) Multiplier(r Request) (decimal.Decimal, bool) {
+	utilization := newInventorySnapshot(r.Inventory).utilization()
+	if len(utilization) == 0 {
+		return decimal.Decimal{}, false
+	}
+
+	var result decimal.Decimal
+	apply := false
+
+	for name := range requestedResources(r) {
+		val, exists := utilization[name]
+		if !exists {
+			continue
+		}
+
+		tier := highestTier(sm.tiers[name], val)
+		if tier == nil {
+			continue
+		}
+
+		if !apply || tier.Multiplier.GreaterThan(result) {
+			result = tier.Multiplier
+			apply = true
+		}
+	}
+
+	return result, apply
+}
+
+// highestTier returns tier with the highest threshold reached by utilization
+func highestTier(tiers []UtilizationTier, utilization float64) *UtilizationTier {
+	var result *UtilizationTier
+
+	for i := range tiers {
+		tier := &tiers[i]
+		if utilization >= tier.Threshold && (result == nil || tier.Threshold > result.Threshold) {
+			result = tier
+		}
+	}
+
+	return result
+}
+
+func requestedResources(r Request) map[string]struct{} {
+	resources := r.GSpec.Resources
+	if len(r.AllocatedResources) > 0 {
+		resources = r.AllocatedResources
+	}
+
+	res := make(map[string]struct{})
+
+	add := func(name string, units uint64) {
+		if units > 0 {
+			res[name] = struct{}{}
+		}
+	}
+
+	for _, unit := range resources {
+		if unit.CPU != nil {
+			add(UtilizationResourceCPU, unit.CPU.Units.Value())
+		}
+
+		if unit.Memory != nil {
+			add(UtilizationResourceMemory, unit.Memory.Quantity.Value())
+		}
+
+		if unit.GPU != nil {
+			add(UtilizationResourceGPU, unit.GPU.Units.Value())
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,152 @@
+package bidengine
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akash-network/node/testutil"
+)
+
+func Test_InventorySnapshot(t *testing.T) {
+	require.Nil(t, newInventorySnapshot(nil))
+	require.Empty(t, newInventorySnapshot(nil).utilization())
+
+	inv := utilizationInventory(2500, 3)
+	inv.Reservations.Pending.Count = 2
+	inv.Reservations.Active.Count = 5
+
+	snapshot := newInventorySnapshot(inv)
+	require.Equal(t, 1, snapshot.Nodes)
+	require.Equal(t, inventoryResource{Allocatable: 10000, Allocated: 2500, Utilization: 0.25}, snapshot.CPU)
+	require.Equal(t, inventoryResource{Allocatable: 1000, Allocated: 0, Utilization: 0}, snapshot.Memory)
+	require.Equal(t, inventoryResource{Allocatable: 4, Allocated: 3, Utilization: 0.75}, snapshot.GPU)
+	require.Equal(t, inventoryReservations{Pending: 2, Active: 5}, snapshot.Reservations)
+
+	require.Equal(t, map[string]float64{
+		UtilizationResourceCPU:    0.25,
+		UtilizationResourceMemory: 0,
+		UtilizationResourceGPU:    0.75,
+	}, snapshot.utilization())
+}
+
+func Test_SurgeMultiplierRejectsInvalidConfig(t *testing.T) {
+	_, err := MakeSurgeMultiplier(map[string][]UtilizationTier{
+		"disk": {{Threshold: 0.5, Multiplier: decimal.NewFromInt(2)}},
+	})
+	require.ErrorIs(t, err, errSurgeResourceUnknown)
+
+	_, err = MakeSurgeMultiplier(map[string][]UtilizationTier{
+		UtilizationResourceGPU: {{Threshold: 2, Multiplier: decimal.NewFromInt(2)}},
+	})
+	require.ErrorIs(t, err, errUtilizationTierInvalid)
+
+	_, err = MakeSurgeMultiplier(map[string][]UtilizationTier{
+		UtilizationResourceCPU: {{Threshold: 0.5, Multiplier: decimal.NewFromInt(-2)}},
+	})
+	require.ErrorIs(t, err, errMultiplierNegative)
+}
+
+func Test_SurgeMultiplier(t *testing.T) {
+	multiplier, err := MakeSurgeMultiplier(map[string][]UtilizationTier{
+		UtilizationResourceCPU: {
+			{Threshold: 0, Multiplier: decimal.RequireFromString("0.8")},
+			{Threshold: 0.3, Multiplier: decimal.NewFromInt(1)},
+			{Threshold: 0.8, Multiplier: decimal.RequireFromString("1.5")},
+		},
+		UtilizationResourceGPU: {
+			{Threshold: 0, Multiplier: decimal.RequireFromString("0.5")},
+			{Threshold: 0.5, Multiplier: decimal.NewFromInt(3)},
+		},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		desc         string
+		gpu          bool
+		cpuAllocated int64
+		gpuAllocated int64
+		expected     string
+	}{
+		{desc: "idle cpu", cpuAllocated: 1000, expected: "0.8"},
+		{desc: "moderate cpu", cpuAllocated: 5000, expected: "1"},
+		{desc: "busy cpu", cpuAllocated: 9000, expected: "1.5"},
+		{desc: "busy gpu does not affect cpu order", cpuAllocated: 1000, gpuAllocated: 4, expected: "0.8"},
+		{desc: "idle gpu order with idle cpu", gpu: true, cpuAllocated: 1000, expected: "0.8"},
+		{desc: "busy gpu order", gpu: true, cpuAllocated: 1000, gpuAllocated: 2, expected: "3"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			gspec := defaultGroupSpec()
+			if c.gpu {
+				gspec = gpuGroupSpec(1)
+			}
+
+			req := Request{
+				GSpec:     gspec,
+				Inventory: utilizationInventory(c.cpuAllocated, c.gpuAllocated),
+			}
+
+			val, apply := multiplier.Multiplier(req)
+			require.True(t, apply)
+			require.True(t, decimal.RequireFromString(c.expected).Equal(val), "got %s", val)
+		})
+	}
+
+	_, apply := multiplier.Multiplier(Request{GSpec: defaultGroupSpec()})
+	require.False(t, apply, "no inventory snapshot")
+}
+
+func Test_SurgePricingFromConfig(t *testing.T) {
+	cfg := PricingConfig{
+		Surge: map[string][]UtilizationTierConfig{
+			UtilizationResourceCPU: {
+				{Threshold: 0, Multiplier: "0.5"},
+				{Threshold: 0.9, Multiplier: "2"},
+			},
+		},
+	}
+
+	pricing, err := cfg.Wrap(context.Background(), testBidPricingStrategy(10))
+	require.NoError(t, err)
+
+	req := Request{
+		GSpec:          defaultGroupSpec(),
+		PricePrecision: DefaultPricePrecision,
+		Inventory:      utilizationInventory(0, 0),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(5), price.Amount)
+
+	req.Inventory = utilizationInventory(9500, 0)
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDec(20), price.Amount)
+}
+
+func Test_ExpressionPricingOnInventory(t *testing.T) {
+	pricing, err := MakeExpressionPricing(`10 * (1 + (inventory?.cpu?.utilization ?? 0))`, testExpressionRuntimeLimit)
+	require.NoError(t, err)
+
+	req := Request{
+		Owner: testutil.AccAddress(t).String(),
+		GSpec: defaultGroupSpec(),
+	}
+
+	price, err := pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 10)
+
+	req.Inventory = utilizationInventory(5000, 0)
+
+	price, err = pricing.CalculatePrice(context.Background(), req)
+	require.NoError(t, err)
+	decNearly(t, price.Amount, 15)
+}
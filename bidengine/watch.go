@@ -0,0 +1,75 @@
+package bidengine
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/akash-network/provider/tools/fromctx"
+)
+
+// watchFile calls reload every time file at path is written or replaced, until ctx is done.
+// Parent directory is watched so file replaced by rename or mounted from ConfigMap,
+// which swaps the ..data symlink path resolves through, is picked up as well.
+// Failed reload is logged, and it is up to reload to keep previously loaded content in effect
+func watchFile(ctx context.Context, module string, path string, reload func() error) error {
+	path = filepath.Clean(path)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	realPath, _ := filepath.EvalSymlinks(path)
+
+	go func() {
+		log := fromctx.LogcFromCtx(ctx).With("module", module, "path", path)
+
+		defer func() {
+			_ = watcher.Close()
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				currentPath, _ := filepath.EvalSymlinks(path)
+
+				written := filepath.Clean(evt.Name) == path && (evt.Has(fsnotify.Create) || evt.Has(fsnotify.Write))
+				swapped := currentPath != "" && currentPath != realPath
+
+				if !written && !swapped {
+					continue
+				}
+
+				realPath = currentPath
+
+				if err := reload(); err != nil {
+					log.Error("unable to reload file, keeping previous", "err", err)
+					continue
+				}
+
+				log.Info("reloaded")
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.Error("watching file", "err", err)
+			}
+		}
+	}()
+
+	return nil
+}
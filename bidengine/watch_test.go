@@ -0,0 +1,79 @@
+package bidengine
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func watchFileForTest(t *testing.T, path string) *atomic.Value {
+	t.Helper()
+
+	var content atomic.Value
+	content.Store("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	err := watchFile(ctx, "test", path, func() error {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		content.Store(string(buf))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return &content
+}
+
+func Test_WatchFileReplacedByRename(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+
+	content := watchFileForTest(t, path)
+
+	for _, val := range []string{"second", "third"} {
+		tmp := filepath.Join(dir, "config.yaml.tmp")
+		require.NoError(t, os.WriteFile(tmp, []byte(val), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+
+		require.Eventually(t, func() bool {
+			return content.Load() == val
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+func Test_WatchFileConfigMapSwap(t *testing.T) {
+	dir := t.TempDir()
+
+	// layout of ConfigMap volume: file links through ..data to the timestamped directory
+	writeVersion := func(name string, val string) {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, name), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, "config.yaml"), []byte(val), 0o600))
+		require.NoError(t, os.Symlink(name, filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	}
+
+	writeVersion("..v1", "first")
+
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), path))
+
+	content := watchFileForTest(t, path)
+
+	writeVersion("..v2", "second")
+
+	require.Eventually(t, func() bool {
+		return content.Load() == "second"
+	}, 5*time.Second, 10*time.Millisecond)
+}
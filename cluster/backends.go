@@ -0,0 +1,515 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/tools/remotecommand"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	mani "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	mquery "github.com/akash-network/node/x/market/query"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	chostname "github.com/akash-network/provider/cluster/types/v1beta3/clients/hostname"
+	cip "github.com/akash-network/provider/cluster/types/v1beta3/clients/ip"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+// PrimaryBackend is the name of cluster provider is configured with when running several clusters
+const PrimaryBackend = "primary"
+
+var (
+	errBackendsEmpty     = errors.New("cluster: at least one backend is required")
+	errBackendDuplicated = errors.New("cluster: duplicate backend")
+	errBackendUnknown    = errors.New("cluster: unknown backend")
+)
+
+// Backend is one of the clusters provider deploys leases into
+type Backend struct {
+	Name   string
+	Client Client
+}
+
+// BackendsStatusClient is implemented by clients aggregating several clusters
+type BackendsStatusClient interface {
+	BackendsStatus(context.Context) []ctypes.ClusterBackendStatus
+}
+
+// multiClient routes lease operations to the cluster the lease is deployed into.
+// Owning cluster is learned from deployments and from cluster reservation was placed in,
+// leases not known yet are looked up in every cluster
+type multiClient struct {
+	backends []Backend
+	byName   map[string]Client
+	log      log.Logger
+
+	lock   sync.Mutex
+	leases map[string]string
+}
+
+var (
+	_ Client               = (*multiClient)(nil)
+	_ BackendsStatusClient = (*multiClient)(nil)
+)
+
+// NewMultiClient returns client aggregating several clusters. First backend is used
+// for leases not found in any cluster and for cluster-wide queries
+func NewMultiClient(log log.Logger, backends ...Backend) (Client, error) {
+	if len(backends) == 0 {
+		return nil, errBackendsEmpty
+	}
+
+	byName := make(map[string]Client, len(backends))
+	for _, backend := range backends {
+		if _, exists := byName[backend.Name]; exists {
+			return nil, fmt.Errorf("%w: %s", errBackendDuplicated, backend.Name)
+		}
+
+		byName[backend.Name] = backend.Client
+	}
+
+	cl := &multiClient{
+		backends: backends,
+		byName:   byName,
+		log:      log.With("client", "multi-cluster"),
+		leases:   make(map[string]string),
+	}
+
+	return cl, nil
+}
+
+func (c *multiClient) route(lid mtypes.LeaseID, name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.leases[mquery.LeasePath(lid)] = name
+}
+
+func (c *multiClient) forget(lid mtypes.LeaseID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.leases, mquery.LeasePath(lid))
+}
+
+func (c *multiClient) routed(lid mtypes.LeaseID) (Client, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	name, exists := c.leases[mquery.LeasePath(lid)]
+	if !exists {
+		return nil, false
+	}
+
+	return c.byName[name], true
+}
+
+// backend returns client of the cluster lease is deployed into
+func (c *multiClient) backend(ctx context.Context, lid mtypes.LeaseID) Client {
+	if cl, exists := c.routed(lid); exists {
+		return cl
+	}
+
+	for _, backend := range c.backends {
+		found, _, err := backend.Client.GetManifestGroup(ctx, lid)
+		if err != nil || !found {
+			continue
+		}
+
+		c.route(lid, backend.Name)
+
+		return backend.Client
+	}
+
+	return c.backends[0].Client
+}
+
+// deploymentBackend returns name of the cluster deployment was reserved in, empty if unknown
+func deploymentBackend(deployment ctypes.IDeployment) string {
+	var sparams []*crd.SchedulerParams
+
+	switch cparams := deployment.ClusterParams().(type) {
+	case crd.ClusterSettings:
+		sparams = cparams.SchedulerParams
+	case crd.ReservationClusterSettings:
+		for _, params := range cparams {
+			sparams = append(sparams, params)
+		}
+	}
+
+	for _, params := range sparams {
+		if params != nil && params.Cluster != "" {
+			return params.Cluster
+		}
+	}
+
+	return ""
+}
+
+// inventoryBackendMetrics returns metrics of every cluster inventory is aggregated from,
+// nil for inventory of single cluster
+func inventoryBackendMetrics(inv ctypes.Inventory) *map[string]inventoryV1.Metrics {
+	backends, valid := inv.(ctypes.InventoryBackends)
+	if !valid {
+		return nil
+	}
+
+	result := make(map[string]inventoryV1.Metrics)
+	for name, backend := range backends.Backends() {
+		result[name] = backend.Metrics()
+	}
+
+	return &result
+}
+
+// backendsStatus returns status of every cluster, nil when provider runs single cluster
+func (s *service) backendsStatus(ctx context.Context) []ctypes.ClusterBackendStatus {
+	client, valid := s.client.(BackendsStatusClient)
+	if !valid {
+		return nil
+	}
+
+	result := client.BackendsStatus(ctx)
+
+	if metrics := s.inventory.backendMetrics.Load(); metrics != nil {
+		for idx := range result {
+			if val, exists := (*metrics)[result[idx].Name]; exists {
+				result[idx].Inventory = &val
+			}
+		}
+	}
+
+	return result
+}
+
+// setCluster records cluster reservation was placed in into scheduler params of every resource unit
+// so deployment is routed to that cluster
+func setCluster(res *reservation) {
+	if res.cluster == "" {
+		return
+	}
+
+	cparams, valid := res.clusterParams.(crd.ReservationClusterSettings)
+	if !valid {
+		return
+	}
+
+	for _, ru := range res.resources.GetResourceUnits() {
+		sparams := cparams[ru.ID]
+		if sparams == nil {
+			sparams = &crd.SchedulerParams{}
+			cparams[ru.ID] = sparams
+		}
+
+		sparams.Cluster = res.cluster
+	}
+}
+
+func (c *multiClient) Deploy(ctx context.Context, deployment ctypes.IDeployment) error {
+	lid := deployment.LeaseID()
+
+	var cl Client
+	if name := deploymentBackend(deployment); name != "" {
+		if cl = c.byName[name]; cl == nil {
+			return fmt.Errorf("%w: %s", errBackendUnknown, name)
+		}
+
+		c.route(lid, name)
+	} else {
+		cl = c.backend(ctx, lid)
+	}
+
+	return cl.Deploy(ctx, deployment)
+}
+
+func (c *multiClient) TeardownLease(ctx context.Context, lid mtypes.LeaseID) error {
+	if err := c.backend(ctx, lid).TeardownLease(ctx, lid); err != nil {
+		return err
+	}
+
+	c.forget(lid)
+
+	return nil
+}
+
+func (c *multiClient) Deployments(ctx context.Context) ([]ctypes.IDeployment, error) {
+	var result []ctypes.IDeployment
+
+	for _, backend := range c.backends {
+		deployments, err := backend.Client.Deployments(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", backend.Name, err)
+		}
+
+		for _, deployment := range deployments {
+			c.route(deployment.LeaseID(), backend.Name)
+		}
+
+		result = append(result, deployments...)
+	}
+
+	return result, nil
+}
+
+func (c *multiClient) LeaseStatus(ctx context.Context, lid mtypes.LeaseID) (map[string]*ctypes.ServiceStatus, error) {
+	return c.backend(ctx, lid).LeaseStatus(ctx, lid)
+}
+
+func (c *multiClient) ForwardedPortStatus(ctx context.Context, lid mtypes.LeaseID) (map[string][]ctypes.ForwardedPortStatus, error) {
+	return c.backend(ctx, lid).ForwardedPortStatus(ctx, lid)
+}
+
+func (c *multiClient) LeaseEvents(ctx context.Context, lid mtypes.LeaseID, service string, follow bool) (ctypes.EventsWatcher, error) {
+	return c.backend(ctx, lid).LeaseEvents(ctx, lid, service, follow)
+}
+
+func (c *multiClient) LeaseLogs(ctx context.Context, lid mtypes.LeaseID, service string, follow bool, tailLines *int64) ([]*ctypes.ServiceLog, error) {
+	return c.backend(ctx, lid).LeaseLogs(ctx, lid, service, follow, tailLines)
+}
+
+func (c *multiClient) ServiceStatus(ctx context.Context, lid mtypes.LeaseID, service string) (*ctypes.ServiceStatus, error) {
+	return c.backend(ctx, lid).ServiceStatus(ctx, lid, service)
+}
+
+func (c *multiClient) Exec(ctx context.Context,
+	lid mtypes.LeaseID,
+	service string,
+	podIndex uint,
+	cmd []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+	tty bool,
+	tsq remotecommand.TerminalSizeQueue) (ctypes.ExecResult, error) {
+	return c.backend(ctx, lid).Exec(ctx, lid, service, podIndex, cmd, stdin, stdout, stderr, tty, tsq)
+}
+
+func (c *multiClient) GetManifestGroup(ctx context.Context, lid mtypes.LeaseID) (bool, crd.ManifestGroup, error) {
+	if cl, exists := c.routed(lid); exists {
+		return cl.GetManifestGroup(ctx, lid)
+	}
+
+	for _, backend := range c.backends {
+		found, group, err := backend.Client.GetManifestGroup(ctx, lid)
+		if err != nil {
+			return false, crd.ManifestGroup{}, err
+		}
+
+		if found {
+			c.route(lid, backend.Name)
+			return true, group, nil
+		}
+	}
+
+	return false, crd.ManifestGroup{}, nil
+}
+
+func (c *multiClient) AllHostnames(ctx context.Context) ([]chostname.ActiveHostname, error) {
+	var result []chostname.ActiveHostname
+
+	for _, backend := range c.backends {
+		hostnames, err := backend.Client.AllHostnames(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", backend.Name, err)
+		}
+
+		result = append(result, hostnames...)
+	}
+
+	return result, nil
+}
+
+func (c *multiClient) GetHostnameDeploymentConnections(ctx context.Context) ([]chostname.LeaseIDConnection, error) {
+	var result []chostname.LeaseIDConnection
+
+	for _, backend := range c.backends {
+		connections, err := backend.Client.GetHostnameDeploymentConnections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", backend.Name, err)
+		}
+
+		result = append(result, connections...)
+	}
+
+	return result, nil
+}
+
+func (c *multiClient) ObserveHostnameState(ctx context.Context) (<-chan chostname.ResourceEvent, error) {
+	chans := make([]<-chan chostname.ResourceEvent, 0, len(c.backends))
+
+	for _, backend := range c.backends {
+		ch, err := backend.Client.ObserveHostnameState(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", backend.Name, err)
+		}
+
+		chans = append(chans, ch)
+	}
+
+	return mergeEvents(ctx, chans), nil
+}
+
+func (c *multiClient) ObserveIPState(ctx context.Context) (<-chan cip.ResourceEvent, error) {
+	chans := make([]<-chan cip.ResourceEvent, 0, len(c.backends))
+
+	for _, backend := range c.backends {
+		ch, err := backend.Client.ObserveIPState(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", backend.Name, err)
+		}
+
+		chans = append(chans, ch)
+	}
+
+	return mergeEvents(ctx, chans), nil
+}
+
+// mergeEvents fans in events of all clusters, result is closed once every cluster closes its channel
+func mergeEvents[T any](ctx context.Context, chans []<-chan T) <-chan T {
+	result := make(chan T)
+
+	var wg sync.WaitGroup
+
+	for _, ch := range chans {
+		ch := ch
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ev := range ch {
+				select {
+				case result <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(result)
+	}()
+
+	return result
+}
+
+func (c *multiClient) GetDeclaredIPs(ctx context.Context, lid mtypes.LeaseID) ([]crd.ProviderLeasedIPSpec, error) {
+	return c.backend(ctx, lid).GetDeclaredIPs(ctx, lid)
+}
+
+func (c *multiClient) ConnectHostnameToDeployment(ctx context.Context, directive chostname.ConnectToDeploymentDirective) error {
+	return c.backend(ctx, directive.LeaseID).ConnectHostnameToDeployment(ctx, directive)
+}
+
+func (c *multiClient) RemoveHostnameFromDeployment(ctx context.Context, hostname string, lid mtypes.LeaseID, allowMissing bool) error {
+	return c.backend(ctx, lid).RemoveHostnameFromDeployment(ctx, hostname, lid, allowMissing)
+}
+
+func (c *multiClient) DeclareHostname(ctx context.Context, lid mtypes.LeaseID, host string, serviceName string, externalPort uint32) error {
+	return c.backend(ctx, lid).DeclareHostname(ctx, lid, host, serviceName, externalPort)
+}
+
+func (c *multiClient) PurgeDeclaredHostnames(ctx context.Context, lid mtypes.LeaseID) error {
+	return c.backend(ctx, lid).PurgeDeclaredHostnames(ctx, lid)
+}
+
+func (c *multiClient) PurgeDeclaredHostname(ctx context.Context, lid mtypes.LeaseID, hostname string) error {
+	return c.backend(ctx, lid).PurgeDeclaredHostname(ctx, lid, hostname)
+}
+
+func (c *multiClient) KubeVersion() (*version.Info, error) {
+	return c.backends[0].Client.KubeVersion()
+}
+
+func (c *multiClient) DeclareIP(ctx context.Context, lid mtypes.LeaseID, serviceName string, port uint32, externalPort uint32, proto mani.ServiceProtocol, sharingKey string, overwrite bool) error {
+	return c.backend(ctx, lid).DeclareIP(ctx, lid, serviceName, port, externalPort, proto, sharingKey, overwrite)
+}
+
+func (c *multiClient) PurgeDeclaredIP(ctx context.Context, lid mtypes.LeaseID, serviceName string, externalPort uint32, proto mani.ServiceProtocol) error {
+	return c.backend(ctx, lid).PurgeDeclaredIP(ctx, lid, serviceName, externalPort, proto)
+}
+
+func (c *multiClient) PurgeDeclaredIPs(ctx context.Context, lid mtypes.LeaseID) error {
+	return c.backend(ctx, lid).PurgeDeclaredIPs(ctx, lid)
+}
+
+// SetNodeMaintenance puts node in maintenance in the first cluster it succeeds in,
+// node names are expected to be unique across clusters
+func (c *multiClient) SetNodeMaintenance(ctx context.Context, maintenance ctypes.NodeMaintenance) error {
+	var errs []error
+
+	for _, backend := range c.backends {
+		err := backend.Client.SetNodeMaintenance(ctx, maintenance)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("cluster %s: %w", backend.Name, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *multiClient) ClearNodeMaintenance(ctx context.Context, node string) error {
+	var errs []error
+
+	for _, backend := range c.backends {
+		err := backend.Client.ClearNodeMaintenance(ctx, node)
+		if err == nil {
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("cluster %s: %w", backend.Name, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *multiClient) ReportServiceFailure(ctx context.Context, lid mtypes.LeaseID, service string, failure ctypes.ServiceFailure) error {
+	return c.backend(ctx, lid).ReportServiceFailure(ctx, lid, service, failure)
+}
+
+func (c *multiClient) MissingPlacement(ctx context.Context, deployment ctypes.IDeployment) (bool, error) {
+	return c.backend(ctx, deployment.LeaseID()).MissingPlacement(ctx, deployment)
+}
+
+// BackendsStatus reports version and number of leases of every cluster
+func (c *multiClient) BackendsStatus(_ context.Context) []ctypes.ClusterBackendStatus {
+	leases := make(map[string]uint32, len(c.backends))
+
+	c.lock.Lock()
+	for _, name := range c.leases {
+		leases[name]++
+	}
+	c.lock.Unlock()
+
+	result := make([]ctypes.ClusterBackendStatus, 0, len(c.backends))
+
+	for _, backend := range c.backends {
+		status := ctypes.ClusterBackendStatus{
+			Name:   backend.Name,
+			Leases: leases[backend.Name],
+		}
+
+		info, err := backend.Client.KubeVersion()
+		if err != nil {
+			c.log.Error("unable to reach cluster", "cluster", backend.Name, "err", err)
+			status.Error = err.Error()
+		} else if info != nil {
+			status.Version = info.GitVersion
+		}
+
+		result = append(result, status)
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	"github.com/akash-network/provider/cluster/mocks"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func backendsTestClient(t *testing.T) (Client, *mocks.Client, *mocks.Client) {
+	primary := &mocks.Client{}
+	secondary := &mocks.Client{}
+
+	client, err := NewMultiClient(testutil.Logger(t),
+		Backend{Name: PrimaryBackend, Client: primary},
+		Backend{Name: "secondary", Client: secondary},
+	)
+	require.NoError(t, err)
+
+	return client, primary, secondary
+}
+
+func TestNewMultiClientInvalid(t *testing.T) {
+	_, err := NewMultiClient(testutil.Logger(t))
+	require.ErrorIs(t, err, errBackendsEmpty)
+
+	_, err = NewMultiClient(testutil.Logger(t),
+		Backend{Name: PrimaryBackend, Client: &mocks.Client{}},
+		Backend{Name: PrimaryBackend, Client: &mocks.Client{}},
+	)
+	require.ErrorIs(t, err, errBackendDuplicated)
+}
+
+func TestMultiClientDeployRoutesByCluster(t *testing.T) {
+	ctx := context.Background()
+	client, primary, secondary := backendsTestClient(t)
+
+	deployment := &ctypes.Deployment{
+		Lid:     testutil.LeaseID(t),
+		CParams: crd.ReservationClusterSettings{1: &crd.SchedulerParams{Cluster: "secondary"}},
+	}
+
+	secondary.On("Deploy", mock.Anything, deployment).Return(nil)
+	secondary.On("LeaseStatus", mock.Anything, deployment.Lid).Return(map[string]*ctypes.ServiceStatus{}, nil)
+
+	require.NoError(t, client.Deploy(ctx, deployment))
+
+	_, err := client.LeaseStatus(ctx, deployment.Lid)
+	require.NoError(t, err)
+
+	primary.AssertNotCalled(t, "LeaseStatus", mock.Anything, mock.Anything)
+	secondary.AssertExpectations(t)
+
+	deployment.CParams = crd.ReservationClusterSettings{1: &crd.SchedulerParams{Cluster: "unknown"}}
+	require.ErrorIs(t, client.Deploy(ctx, deployment), errBackendUnknown)
+}
+
+func TestMultiClientRoutesDeployments(t *testing.T) {
+	ctx := context.Background()
+	client, primary, secondary := backendsTestClient(t)
+
+	onPrimary := &ctypes.Deployment{Lid: testutil.LeaseID(t)}
+	onSecondary := &ctypes.Deployment{Lid: testutil.LeaseID(t)}
+
+	primary.On("Deployments", mock.Anything).Return([]ctypes.IDeployment{onPrimary}, nil)
+	secondary.On("Deployments", mock.Anything).Return([]ctypes.IDeployment{onSecondary}, nil)
+	secondary.On("TeardownLease", mock.Anything, onSecondary.Lid).Return(nil)
+
+	deployments, err := client.Deployments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []ctypes.IDeployment{onPrimary, onSecondary}, deployments)
+
+	require.NoError(t, client.TeardownLease(ctx, onSecondary.Lid))
+	primary.AssertNotCalled(t, "TeardownLease", mock.Anything, mock.Anything)
+
+	// lease not known to any cluster is looked up in each of them and falls back to primary
+	unknown := testutil.LeaseID(t)
+	primary.On("GetManifestGroup", mock.Anything, unknown).Return(false, crd.ManifestGroup{}, nil)
+	secondary.On("GetManifestGroup", mock.Anything, unknown).Return(false, crd.ManifestGroup{}, nil)
+	primary.On("TeardownLease", mock.Anything, unknown).Return(nil)
+
+	require.NoError(t, client.TeardownLease(ctx, unknown))
+	primary.AssertExpectations(t)
+	secondary.AssertExpectations(t)
+}
+
+func TestSetCluster(t *testing.T) {
+	res := newReservation(testutil.OrderID(t), &dtypes.GroupSpec{
+		Name:      "group",
+		Resources: testutil.Resources(t),
+	})
+	res.SetClusterParams(crd.ReservationClusterSettings{})
+
+	setCluster(res)
+	require.Empty(t, deploymentBackend(&ctypes.Deployment{CParams: res.ClusterParams()}))
+
+	res.SetCluster("secondary")
+	setCluster(res)
+
+	for _, ru := range res.Resources().GetResourceUnits() {
+		require.Equal(t, "secondary", res.ClusterParams().(crd.ReservationClusterSettings)[ru.ID].Cluster)
+	}
+
+	require.Equal(t, "secondary", deploymentBackend(&ctypes.Deployment{CParams: res.ClusterParams()}))
+}
@@ -96,6 +96,17 @@ type Client interface {
 	DeclareIP(ctx context.Context, lID mtypes.LeaseID, serviceName string, port uint32, externalPort uint32, proto mani.ServiceProtocol, sharingKey string, overwrite bool) error
 	PurgeDeclaredIP(ctx context.Context, lID mtypes.LeaseID, serviceName string, externalPort uint32, proto mani.ServiceProtocol) error
 	PurgeDeclaredIPs(ctx context.Context, lID mtypes.LeaseID) error
+
+	// SetNodeMaintenance puts node in maintenance, marking leases running on it with migration notice when requested
+	SetNodeMaintenance(ctx context.Context, maintenance ctypes.NodeMaintenance) error
+	// ClearNodeMaintenance takes node out of maintenance and removes migration notices it raised
+	ClearNodeMaintenance(ctx context.Context, node string) error
+
+	// ReportServiceFailure records failure of the service as lease event visible to the tenant
+	ReportServiceFailure(ctx context.Context, lID mtypes.LeaseID, service string, failure ctypes.ServiceFailure) error
+
+	// MissingPlacement reports whether any node services of the deployment are required to run on is gone
+	MissingPlacement(ctx context.Context, deployment ctypes.IDeployment) (bool, error)
 }
 
 func ErrorIsOkToSendToClient(err error) bool {
@@ -194,9 +205,10 @@ func (c *nullClient) LeaseStatus(_ context.Context, lid mtypes.LeaseID) (map[str
 	resp := make(map[string]*ctypes.ServiceStatus)
 	for _, svc := range lease.group.Services {
 		resp[svc.Name] = &ctypes.ServiceStatus{
-			Name:      svc.Name,
-			Available: int32(svc.Count), // nolint: gosec
-			Total:     int32(svc.Count), // nolint: gosec
+			Name:          svc.Name,
+			Available:     int32(svc.Count), // nolint: gosec
+			Total:         int32(svc.Count), // nolint: gosec
+			ReadyReplicas: int32(svc.Count), // nolint: gosec
 		}
 	}
 
@@ -312,6 +324,22 @@ func (c *nullClient) PurgeDeclaredIPs(_ context.Context, _ mtypes.LeaseID) error
 	return errNotImplemented
 }
 
+func (c *nullClient) SetNodeMaintenance(_ context.Context, _ ctypes.NodeMaintenance) error {
+	return errNotImplemented
+}
+
+func (c *nullClient) ClearNodeMaintenance(_ context.Context, _ string) error {
+	return errNotImplemented
+}
+
+func (c *nullClient) ReportServiceFailure(_ context.Context, _ mtypes.LeaseID, _ string, _ ctypes.ServiceFailure) error {
+	return nil
+}
+
+func (c *nullClient) MissingPlacement(_ context.Context, _ ctypes.IDeployment) (bool, error) {
+	return false, nil
+}
+
 func (c *nullClient) ObserveIPState(_ context.Context) (<-chan cip.ResourceEvent, error) {
 	return nil, errNotImplemented
 }
@@ -0,0 +1,278 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+var errCommitConfigInvalid = errors.New("invalid commit levels config")
+
+// CommitLevels are overcommit factors applied to requested resources.
+// Values not above 1 disable overcommit of the resource
+type CommitLevels struct {
+	CPU     float64 `yaml:"cpu"`
+	GPU     float64 `yaml:"gpu"`
+	Memory  float64 `yaml:"memory"`
+	Storage float64 `yaml:"storage"`
+}
+
+// CommitPool applies own commit levels to nodes carrying all of its labels
+type CommitPool struct {
+	Name         string            `yaml:"name"`
+	MatchLabels  map[string]string `yaml:"match_labels"`
+	CommitLevels `yaml:",inline"`
+}
+
+// CommitConfig is the yaml representation of per node pool and per storage class commit levels.
+// Pools are matched in order, nodes matching none of them use global commit levels.
+// Storage class levels replace pool storage level for volumes of the class
+//
+//	pools:
+//	  - name: bare-metal
+//	    match_labels:
+//	      node.example.com/pool: dedicated
+//	    cpu: 1
+//	    memory: 1
+//	  - name: burstable
+//	    match_labels:
+//	      node.example.com/pool: burstable
+//	    cpu: 2
+//	    memory: 1.5
+//	storage_classes:
+//	  beta3: 2
+type CommitConfig struct {
+	Pools          []CommitPool       `yaml:"pools"`
+	StorageClasses map[string]float64 `yaml:"storage_classes"`
+}
+
+// ReadCommitConfigPath reads per node pool and per storage class commit levels from yaml file
+func ReadCommitConfigPath(path string) (CommitConfig, error) {
+	var cfg CommitConfig
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err = yaml.Unmarshal(buf, &cfg); err != nil {
+		return cfg, err
+	}
+
+	if err = cfg.validate(); err != nil {
+		return CommitConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+func (c CommitConfig) validate() error {
+	names := make(map[string]struct{}, len(c.Pools))
+
+	for idx, pool := range c.Pools {
+		if pool.Name == "" {
+			return fmt.Errorf("%w: pool at idx %d has no name", errCommitConfigInvalid, idx)
+		}
+
+		if _, exists := names[pool.Name]; exists {
+			return fmt.Errorf("%w: duplicate pool %q", errCommitConfigInvalid, pool.Name)
+		}
+
+		names[pool.Name] = struct{}{}
+
+		if len(pool.MatchLabels) == 0 {
+			return fmt.Errorf("%w: pool %q has no match labels", errCommitConfigInvalid, pool.Name)
+		}
+
+		if err := pool.CommitLevels.validate(); err != nil {
+			return fmt.Errorf("%w: pool %q: %s", errCommitConfigInvalid, pool.Name, err)
+		}
+	}
+
+	for class, level := range c.StorageClasses {
+		if level < 0 {
+			return fmt.Errorf("%w: storage class %q has negative level", errCommitConfigInvalid, class)
+		}
+	}
+
+	return nil
+}
+
+func (l CommitLevels) validate() error {
+	if l.CPU < 0 || l.GPU < 0 || l.Memory < 0 || l.Storage < 0 {
+		return errors.New("negative commit level")
+	}
+
+	return nil
+}
+
+func (p *CommitPool) matches(labels map[string]string) bool {
+	for key, val := range p.MatchLabels {
+		if lval, exists := labels[key]; !exists || lval != val {
+			return false
+		}
+	}
+
+	return true
+}
+
+// commitPools returns configured pools followed by the default one holding global commit levels
+func (c Config) commitPools() []CommitPool {
+	pools := make([]CommitPool, 0, len(c.Commit.Pools)+1)
+	pools = append(pools, c.Commit.Pools...)
+
+	return append(pools, CommitPool{
+		CommitLevels: CommitLevels{
+			CPU:     c.CPUCommitLevel,
+			GPU:     c.GPUCommitLevel,
+			Memory:  c.MemoryCommitLevel,
+			Storage: c.StorageCommitLevel,
+		},
+	})
+}
+
+// commitPool returns pool by its name, unknown names resolve to the default pool
+func (is *inventoryService) commitPool(name string) CommitPool {
+	for _, pool := range is.pools {
+		if pool.Name == name {
+			return pool
+		}
+	}
+
+	return is.pools[len(is.pools)-1]
+}
+
+// nodePool returns name of the first configured pool node belongs to, empty for the default pool
+func (is *inventoryService) nodePool(name string) string {
+	if is.nodeLabels == nil {
+		return ""
+	}
+
+	labels := is.nodeLabels(name)
+
+	for idx := range is.config.Commit.Pools {
+		if is.config.Commit.Pools[idx].matches(labels) {
+			return is.config.Commit.Pools[idx].Name
+		}
+	}
+
+	return ""
+}
+
+// storageCommitLevel returns commit level of the volume, class specific one taking precedence over the pool's
+func (is *inventoryService) storageCommitLevel(pool CommitPool, volume atypes.Storage) float64 {
+	attr := volume.Attributes.Find(sdl.StorageAttributeClass)
+	if class, valid := attr.AsString(); valid {
+		if level, exists := is.config.Commit.StorageClasses[class]; exists {
+			return level
+		}
+	}
+
+	return pool.Storage
+}
+
+// poolPlacement limits nodes of the wrapped placement strategy to the ones of a single commit pool
+type poolPlacement struct {
+	strategy ctypes.PlacementStrategy
+	pool     string
+	nodePool func(string) string
+}
+
+var _ ctypes.PlacementStrategy = (*poolPlacement)(nil)
+
+func (p poolPlacement) Order(nodes inventoryV1.Nodes, resources dtypes.ResourceUnits) []int {
+	order := cinventory.PlacementOrder(p.strategy, nodes, resources)
+	res := order[:0]
+
+	for _, idx := range order {
+		if p.nodePool(nodes[idx].Name) == p.pool {
+			res = append(res, idx)
+		}
+	}
+
+	return res
+}
+
+// placementFor returns placement strategy of the pool, leaving the configured one as is when no pools are configured
+func (is *inventoryService) placementFor(pool string) ctypes.PlacementStrategy {
+	if len(is.config.Commit.Pools) == 0 {
+		return is.placement
+	}
+
+	return poolPlacement{
+		strategy: is.placement,
+		pool:     pool,
+		nodePool: is.nodePool,
+	}
+}
+
+// setCommitPool records commit pool reservation was adjusted in into its cluster params,
+// so workloads request resources with the same commit levels and land on nodes of the pool.
+// Workloads of the default pool are kept off nodes of configured pools.
+// Nothing is recorded when neither pools nor storage class levels are configured, builder applies global levels then
+func (is *inventoryService) setCommitPool(res *reservation, pool CommitPool) {
+	if pool.Name == "" && len(is.config.Commit.Pools) == 0 && len(is.config.Commit.StorageClasses) == 0 {
+		return
+	}
+
+	cparams, valid := res.clusterParams.(crd.ReservationClusterSettings)
+	if !valid {
+		return
+	}
+
+	for _, ru := range res.resources.GetResourceUnits() {
+		sparams := cparams[ru.ID]
+		if sparams == nil {
+			sparams = &crd.SchedulerParams{}
+			cparams[ru.ID] = sparams
+		}
+
+		commit := &crd.SchedulerCommit{
+			Pool:        pool.Name,
+			MatchLabels: copyLabels(pool.MatchLabels),
+			CPU:         pool.CPU,
+			GPU:         pool.GPU,
+			Memory:      pool.Memory,
+			Storage:     pool.Storage,
+		}
+
+		if len(is.config.Commit.StorageClasses) != 0 {
+			commit.StorageClasses = make(map[string]float64, len(is.config.Commit.StorageClasses))
+			for class, level := range is.config.Commit.StorageClasses {
+				commit.StorageClasses[class] = level
+			}
+		}
+
+		if pool.Name == "" {
+			for idx := range is.config.Commit.Pools {
+				commit.ExcludeLabels = append(commit.ExcludeLabels, copyLabels(is.config.Commit.Pools[idx].MatchLabels))
+			}
+		}
+
+		sparams.Commit = commit
+	}
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	res := make(map[string]string, len(labels))
+	for key, val := range labels {
+		res[key] = val
+	}
+
+	return res
+}
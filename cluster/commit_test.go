@@ -0,0 +1,206 @@
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/akash-api/go/node/types/unit"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
+	"github.com/akash-network/node/testutil"
+
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func TestReadCommitConfigPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commit.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+pools:
+  - name: bare-metal
+    match_labels:
+      pool: dedicated
+    cpu: 1
+  - name: burstable
+    match_labels:
+      pool: burstable
+    cpu: 2
+    memory: 1.5
+storage_classes:
+  beta3: 2
+`), 0o600))
+
+	cfg, err := ReadCommitConfigPath(path)
+	require.NoError(t, err)
+	require.Equal(t, CommitConfig{
+		Pools: []CommitPool{
+			{
+				Name:         "bare-metal",
+				MatchLabels:  map[string]string{"pool": "dedicated"},
+				CommitLevels: CommitLevels{CPU: 1},
+			},
+			{
+				Name:         "burstable",
+				MatchLabels:  map[string]string{"pool": "burstable"},
+				CommitLevels: CommitLevels{CPU: 2, Memory: 1.5},
+			},
+		},
+		StorageClasses: map[string]float64{"beta3": 2},
+	}, cfg)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+pools:
+  - name: any
+    cpu: 2
+`), 0o600))
+
+	_, err = ReadCommitConfigPath(path)
+	require.ErrorIs(t, err, errCommitConfigInvalid)
+}
+
+func commitTestGroup(cpu uint64, storageClass string) *dtypes.GroupSpec {
+	volume := types.Storage{
+		Name:     "data",
+		Quantity: types.NewResourceValue(10 * unit.Gi),
+	}
+
+	if storageClass != "" {
+		volume.Attributes = types.Attributes{
+			{Key: sdl.StorageAttributePersistent, Value: "true"},
+			{Key: sdl.StorageAttributeClass, Value: storageClass},
+		}
+	}
+
+	return &dtypes.GroupSpec{
+		Name: "group",
+		Resources: dtypes.ResourceUnits{
+			{
+				Resources: types.Resources{
+					ID:      1,
+					CPU:     &types.CPU{Units: types.NewResourceValue(cpu)},
+					GPU:     &types.GPU{Units: types.NewResourceValue(0)},
+					Memory:  &types.Memory{Quantity: types.NewResourceValue(unit.Gi)},
+					Storage: types.Volumes{volume},
+				},
+				Count: 1,
+			},
+		},
+	}
+}
+
+func TestInventory_CommitPools(t *testing.T) {
+	config := Config{
+		CPUCommitLevel: 1,
+		Commit: CommitConfig{
+			Pools: []CommitPool{
+				{
+					Name:         "bare-metal",
+					MatchLabels:  map[string]string{"pool": "dedicated"},
+					CommitLevels: CommitLevels{CPU: 1},
+				},
+				{
+					Name:         "burstable",
+					MatchLabels:  map[string]string{"pool": "burstable"},
+					CommitLevels: CommitLevels{CPU: 2},
+				},
+			},
+		},
+	}
+
+	labels := map[string]map[string]string{
+		"nodeA": {"pool": "dedicated"},
+		"nodeB": {"pool": "burstable"},
+	}
+
+	is := &inventoryService{
+		config: config,
+		log:    testutil.Logger(t),
+		pools:  config.commitPools(),
+		nodeLabels: func(name string) map[string]string {
+			return labels[name]
+		},
+	}
+
+	inv := <-cinventory.NewNull(context.Background(), "nodeA", "nodeB", "nodeC").ResultChan()
+
+	// fits dedicated node as is
+	res, err := is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(1000, ""))
+	require.NoError(t, err)
+	require.Equal(t, "bare-metal", res.pool)
+	require.Equal(t, uint64(1000), res.Resources().GetResourceUnits()[0].CPU.Units.Value())
+	require.Equal(t, []string{"nodeA"}, res.placement[1])
+
+	// fits burstable node only once overcommitted
+	res, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(6000, ""))
+	require.NoError(t, err)
+	require.Equal(t, "burstable", res.pool)
+	require.Equal(t, uint64(3000), res.Resources().GetResourceUnits()[0].CPU.Units.Value())
+	require.Equal(t, []string{"nodeB"}, res.placement[1])
+
+	cparams := res.ClusterParams().(crd.ReservationClusterSettings)
+	require.Equal(t, &crd.SchedulerCommit{
+		Pool:        "burstable",
+		MatchLabels: map[string]string{"pool": "burstable"},
+		CPU:         2,
+	}, cparams[1].Commit)
+
+	// falls back to nodes of no pool with global commit levels
+	res, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(4500, ""))
+	require.NoError(t, err)
+	require.Equal(t, "", res.pool)
+	require.Equal(t, []string{"nodeC"}, res.placement[1])
+	require.Equal(t, &crd.SchedulerCommit{
+		CPU: 1,
+		ExcludeLabels: []map[string]string{
+			{"pool": "dedicated"},
+			{"pool": "burstable"},
+		},
+	}, res.ClusterParams().(crd.ReservationClusterSettings)[1].Commit)
+	require.Equal(t, "", is.commitPool("removed").Name)
+}
+
+func TestInventory_StorageClassCommitLevels(t *testing.T) {
+	config := Config{
+		StorageCommitLevel: 2,
+		Commit: CommitConfig{
+			StorageClasses: map[string]float64{"beta3": 4},
+		},
+	}
+
+	is := &inventoryService{
+		config: config,
+		pools:  config.commitPools(),
+	}
+
+	committed := is.resourcesToCommit(commitTestGroup(1000, ""), is.commitPool(""))
+	require.Equal(t, uint64(5*unit.Gi), committed.GetResourceUnits()[0].Storage[0].Quantity.Value())
+
+	committed = is.resourcesToCommit(commitTestGroup(1000, "beta3"), is.commitPool(""))
+	require.Equal(t, uint64(10*unit.Gi/4), committed.GetResourceUnits()[0].Storage[0].Quantity.Value())
+
+	committed = is.resourcesToCommit(commitTestGroup(1000, "beta2"), is.commitPool(""))
+	require.Equal(t, uint64(5*unit.Gi), committed.GetResourceUnits()[0].Storage[0].Quantity.Value())
+
+	// class levels reach workloads of the default pool
+	res := newReservation(testutil.OrderID(t), commitTestGroup(1000, "beta3"))
+	res.SetClusterParams(crd.ReservationClusterSettings{})
+
+	is.setCommitPool(res, is.commitPool(""))
+	require.Equal(t, &crd.SchedulerCommit{
+		Storage:        2,
+		StorageClasses: map[string]float64{"beta3": 4},
+	}, res.ClusterParams().(crd.ReservationClusterSettings)[1].Commit)
+
+	// nothing is recorded without pools and class levels
+	is.config.Commit = CommitConfig{}
+	res.SetClusterParams(crd.ReservationClusterSettings{})
+
+	is.setCommitPool(res, is.commitPool(""))
+	require.Nil(t, res.ClusterParams().(crd.ReservationClusterSettings)[1])
+}
@@ -1,11 +1,17 @@
 package cluster
 
-import "time"
+import (
+	"time"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
 
 type Config struct {
 	InventoryResourcePollPeriod     time.Duration
 	InventoryResourceDebugFrequency uint
 	InventoryExternalPortQuantity   uint
+	InventoryPlacementStrategy      string
+	InventoryPlacementPinning       string
 	CPUCommitLevel                  float64
 	GPUCommitLevel                  float64
 	MemoryCommitLevel               float64
@@ -18,7 +24,28 @@ type Config struct {
 	MonitorRetryPeriodJitter        time.Duration
 	MonitorHealthcheckPeriod        time.Duration
 	MonitorHealthcheckPeriodJitter  time.Duration
-	ClusterSettings                 map[interface{}]interface{}
+	// MonitorFailurePolicies is what monitor does once retries of lease failing for classified reason are exhausted.
+	// Leases failing for reasons without policy are closed
+	MonitorFailurePolicies ctypes.FailurePolicies
+	ClusterSettings        map[interface{}]interface{}
+	// ReservationsStatePath is the file pending reservations are persisted to across restarts.
+	// Reservations are kept in memory only when empty
+	ReservationsStatePath string
+	// Commit overrides global commit levels on matching node pools and storage classes
+	Commit CommitConfig
+	// Headroom is capacity inventory keeps free for system overhead and bursts
+	Headroom HeadroomConfig
+	// InventoryForecastInterval is how often usage is sampled for capacity forecasts, forecasting is disabled when zero
+	InventoryForecastInterval time.Duration
+	// InventoryForecastWindow is how far back samples are kept to compute usage trends
+	InventoryForecastWindow time.Duration
+	// InventoryForecastAlert raises an exhaustion alert when a resource is forecast to run out within it
+	InventoryForecastAlert time.Duration
+	// Preemptible is the attribute class orders opt into preemptible leases with, disabled when empty
+	Preemptible ctypes.PreemptibleClass
+	// RolloutPolicy is the strategy services of leases are updated with. Surge is decided per manifest,
+	// leases inventory has no spare capacity for at that time keep rolling update
+	RolloutPolicy ctypes.RolloutStrategy
 }
 
 func NewDefaultConfig() Config {
@@ -30,5 +57,9 @@ func NewDefaultConfig() Config {
 		MonitorRetryPeriodJitter:        time.Second * 15,
 		MonitorHealthcheckPeriod:        time.Second * 10, // nolint revive
 		MonitorHealthcheckPeriodJitter:  time.Second * 5,
+		InventoryForecastInterval:       time.Minute * 5,
+		InventoryForecastWindow:         time.Hour * 24 * 7,
+		InventoryForecastAlert:          time.Hour * 24 * 14,
+		RolloutPolicy:                   ctypes.RolloutRolling,
 	}
 }
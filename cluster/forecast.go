@@ -0,0 +1,255 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+var (
+	inventoryUsageTrend = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "provider_inventory_usage_trend",
+		Help: "change of used capacity per hour over the forecast window",
+	}, []string{"quantity"})
+
+	inventoryExhaustionSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "provider_inventory_exhaustion_seconds",
+		Help: "seconds until used capacity reaches allocatable at current trend, absent when usage is not growing",
+	}, []string{"quantity"})
+
+	inventoryExhaustionAlert = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "provider_inventory_exhaustion_alert",
+		Help: "1 when capacity is forecast to be exhausted within the alert threshold",
+	}, []string{"quantity"})
+)
+
+type resourceSample struct {
+	allocatable uint64
+	used        uint64
+	pending     uint64
+}
+
+type capacitySample struct {
+	at        time.Time
+	resources map[string]resourceSample
+}
+
+// capacityForecaster keeps a time series of inventory usage and extrapolates it linearly
+// to tell when each resource runs out
+type capacityForecaster struct {
+	window  time.Duration
+	alert   time.Duration
+	samples []capacitySample
+	alerted map[string]bool
+	log     log.Logger
+}
+
+// newCapacityForecaster returns nil when forecasting is disabled
+func newCapacityForecaster(config Config, log log.Logger) *capacityForecaster {
+	if config.InventoryForecastInterval <= 0 || config.InventoryForecastWindow <= 0 {
+		return nil
+	}
+
+	return &capacityForecaster{
+		window:  config.InventoryForecastWindow,
+		alert:   config.InventoryForecastAlert,
+		alerted: make(map[string]bool),
+		log:     log.With("cmp", "capacity-forecast"),
+	}
+}
+
+// sample records current usage, drops samples out of the window and refreshes metrics.
+// Metrics are of inventory with pending reservations adjusted in, unreserved ones of the same inventory without them,
+// so capacity pending reservations hold is measured the same way as used capacity
+func (f *capacityForecaster) sample(now time.Time, metrics inventoryV1.Metrics, unreserved inventoryV1.Metrics) {
+	resources := usageSamples(metrics)
+
+	for quantity, res := range usageSamples(unreserved) {
+		if sample, exists := resources[quantity]; exists && sample.used > res.used {
+			sample.pending = sample.used - res.used
+			resources[quantity] = sample
+		}
+	}
+
+	f.samples = append(f.samples, capacitySample{
+		at:        now,
+		resources: resources,
+	})
+
+	start := 0
+	for start < len(f.samples) && now.Sub(f.samples[start].at) > f.window {
+		start++
+	}
+
+	f.samples = f.samples[start:]
+
+	f.updateMetrics(now, f.forecast(now))
+}
+
+// forecast returns usage trend of every resource, nil when forecasting is disabled or nothing was sampled yet
+func (f *capacityForecaster) forecast(now time.Time) *ctypes.CapacityForecast {
+	if f == nil || len(f.samples) == 0 {
+		return nil
+	}
+
+	latest := f.samples[len(f.samples)-1]
+
+	result := &ctypes.CapacityForecast{
+		Samples:   len(f.samples),
+		Since:     f.samples[0].at,
+		Resources: make(map[string]ctypes.ResourceForecast, len(latest.resources)),
+	}
+
+	for quantity, res := range latest.resources {
+		forecast := ctypes.ResourceForecast{
+			Allocatable: res.allocatable,
+			Used:        res.used,
+			Pending:     res.pending,
+			Trend:       f.trend(quantity) * float64(time.Hour/time.Second),
+		}
+
+		if forecast.Trend > 0 {
+			left := float64(res.allocatable) - float64(res.used)
+			exhaustion := now
+
+			if left > 0 {
+				exhaustion = now.Add(time.Duration(left / forecast.Trend * float64(time.Hour)))
+			}
+
+			forecast.Exhaustion = &exhaustion
+		}
+
+		result.Resources[quantity] = forecast
+	}
+
+	return result
+}
+
+// trend is the least squares slope of used capacity per second over samples of the window
+func (f *capacityForecaster) trend(quantity string) float64 {
+	var n, sumX, sumY, sumXY, sumXX float64
+
+	origin := f.samples[0].at
+
+	for _, sample := range f.samples {
+		res, exists := sample.resources[quantity]
+		if !exists {
+			continue
+		}
+
+		x := sample.at.Sub(origin).Seconds()
+		y := float64(res.used)
+
+		n++
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if n < 2 || denominator == 0 {
+		return 0
+	}
+
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func (f *capacityForecaster) updateMetrics(now time.Time, forecast *ctypes.CapacityForecast) {
+	if forecast == nil {
+		return
+	}
+
+	for quantity, res := range forecast.Resources {
+		inventoryUsageTrend.WithLabelValues(quantity).Set(res.Trend)
+
+		if res.Exhaustion == nil {
+			inventoryExhaustionSeconds.DeleteLabelValues(quantity)
+		} else {
+			inventoryExhaustionSeconds.WithLabelValues(quantity).Set(math.Max(res.Exhaustion.Sub(now).Seconds(), 0))
+		}
+
+		alert := f.alert > 0 && res.Exhaustion != nil && res.Exhaustion.Sub(now) <= f.alert
+
+		if alert && !f.alerted[quantity] {
+			f.log.Info("capacity forecast to be exhausted", "quantity", quantity, "exhaustion", res.Exhaustion.UTC(), "trend", res.Trend)
+		} else if !alert && f.alerted[quantity] {
+			f.log.Info("capacity no longer forecast to be exhausted", "quantity", quantity)
+		}
+
+		f.alerted[quantity] = alert
+
+		if alert {
+			inventoryExhaustionAlert.WithLabelValues(quantity).Set(1)
+		} else {
+			inventoryExhaustionAlert.WithLabelValues(quantity).Set(0)
+		}
+	}
+}
+
+// usageSamples returns usage of every resource keyed by quantity
+func usageSamples(metrics inventoryV1.Metrics) map[string]resourceSample {
+	resources := map[string]resourceSample{
+		"cpu":               usageSample(metrics.TotalAllocatable.CPU, metrics.TotalAvailable.CPU),
+		"gpu":               usageSample(metrics.TotalAllocatable.GPU, metrics.TotalAvailable.GPU),
+		"memory":            usageSample(metrics.TotalAllocatable.Memory, metrics.TotalAvailable.Memory),
+		"storage-ephemeral": usageSample(metrics.TotalAllocatable.StorageEphemeral, metrics.TotalAvailable.StorageEphemeral),
+	}
+
+	for class, allocatable := range metrics.TotalAllocatable.Storage {
+		resources[fmt.Sprintf("storage-%s", class)] = usageSample(
+			nonNegative(allocatable),
+			nonNegative(metrics.TotalAvailable.Storage[class]),
+		)
+	}
+
+	return resources
+}
+
+func usageSample(allocatable uint64, available uint64) resourceSample {
+	res := resourceSample{allocatable: allocatable}
+
+	if allocatable > available {
+		res.used = allocatable - available
+	}
+
+	return res
+}
+
+func nonNegative(val int64) uint64 {
+	if val < 0 {
+		return 0
+	}
+
+	return uint64(val)
+}
+
+// capacityForecast returns usage trends, nil when forecasting is disabled
+func (is *inventoryService) capacityForecast(ctx context.Context) (*ctypes.CapacityForecast, error) {
+	ch := make(chan *ctypes.CapacityForecast, 1)
+
+	select {
+	case <-is.lc.Done():
+		return nil, ErrNotRunning
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case is.forecastch <- ch:
+	}
+
+	select {
+	case <-is.lc.Done():
+		return nil, ErrNotRunning
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		return result, nil
+	}
+}
@@ -0,0 +1,90 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	"github.com/akash-network/node/testutil"
+)
+
+func forecastTestMetrics(cpuAvailable uint64, storageAvailable int64) inventoryV1.Metrics {
+	return inventoryV1.Metrics{
+		TotalAllocatable: inventoryV1.MetricTotal{
+			CPU:     10000,
+			GPU:     2,
+			Memory:  1000,
+			Storage: map[string]int64{"beta2": 1000},
+		},
+		TotalAvailable: inventoryV1.MetricTotal{
+			CPU:     cpuAvailable,
+			GPU:     2,
+			Memory:  1000,
+			Storage: map[string]int64{"beta2": storageAvailable},
+		},
+	}
+}
+
+func TestNewCapacityForecasterDisabled(t *testing.T) {
+	require.Nil(t, newCapacityForecaster(Config{InventoryForecastWindow: time.Hour}, testutil.Logger(t)))
+	require.Nil(t, (*capacityForecaster)(nil).forecast(time.Now()))
+}
+
+func TestCapacityForecaster(t *testing.T) {
+	f := newCapacityForecaster(Config{
+		InventoryForecastInterval: time.Hour,
+		InventoryForecastWindow:   3 * time.Hour,
+		InventoryForecastAlert:    24 * time.Hour,
+	}, testutil.Logger(t))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// cpu usage grows by 1000m an hour, storage shrinks, gpu and memory stay idle.
+	// 500m of used cpu is held by pending reservations
+	for hour := 0; hour < 5; hour++ {
+		f.sample(start.Add(time.Duration(hour)*time.Hour),
+			forecastTestMetrics(uint64(9000-1000*hour), int64(500+100*hour)),
+			forecastTestMetrics(uint64(9500-1000*hour), int64(500+100*hour)))
+	}
+
+	now := start.Add(4 * time.Hour)
+	forecast := f.forecast(now)
+
+	// samples older than the window are dropped
+	require.Equal(t, 4, forecast.Samples)
+	require.Equal(t, start.Add(time.Hour), forecast.Since)
+
+	cpu := forecast.Resources["cpu"]
+	require.Equal(t, uint64(5000), cpu.Used)
+	require.Equal(t, uint64(500), cpu.Pending)
+	require.InDelta(t, 1000, cpu.Trend, 0.001)
+	require.NotNil(t, cpu.Exhaustion)
+	require.WithinDuration(t, now.Add(5*time.Hour), *cpu.Exhaustion, time.Second)
+	require.True(t, f.alerted["cpu"])
+
+	storage := forecast.Resources["storage-beta2"]
+	require.Equal(t, uint64(100), storage.Used)
+	require.Zero(t, storage.Pending)
+	require.Less(t, storage.Trend, 0.0)
+	require.Nil(t, storage.Exhaustion)
+
+	require.Nil(t, forecast.Resources["gpu"].Exhaustion)
+	require.False(t, f.alerted["gpu"])
+}
+
+func TestCapacityForecasterExhausted(t *testing.T) {
+	f := newCapacityForecaster(Config{
+		InventoryForecastInterval: time.Minute,
+		InventoryForecastWindow:   time.Hour,
+	}, testutil.Logger(t))
+
+	start := time.Now()
+	f.sample(start, forecastTestMetrics(1000, 1000), forecastTestMetrics(1000, 1000))
+	f.sample(start.Add(time.Minute), forecastTestMetrics(0, 1000), forecastTestMetrics(0, 1000))
+
+	cpu := f.forecast(start.Add(time.Minute)).Resources["cpu"]
+	require.Equal(t, start.Add(time.Minute), *cpu.Exhaustion)
+	require.False(t, f.alerted["cpu"])
+}
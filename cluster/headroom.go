@@ -0,0 +1,252 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+)
+
+var errHeadroomInvalid = errors.New("invalid headroom")
+
+// HeadroomRule is capacity to keep free. Every value is either an absolute quantity,
+// e.g. 500m cpu or 4Gi memory, or a percentage of allocatable capacity, e.g. 5%
+type HeadroomRule struct {
+	CPU              string `yaml:"cpu"`
+	GPU              string `yaml:"gpu"`
+	Memory           string `yaml:"memory"`
+	StorageEphemeral string `yaml:"storage_ephemeral"`
+}
+
+// HeadroomConfig is the yaml representation of capacity inventory does not bid on.
+// Node rule applies to every node, cluster rule to the sum of all nodes
+//
+//	node:
+//	  cpu: 500m
+//	  memory: 5%
+//	cluster:
+//	  gpu: 2
+type HeadroomConfig struct {
+	Node    HeadroomRule `yaml:"node"`
+	Cluster HeadroomRule `yaml:"cluster"`
+}
+
+// ReadHeadroomConfigPath reads headroom rules from yaml file
+func ReadHeadroomConfigPath(path string) (HeadroomConfig, error) {
+	var cfg HeadroomConfig
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err = yaml.Unmarshal(buf, &cfg); err != nil {
+		return cfg, err
+	}
+
+	if _, err = newHeadroom(cfg); err != nil {
+		return HeadroomConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+type headroomAmount struct {
+	absolute uint64
+	percent  float64
+}
+
+func parseHeadroomAmount(val string, milli bool) (headroomAmount, error) {
+	if val == "" {
+		return headroomAmount{}, nil
+	}
+
+	if pct, isPercent := strings.CutSuffix(val, "%"); isPercent {
+		percent, err := strconv.ParseFloat(pct, 64)
+		if err != nil {
+			return headroomAmount{}, fmt.Errorf("%w: %q: %w", errHeadroomInvalid, val, err)
+		}
+
+		if percent < 0 || percent > 100 {
+			return headroomAmount{}, fmt.Errorf("%w: %q is out of 0-100%% range", errHeadroomInvalid, val)
+		}
+
+		return headroomAmount{percent: percent}, nil
+	}
+
+	quantity, err := resource.ParseQuantity(val)
+	if err != nil {
+		return headroomAmount{}, fmt.Errorf("%w: %q: %w", errHeadroomInvalid, val, err)
+	}
+
+	if quantity.Sign() < 0 {
+		return headroomAmount{}, fmt.Errorf("%w: %q is negative", errHeadroomInvalid, val)
+	}
+
+	if milli {
+		return headroomAmount{absolute: uint64(quantity.MilliValue())}, nil // nolint: gosec
+	}
+
+	return headroomAmount{absolute: uint64(quantity.Value())}, nil // nolint: gosec
+}
+
+func (a headroomAmount) of(allocatable uint64) uint64 {
+	if a.percent > 0 {
+		return uint64(float64(allocatable) * a.percent / 100)
+	}
+
+	return a.absolute
+}
+
+type headroomLimits struct {
+	cpu              headroomAmount
+	gpu              headroomAmount
+	memory           headroomAmount
+	storageEphemeral headroomAmount
+}
+
+func newHeadroomLimits(rule HeadroomRule) (headroomLimits, error) {
+	var limits headroomLimits
+	var err error
+
+	if limits.cpu, err = parseHeadroomAmount(rule.CPU, true); err != nil {
+		return limits, fmt.Errorf("cpu: %w", err)
+	}
+
+	if limits.gpu, err = parseHeadroomAmount(rule.GPU, false); err != nil {
+		return limits, fmt.Errorf("gpu: %w", err)
+	}
+
+	if limits.memory, err = parseHeadroomAmount(rule.Memory, false); err != nil {
+		return limits, fmt.Errorf("memory: %w", err)
+	}
+
+	if limits.storageEphemeral, err = parseHeadroomAmount(rule.StorageEphemeral, false); err != nil {
+		return limits, fmt.Errorf("storage_ephemeral: %w", err)
+	}
+
+	return limits, nil
+}
+
+func (l headroomLimits) of(allocatable ctypes.ResourceHeadroom) ctypes.ResourceHeadroom {
+	return ctypes.ResourceHeadroom{
+		CPU:              l.cpu.of(allocatable.CPU),
+		GPU:              l.gpu.of(allocatable.GPU),
+		Memory:           l.memory.of(allocatable.Memory),
+		StorageEphemeral: l.storageEphemeral.of(allocatable.StorageEphemeral),
+	}
+}
+
+type headroom struct {
+	node    headroomLimits
+	cluster headroomLimits
+}
+
+var _ ctypes.Headroom = (*headroom)(nil)
+
+// newHeadroom returns nil when config keeps no capacity free
+func newHeadroom(cfg HeadroomConfig) (ctypes.Headroom, error) {
+	if cfg == (HeadroomConfig{}) {
+		return nil, nil
+	}
+
+	node, err := newHeadroomLimits(cfg.Node)
+	if err != nil {
+		return nil, fmt.Errorf("node %w", err)
+	}
+
+	cluster, err := newHeadroomLimits(cfg.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %w", err)
+	}
+
+	return &headroom{
+		node:    node,
+		cluster: cluster,
+	}, nil
+}
+
+func (h *headroom) Node(nd *inventoryV1.Node) ctypes.ResourceHeadroom {
+	return h.node.of(cinventory.NodeAllocatable(nd))
+}
+
+func (h *headroom) Cluster(allocatable inventoryV1.MetricTotal) ctypes.ResourceHeadroom {
+	return h.cluster.of(ctypes.ResourceHeadroom{
+		CPU:              allocatable.CPU,
+		GPU:              allocatable.GPU,
+		Memory:           allocatable.Memory,
+		StorageEphemeral: allocatable.StorageEphemeral,
+	})
+}
+
+// headroomStatus returns capacity currently kept free, nil when no headroom is configured
+func (is *inventoryService) headroomStatus(ctx context.Context) (*ctypes.HeadroomStatus, error) {
+	ch := make(chan *ctypes.HeadroomStatus, 1)
+
+	select {
+	case <-is.lc.Done():
+		return nil, ErrNotRunning
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case is.headroomch <- ch:
+	}
+
+	select {
+	case <-is.lc.Done():
+		return nil, ErrNotRunning
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		return result, nil
+	}
+}
+
+func getHeadroomStatus(headroom ctypes.Headroom, inv ctypes.Inventory) *ctypes.HeadroomStatus {
+	if headroom == nil || inv == nil {
+		return nil
+	}
+
+	snapshot := inv.Snapshot()
+
+	status := &ctypes.HeadroomStatus{
+		Cluster: headroom.Cluster(inv.Metrics().TotalAllocatable),
+		Nodes:   make(map[string]ctypes.ResourceHeadroom, len(snapshot.Nodes)),
+	}
+
+	for idx := range snapshot.Nodes {
+		status.Nodes[snapshot.Nodes[idx].Name] = headroom.Node(&snapshot.Nodes[idx])
+	}
+
+	return status
+}
+
+func updateHeadroomMetrics(status *ctypes.HeadroomStatus) {
+	if status == nil {
+		return
+	}
+
+	nodes := ctypes.ResourceHeadroom{}
+	for _, nd := range status.Nodes {
+		nodes.CPU += nd.CPU
+		nodes.GPU += nd.GPU
+		nodes.Memory += nd.Memory
+		nodes.StorageEphemeral += nd.StorageEphemeral
+	}
+
+	for scope, val := range map[string]ctypes.ResourceHeadroom{"node": nodes, "cluster": status.Cluster} {
+		clusterInventoryHeadroom.WithLabelValues(scope, "cpu").Set(float64(val.CPU) / 1000)
+		clusterInventoryHeadroom.WithLabelValues(scope, "gpu").Set(float64(val.GPU))
+		clusterInventoryHeadroom.WithLabelValues(scope, "memory").Set(float64(val.Memory))
+		clusterInventoryHeadroom.WithLabelValues(scope, "storage-ephemeral").Set(float64(val.StorageEphemeral))
+	}
+}
@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akash-network/akash-api/go/node/types/unit"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+)
+
+func TestReadHeadroomConfigPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "headroom.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+node:
+  cpu: 500m
+  memory: 5%
+cluster:
+  gpu: 2
+`), 0o600))
+
+	cfg, err := ReadHeadroomConfigPath(path)
+	require.NoError(t, err)
+	require.Equal(t, HeadroomConfig{
+		Node:    HeadroomRule{CPU: "500m", Memory: "5%"},
+		Cluster: HeadroomRule{GPU: "2"},
+	}, cfg)
+
+	for _, invalid := range []string{"node:\n  cpu: 120%\n", "node:\n  memory: -1Gi\n", "cluster:\n  gpu: many\n"} {
+		require.NoError(t, os.WriteFile(path, []byte(invalid), 0o600))
+
+		_, err = ReadHeadroomConfigPath(path)
+		require.ErrorIs(t, err, errHeadroomInvalid)
+	}
+}
+
+func TestNewHeadroomNone(t *testing.T) {
+	headroom, err := newHeadroom(HeadroomConfig{})
+	require.NoError(t, err)
+	require.Nil(t, headroom)
+}
+
+func TestHeadroom_Node(t *testing.T) {
+	headroom, err := newHeadroom(HeadroomConfig{
+		Node: HeadroomRule{CPU: "10%", Memory: "1Gi"},
+	})
+	require.NoError(t, err)
+
+	snapshot := (<-cinventory.NewNull(context.Background(), "nodeA").ResultChan()).Snapshot()
+
+	require.Equal(t, ctypes.ResourceHeadroom{
+		CPU:    500,
+		Memory: unit.Gi,
+	}, headroom.Node(&snapshot.Nodes[0]))
+}
+
+func TestInventory_NodeHeadroom(t *testing.T) {
+	headroom, err := newHeadroom(HeadroomConfig{
+		Node: HeadroomRule{CPU: "1"},
+	})
+	require.NoError(t, err)
+
+	is := &inventoryService{
+		log:      testutil.Logger(t),
+		pools:    Config{}.commitPools(),
+		headroom: headroom,
+	}
+
+	inv := <-cinventory.NewNull(context.Background(), "nodeA").ResultChan()
+
+	// node has 4900m available, 4000m would leave less than 1 cpu free
+	_, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(4000, ""))
+	require.ErrorIs(t, err, ctypes.ErrInsufficientCapacity)
+
+	_, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(3900, ""))
+	require.NoError(t, err)
+
+	status := getHeadroomStatus(is.headroom, inv)
+	require.Equal(t, ctypes.ResourceHeadroom{CPU: 1000}, status.Nodes["nodeA"])
+}
+
+func TestInventory_ClusterGPUHeadroom(t *testing.T) {
+	headroom, err := newHeadroom(HeadroomConfig{
+		Cluster: HeadroomRule{GPU: "1"},
+	})
+	require.NoError(t, err)
+
+	is := &inventoryService{
+		log:      testutil.Logger(t),
+		pools:    Config{}.commitPools(),
+		headroom: headroom,
+	}
+
+	// solo node has a single gpu left
+	inv := <-cinventory.NewNull(context.Background()).ResultChan()
+
+	group := commitTestGroup(1000, "")
+	group.Resources[0].GPU = &types.GPU{Units: types.NewResourceValue(1)}
+
+	_, err = is.adjustReservation(inv, testutil.OrderID(t), group)
+	require.ErrorIs(t, err, ctypes.ErrInsufficientCapacity)
+
+	// cpu only workloads do not touch gpu headroom
+	_, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(1000, ""))
+	require.NoError(t, err)
+}
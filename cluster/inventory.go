@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -17,6 +18,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/tendermint/tendermint/libs/log"
 	tpubsub "github.com/troian/pubsub"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
@@ -30,6 +36,7 @@ import (
 	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
 	cip "github.com/akash-network/provider/cluster/types/v1beta3/clients/ip"
 	cfromctx "github.com/akash-network/provider/cluster/types/v1beta3/fromctx"
+	"github.com/akash-network/provider/cluster/util"
 	"github.com/akash-network/provider/event"
 	"github.com/akash-network/provider/operator/waiter"
 	"github.com/akash-network/provider/tools/fromctx"
@@ -66,6 +73,11 @@ var (
 		Name: "provider_inventory_available_total",
 		Help: "",
 	}, []string{"quantity"})
+
+	clusterInventoryHeadroom = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "provider_inventory_headroom_total",
+		Help: "capacity kept free for system overhead, summed over nodes for node scope",
+	}, []string{"scope", "quantity"})
 )
 
 type invSnapshotResp struct {
@@ -79,6 +91,11 @@ type inventoryRequest struct {
 	ch        chan<- inventoryResponse
 }
 
+type ownerUsageRequest struct {
+	owner string
+	ch    chan<- ctypes.OwnerUsage
+}
+
 type inventoryResponse struct {
 	value ctypes.Reservation
 	err   error
@@ -92,13 +109,27 @@ type inventoryService struct {
 	statusV1ch             chan chan<- invSnapshotResp
 	lookupch               chan inventoryRequest
 	reservech              chan inventoryRequest
+	dryrunch               chan inventoryRequest
 	unreservech            chan inventoryRequest
+	ownerUsagech           chan ownerUsageRequest
+	headroomch             chan chan<- *ctypes.HeadroomStatus
+	forecastch             chan chan<- *ctypes.CapacityForecast
 	reservationCount       int64
 	readych                chan struct{}
 	log                    log.Logger
 	lc                     lifecycle.Lifecycle
 	waiter                 waiter.OperatorWaiter
 	availableExternalPorts uint
+	store                  *reservationStore
+	placement              ctypes.PlacementStrategy
+	pools                  []CommitPool
+	nodeInformers          map[string]informers.SharedInformerFactory
+	nodeLabels             func(name string) map[string]string
+	nodeMaintenance        func() []ctypes.NodeMaintenance
+	headroom               ctypes.Headroom
+	forecaster             *capacityForecaster
+	// backendMetrics is the latest inventory of each cluster when provider runs several clusters
+	backendMetrics atomic.Pointer[map[string]inventoryV1.Metrics]
 
 	clients struct {
 		ip        cip.Client
@@ -114,8 +145,23 @@ func newInventoryService(
 	client Client,
 	waiter waiter.OperatorWaiter,
 	deployments []ctypes.IDeployment,
+	restored []*reservation,
 ) (*inventoryService, error) {
-	sub, err := sub.Clone()
+	placement, err := cinventory.NewPlacementStrategy(config.InventoryPlacementStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validatePlacementPinning(config.InventoryPlacementPinning); err != nil {
+		return nil, err
+	}
+
+	headroom, err := newHeadroom(config.Headroom)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err = sub.Clone()
 	if err != nil {
 		return nil, err
 	}
@@ -128,25 +174,40 @@ func newInventoryService(
 		statusV1ch:             make(chan chan<- invSnapshotResp),
 		lookupch:               make(chan inventoryRequest),
 		reservech:              make(chan inventoryRequest),
+		dryrunch:               make(chan inventoryRequest),
 		unreservech:            make(chan inventoryRequest),
+		ownerUsagech:           make(chan ownerUsageRequest),
+		headroomch:             make(chan chan<- *ctypes.HeadroomStatus),
+		forecastch:             make(chan chan<- *ctypes.CapacityForecast),
 		readych:                make(chan struct{}),
 		log:                    log.With("cmp", "inventory-service"),
 		lc:                     lifecycle.New(),
 		availableExternalPorts: config.InventoryExternalPortQuantity,
 		waiter:                 waiter,
+		store:                  newReservationStore(config.ReservationsStatePath),
+		placement:              placement,
+		pools:                  config.commitPools(),
+		headroom:               headroom,
 	}
 
+	is.forecaster = newCapacityForecaster(config, is.log)
+
+	is.watchNodes(ctx)
+
 	is.clients.inventory = cfromctx.ClientInventoryFromContext(ctx)
 	is.clients.ip = cfromctx.ClientIPFromContext(ctx)
 
-	reservations := make([]*reservation, 0, len(deployments))
+	reservations := make([]*reservation, 0, len(deployments)+len(restored))
 	for _, d := range deployments {
 		res := newReservation(d.LeaseID().OrderID(), d.ManifestGroup())
 		res.SetClusterParams(d.ClusterParams())
+		is.restorePreemptible(res, d.ManifestGroup(), d.ClusterParams())
 
 		reservations = append(reservations, res)
 	}
 
+	reservations = append(reservations, restored...)
+
 	go is.lc.WatchChannel(ctx.Done())
 	go is.run(ctx, reservations)
 
@@ -211,6 +272,24 @@ func (is *inventoryService) reserve(order mtypes.OrderID, resources dtypes.Resou
 	}
 }
 
+// reserveDryRun checks if resources fit current inventory without reserving them
+func (is *inventoryService) reserveDryRun(order mtypes.OrderID, resources dtypes.ResourceGroup) (ctypes.Reservation, error) {
+	ch := make(chan inventoryResponse, 1)
+	req := inventoryRequest{
+		order:     order,
+		resources: resources,
+		ch:        ch,
+	}
+
+	select {
+	case is.dryrunch <- req:
+		response := <-ch
+		return response.value, response.err
+	case <-is.lc.ShuttingDown():
+		return nil, ErrNotRunning
+	}
+}
+
 func (is *inventoryService) unreserve(order mtypes.OrderID) error { // nolint: golint,unparam
 	ch := make(chan inventoryResponse, 1)
 	req := inventoryRequest{
@@ -252,6 +331,31 @@ func (is *inventoryService) status(ctx context.Context) (inventoryV1.InventoryMe
 	}
 }
 
+func (is *inventoryService) ownerUsage(ctx context.Context, owner string) (ctypes.OwnerUsage, error) {
+	ch := make(chan ctypes.OwnerUsage, 1)
+	req := ownerUsageRequest{
+		owner: owner,
+		ch:    ch,
+	}
+
+	select {
+	case <-is.lc.Done():
+		return ctypes.OwnerUsage{}, ErrNotRunning
+	case <-ctx.Done():
+		return ctypes.OwnerUsage{}, ctx.Err()
+	case is.ownerUsagech <- req:
+	}
+
+	select {
+	case <-is.lc.Done():
+		return ctypes.OwnerUsage{}, ErrNotRunning
+	case <-ctx.Done():
+		return ctypes.OwnerUsage{}, ctx.Err()
+	case result := <-ch:
+		return result, nil
+	}
+}
+
 func (is *inventoryService) statusV1(ctx context.Context) (*provider.Inventory, error) {
 	ch := make(chan invSnapshotResp, 1)
 
@@ -273,22 +377,84 @@ func (is *inventoryService) statusV1(ctx context.Context) (*provider.Inventory,
 	}
 }
 
-func (is *inventoryService) resourcesToCommit(rgroup dtypes.ResourceGroup) dtypes.ResourceGroup {
+// watchNodes starts node informer of every cluster commit pools are matched against and node maintenance is read from.
+// Node names are expected to be unique across clusters.
+// Without kube client nodes are not watched, commit pools match no node and no node is in maintenance
+func (is *inventoryService) watchNodes(ctx context.Context) {
+	clients := cfromctx.KubeClientBackendsFromContext(ctx)
+	if len(clients) == 0 {
+		kc, err := fromctx.KubeClientFromCtx(ctx)
+		if err != nil {
+			is.log.Info("nodes are not watched", "reason", err.Error())
+			return
+		}
+
+		clients = map[string]kubernetes.Interface{PrimaryBackend: kc}
+	}
+
+	names := make([]string, 0, len(clients))
+	for name := range clients {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	is.nodeInformers = make(map[string]informers.SharedInformerFactory, len(clients))
+	listers := make([]corelisters.NodeLister, 0, len(clients))
+
+	for _, name := range names {
+		factory := informers.NewSharedInformerFactory(clients[name], 0)
+		listers = append(listers, factory.Core().V1().Nodes().Lister())
+
+		is.nodeInformers[name] = factory
+	}
+
+	is.nodeLabels = func(name string) map[string]string {
+		for _, lister := range listers {
+			if node, err := lister.Get(name); err == nil {
+				return node.Labels
+			}
+		}
+
+		return nil
+	}
+
+	is.nodeMaintenance = func() []ctypes.NodeMaintenance {
+		var nodes []*corev1.Node
+
+		for _, lister := range listers {
+			items, err := lister.List(labels.Everything())
+			if err != nil {
+				continue
+			}
+
+			nodes = append(nodes, items...)
+		}
+
+		return nodesMaintenance(is.log, nodes)
+	}
+
+	for _, factory := range is.nodeInformers {
+		factory.Start(ctx.Done())
+	}
+}
+
+func (is *inventoryService) resourcesToCommit(rgroup dtypes.ResourceGroup, pool CommitPool) dtypes.ResourceGroup {
 	replacedResources := make(dtypes.ResourceUnits, 0)
 
 	for _, resource := range rgroup.GetResourceUnits() {
 		runits := atypes.Resources{
 			ID: resource.ID,
 			CPU: &atypes.CPU{
-				Units:      sdlutil.ComputeCommittedResources(is.config.CPUCommitLevel, resource.Resources.GetCPU().GetUnits()),
+				Units:      sdlutil.ComputeCommittedResources(pool.CPU, resource.Resources.GetCPU().GetUnits()),
 				Attributes: resource.Resources.GetCPU().GetAttributes(),
 			},
 			GPU: &atypes.GPU{
-				Units:      sdlutil.ComputeCommittedResources(is.config.GPUCommitLevel, resource.Resources.GetGPU().GetUnits()),
+				Units:      sdlutil.ComputeCommittedResources(pool.GPU, resource.Resources.GetGPU().GetUnits()),
 				Attributes: resource.Resources.GetGPU().GetAttributes(),
 			},
 			Memory: &atypes.Memory{
-				Quantity:   sdlutil.ComputeCommittedResources(is.config.MemoryCommitLevel, resource.Resources.GetMemory().GetQuantity()),
+				Quantity:   sdlutil.ComputeCommittedResources(pool.Memory, resource.Resources.GetMemory().GetQuantity()),
 				Attributes: resource.Resources.GetMemory().GetAttributes(),
 			},
 			Endpoints: resource.Resources.GetEndpoints(),
@@ -299,7 +465,7 @@ func (is *inventoryService) resourcesToCommit(rgroup dtypes.ResourceGroup) dtype
 		for _, volume := range resource.Resources.GetStorage() {
 			storage = append(storage, atypes.Storage{
 				Name:       volume.Name,
-				Quantity:   sdlutil.ComputeCommittedResources(is.config.StorageCommitLevel, volume.GetQuantity()),
+				Quantity:   sdlutil.ComputeCommittedResources(is.storageCommitLevel(pool, volume), volume.GetQuantity()),
 				Attributes: volume.GetAttributes(),
 			})
 		}
@@ -415,36 +581,97 @@ func countPendingIPs(state *inventoryServiceState) uint {
 	return pending
 }
 
+// persistReservations queues pending reservations to be stored if the store is configured
+func (is *inventoryService) persistReservations(state *inventoryServiceState) {
+	if is.store == nil {
+		return
+	}
+
+	if err := is.store.update(state.reservations); err != nil {
+		is.log.Error("unable to persist reservations", "err", err)
+	}
+}
+
+// claimRestoredReservation hands reservation restored on startup back to the order requesting it again
+func (is *inventoryService) claimRestoredReservation(req inventoryRequest, state *inventoryServiceState) bool {
+	for _, res := range state.reservations {
+		if res.restoredBid == nil || !res.OrderID().Equals(req.order) {
+			continue
+		}
+		if res.Resources().GetName() != req.resources.GetName() {
+			continue
+		}
+
+		res.restoredBid = nil
+		res.requested = req.resources
+
+		is.log.Info("restored reservation claimed", "order", req.order)
+		req.ch <- inventoryResponse{value: res}
+		inventoryRequestsCounter.WithLabelValues("reserve", "restored").Inc()
+
+		return true
+	}
+
+	return false
+}
+
+// dropRestoredReservations removes restored reservations nobody claimed back which bid is no longer open
+func (is *inventoryService) dropRestoredReservations(state *inventoryServiceState, stale func(bid mtypes.BidID) bool) {
+	reservations := state.reservations[:0]
+	dropped := false
+
+	for _, res := range state.reservations {
+		if res.restoredBid != nil && stale(*res.restoredBid) {
+			is.log.Info("dropping stale restored reservation", "order", res.OrderID())
+			inventoryRequestsCounter.WithLabelValues("unreserve", "stale").Inc()
+			dropped = true
+			continue
+		}
+
+		reservations = append(reservations, res)
+	}
+
+	state.reservations = reservations
+
+	if dropped {
+		is.persistReservations(state)
+	}
+}
+
 func (is *inventoryService) handleRequest(req inventoryRequest, state *inventoryServiceState) {
-	// convert the resources to the committed amount
-	resourcesToCommit := is.resourcesToCommit(req.resources)
-	// create new registration if capacity available
-	reservation := newReservation(req.order, resourcesToCommit)
+	if is.claimRestoredReservation(req, state) {
+		return
+	}
 
 	{
 		jReservation, _ := json.Marshal(req.resources.GetResourceUnits())
 		is.log.Debug(fmt.Sprintf("reservation requested. order=%s, resources=%s", req.order, jReservation))
 	}
 
-	if reservation.endpointQuantity != 0 {
+	endpointQuantity := util.GetEndpointQuantityOfResourceGroup(req.resources, atypes.Endpoint_LEASED_IP)
+
+	if endpointQuantity != 0 {
 		if is.clients.ip == nil {
 			req.ch <- inventoryResponse{err: errNoLeasedIPsAvailable}
 			return
 		}
 		numIPUnused := state.ipAddrUsage.Available - state.ipAddrUsage.InUse
 		pending := countPendingIPs(state)
-		if reservation.endpointQuantity > (numIPUnused - pending) {
+		if endpointQuantity > (numIPUnused - pending) {
 			is.log.Info("insufficient number of IP addresses available", "order", req.order)
-			req.ch <- inventoryResponse{err: fmt.Errorf("%w: unable to reserve %d", errInsufficientIPs, reservation.endpointQuantity)}
+			req.ch <- inventoryResponse{err: fmt.Errorf("%w: unable to reserve %d", errInsufficientIPs, endpointQuantity)}
 			return
 		}
 
-		is.log.Info("reservation used leased IPs", "used", reservation.endpointQuantity, "available", state.ipAddrUsage.Available, "in-use", state.ipAddrUsage.InUse, "pending", pending)
-	} else {
-		reservation.ipsConfirmed = true // No IPs, just mark it as confirmed implicitly
+		is.log.Info("reservation used leased IPs", "used", endpointQuantity, "available", state.ipAddrUsage.Available, "in-use", state.ipAddrUsage.InUse, "pending", pending)
+	}
+
+	// create new registration if capacity available in any of commit pools
+	reservation, err := is.adjustReservation(state.inventory, req.order, req.resources)
+	if errors.Is(err, ctypes.ErrInsufficientCapacity) {
+		reservation, err = is.adjustPreempting(state, req.order, req.resources)
 	}
 
-	err := state.inventory.Adjust(reservation)
 	if err != nil {
 		is.log.Info("insufficient capacity for reservation", "order", req.order)
 		inventoryRequestsCounter.WithLabelValues("reserve", "insufficient-capacity").Inc()
@@ -452,11 +679,75 @@ func (is *inventoryService) handleRequest(req inventoryRequest, state *inventory
 		return
 	}
 
+	// No IPs, just mark it as confirmed implicitly
+	reservation.ipsConfirmed = endpointQuantity == 0
+
 	// Add the reservation to the list
 	state.reservations = append(state.reservations, reservation)
+	is.persistReservations(state)
+
 	req.ch <- inventoryResponse{value: reservation}
 	inventoryRequestsCounter.WithLabelValues("reserve", "create").Inc()
+}
+
+// adjustReservation converts resources to the committed amount of each commit pool in turn
+// and reserves them on nodes of the first pool they fit in
+func (is *inventoryService) adjustReservation(
+	inv ctypes.Inventory,
+	order mtypes.OrderID,
+	resources dtypes.ResourceGroup,
+	opts ...ctypes.InventoryOption,
+) (*reservation, error) {
+	var err error
+
+	for _, pool := range is.pools {
+		res := newReservation(order, is.resourcesToCommit(resources, pool))
+		res.requested = resources
+		res.pool = pool.Name
+		res.preemptible = is.config.Preemptible.MatchesGroup(resources)
+
+		if err = inv.Adjust(res, append(is.adjustOptions(pool.Name), opts...)...); err == nil {
+			is.finalizeReservation(res, pool)
+			return res, nil
+		}
+	}
+
+	return nil, err
+}
+
+// adjustOptions returns options reservations committed in the pool are adjusted with,
+// both when reserved and when readjusted against refreshed inventory
+func (is *inventoryService) adjustOptions(pool string) []ctypes.InventoryOption {
+	return []ctypes.InventoryOption{
+		ctypes.WithPlacementStrategy(is.skipMaintenance(is.placementFor(pool))),
+		ctypes.WithHeadroom(is.headroom),
+	}
+}
 
+// finalizeReservation carries placement and commit pool decisions of adjusted reservation into its cluster params
+func (is *inventoryService) finalizeReservation(res *reservation, pool CommitPool) {
+	pinPlacement(res, is.config.InventoryPlacementPinning)
+	is.setCommitPool(res, pool)
+	setPreemptible(res)
+	setCluster(res)
+}
+
+func (is *inventoryService) handleDryRunRequest(req inventoryRequest, state *inventoryServiceState) {
+	if state.inventory == nil {
+		inventoryRequestsCounter.WithLabelValues("dry-run", "not-ready").Inc()
+		req.ch <- inventoryResponse{err: errInventoryNotAvailableYet}
+		return
+	}
+
+	reservation, err := is.adjustReservation(state.inventory, req.order, req.resources, ctypes.WithDryRun())
+	if err != nil {
+		inventoryRequestsCounter.WithLabelValues("dry-run", "insufficient-capacity").Inc()
+		req.ch <- inventoryResponse{err: err}
+		return
+	}
+
+	inventoryRequestsCounter.WithLabelValues("dry-run", "success").Inc()
+	req.ch <- inventoryResponse{value: reservation}
 }
 
 func (is *inventoryService) run(ctx context.Context, reservationsArg []*reservation) {
@@ -466,6 +757,22 @@ func (is *inventoryService) run(ctx context.Context, reservationsArg []*reservat
 	rctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// pending reservations are written in background, the last update is written before shutdown completes
+	if is.store != nil {
+		storectx, storecancel := context.WithCancel(context.Background())
+		storedonech := make(chan struct{})
+
+		go func() {
+			defer close(storedonech)
+			is.store.run(storectx, is.log)
+		}()
+
+		defer func() {
+			storecancel()
+			<-storedonech
+		}()
+	}
+
 	state := &inventoryServiceState{
 		inventory:    nil,
 		reservations: reservationsArg,
@@ -479,6 +786,10 @@ func (is *inventoryService) run(ctx context.Context, reservationsArg []*reservat
 		return
 	}
 
+	for _, factory := range is.nodeInformers {
+		factory.WaitForCacheSync(ctx.Done())
+	}
+
 	var runch <-chan runner.Result
 	var currinv ctypes.Inventory
 
@@ -515,6 +826,15 @@ func (is *inventoryService) run(ctx context.Context, reservationsArg []*reservat
 		default:
 		}
 	}
+
+	// nil channel never fires when forecasting is disabled
+	var forecastch <-chan time.Time
+	if is.forecaster != nil {
+		forecastTicker := time.NewTicker(is.config.InventoryForecastInterval)
+		defer forecastTicker.Stop()
+
+		forecastch = forecastTicker.C
+	}
 loop:
 	for {
 		select {
@@ -523,7 +843,20 @@ loop:
 			is.lc.ShutdownInitiated(err)
 			break loop
 		case ev := <-is.sub.Events():
-			switch ev := ev.(type) { // nolint: gocritic
+			switch ev := ev.(type) {
+			case mtypes.EventOrderClosed:
+				is.dropRestoredReservations(state, func(bid mtypes.BidID) bool {
+					return bid.OrderID().Equals(ev.ID)
+				})
+			case mtypes.EventBidClosed:
+				is.dropRestoredReservations(state, func(bid mtypes.BidID) bool {
+					return bid.Equals(ev.ID)
+				})
+			case mtypes.EventLeaseCreated:
+				// lease went to another provider
+				is.dropRestoredReservations(state, func(bid mtypes.BidID) bool {
+					return bid.OrderID().Equals(ev.ID.OrderID()) && bid.Provider != ev.ID.Provider
+				})
 			case event.ClusterDeployment:
 				// mark reservation allocated if deployment successful
 				for _, res := range state.reservations {
@@ -538,6 +871,9 @@ loop:
 					res.allocated = ev.Status == event.ClusterDeploymentDeployed
 
 					if res.allocated != allocatedPrev {
+						res.restoredBid = nil
+						is.persistReservations(state)
+
 						externalPortCount := reservationCountEndpoints(res)
 						if ev.Status == event.ClusterDeploymentDeployed {
 							is.availableExternalPorts -= externalPortCount
@@ -567,6 +903,18 @@ loop:
 			updateIPs()
 		case req := <-reservech:
 			is.handleRequest(req, state)
+		case req := <-is.dryrunch:
+			is.handleDryRunRequest(req, state)
+		case req := <-is.ownerUsagech:
+			req.ch <- getOwnerUsage(state, req.owner)
+		case ch := <-is.headroomch:
+			ch <- getHeadroomStatus(is.headroom, state.inventory)
+		case ch := <-is.forecastch:
+			ch <- is.forecaster.forecast(time.Now())
+		case <-forecastch:
+			if state.inventory != nil {
+				is.forecaster.sample(time.Now(), state.inventory.Metrics(), currinv.Metrics())
+			}
 		case req := <-is.lookupch:
 			// lookup registration
 			for _, res := range state.reservations {
@@ -576,6 +924,19 @@ loop:
 				if res.Resources().GetName() != req.resources.GetName() {
 					continue
 				}
+
+				// lease of the order has been won, evict preemptible reservations it holds
+				if len(res.Preempted()) != 0 {
+					for _, preempted := range is.evictPreempted(state, res) {
+						bus.Pub(preempted, []string{ptypes.PubSubTopicReservationPreempted})
+					}
+
+					is.persistReservations(state)
+				}
+
+				// manifest is about to be deployed, decide how its services roll out with the capacity left now
+				setRollout(res, is.rolloutFor(state.inventory, res))
+
 				req.ch <- inventoryResponse{value: res}
 				inventoryRequestsCounter.WithLabelValues("lookup", "found").Inc()
 				continue loop
@@ -597,6 +958,7 @@ loop:
 				is.log.Info("removing reservation", "order", res.OrderID())
 
 				state.reservations = append(state.reservations[:idx], state.reservations[idx+1:]...)
+				is.persistReservations(state)
 				// reclaim availableExternalPorts if unreserving allocated resources
 				if res.allocated {
 					is.availableExternalPorts += reservationCountEndpoints(res)
@@ -651,6 +1013,8 @@ loop:
 			metrics := state.inventory.Metrics()
 
 			is.updateInventoryMetrics(metrics)
+			is.backendMetrics.Store(inventoryBackendMetrics(state.inventory))
+			updateHeadroomMetrics(getHeadroomStatus(is.headroom, state.inventory))
 
 			data, err := json.Marshal(&metrics)
 			if err == nil {
@@ -662,9 +1026,19 @@ loop:
 			// readjust inventory accordingly with pending leases
 			for _, r := range state.reservations {
 				if !r.allocated {
-					if err := state.inventory.Adjust(r); err != nil {
+					// keep capacity of preemptible reservations held until lease is won
+					held := r.Preempted()
+					r.SetPreempted(nil)
+
+					opts := append(is.adjustOptions(r.pool), ctypes.WithPreemption(heldPreemptions(state, held)...))
+
+					if err := state.inventory.Adjust(r, opts...); err != nil {
+						r.SetPreempted(held)
 						is.log.Error("adjust inventory for pending reservation", "error", err.Error())
+						continue
 					}
+
+					is.finalizeReservation(r, is.commitPool(r.pool))
 				}
 			}
 
@@ -843,6 +1217,42 @@ func (is *inventoryService) getStatusV1(state *inventoryServiceState) (*provider
 	return status, nil
 }
 
+// getOwnerUsage sums resources of all reservations, deployed or pending, held by the owner
+func getOwnerUsage(state *inventoryServiceState, owner string) ctypes.OwnerUsage {
+	usage := ctypes.OwnerUsage{}
+
+	for _, reservation := range state.reservations {
+		if reservation.OrderID().Owner != owner {
+			continue
+		}
+
+		if reservation.allocated {
+			usage.Leases++
+		} else {
+			usage.Pending++
+		}
+
+		// policies limit resources owners request, not the committed amount
+		for _, resource := range reservation.requestedResources().GetResourceUnits() {
+			count := uint64(resource.Count)
+
+			if resource.CPU != nil {
+				usage.CPU += resource.CPU.Units.Val.Uint64() * count
+			}
+
+			if resource.GPU != nil {
+				usage.GPU += resource.GPU.Units.Val.Uint64() * count
+			}
+
+			if resource.Memory != nil {
+				usage.Memory += resource.Memory.Quantity.Val.Uint64() * count
+			}
+		}
+	}
+
+	return usage
+}
+
 func reservationCountEndpoints(reservation *reservation) uint {
 	var externalPortCount uint
 
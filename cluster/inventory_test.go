@@ -33,6 +33,7 @@ import (
 	aclient "github.com/akash-network/provider/pkg/client/clientset/versioned"
 	afake "github.com/akash-network/provider/pkg/client/clientset/versioned/fake"
 	"github.com/akash-network/provider/tools/fromctx"
+	ptypes "github.com/akash-network/provider/types"
 )
 
 func TestInventory_reservationAllocatable(t *testing.T) {
@@ -87,6 +88,50 @@ func TestInventory_reservationAllocatable(t *testing.T) {
 	}
 }
 
+func TestInventory_OwnerUsage(t *testing.T) {
+	mkres := func(owner string, allocated bool, cpu, gpu, memory uint64, count uint32) *reservation {
+		return &reservation{
+			order:     mtypes.OrderID{Owner: owner},
+			allocated: allocated,
+			resources: &dtypes.GroupSpec{
+				Resources: dtypes.ResourceUnits{
+					{
+						Resources: types.Resources{
+							ID:     1,
+							CPU:    &types.CPU{Units: types.NewResourceValue(cpu)},
+							GPU:    &types.GPU{Units: types.NewResourceValue(gpu)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(memory)},
+						},
+						Count: count,
+					},
+				},
+			},
+		}
+	}
+
+	// pending reservation committed at half of requested cpu
+	pending := mkres("akash1a", false, 250, 0, unit.Gi, 1)
+	pending.requested = mkres("akash1a", false, 500, 0, unit.Gi, 1).resources
+
+	state := &inventoryServiceState{
+		reservations: []*reservation{
+			mkres("akash1a", true, 1000, 1, unit.Gi, 2),
+			pending,
+			mkres("akash1b", true, 4000, 4, 8*unit.Gi, 1),
+		},
+	}
+
+	require.Equal(t, ctypes.OwnerUsage{
+		Leases:  1,
+		Pending: 1,
+		CPU:     2500,
+		GPU:     2,
+		Memory:  3 * unit.Gi,
+	}, getOwnerUsage(state, "akash1a"))
+
+	require.Equal(t, ctypes.OwnerUsage{}, getOwnerUsage(state, "akash1c"))
+}
+
 func TestInventory_ClusterDeploymentNotDeployed(t *testing.T) {
 	config := Config{
 		InventoryResourcePollPeriod:     time.Second,
@@ -119,7 +164,8 @@ func TestInventory_ClusterDeploymentNotDeployed(t *testing.T) {
 		subscriber,
 		clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		deployments)
+		deployments,
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -217,7 +263,8 @@ func TestInventory_ClusterDeploymentDeployed(t *testing.T) {
 		subscriber,
 		clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		deployments)
+		deployments,
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -431,7 +478,8 @@ func TestInventory_ReserveIPNoIPOperator(t *testing.T) {
 		subscriber,
 		scaffold.clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		make([]ctypes.IDeployment, 0))
+		make([]ctypes.IDeployment, 0),
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -486,7 +534,8 @@ func TestInventory_ReserveIPUnavailableWithIPOperator(t *testing.T) {
 		subscriber,
 		scaffold.clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		make([]ctypes.IDeployment, 0))
+		make([]ctypes.IDeployment, 0),
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -561,7 +610,8 @@ func TestInventory_ReserveIPAvailableWithIPOperator(t *testing.T) {
 		subscriber,
 		scaffold.clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		make([]ctypes.IDeployment, 0))
+		make([]ctypes.IDeployment, 0),
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -677,7 +727,8 @@ func TestInventory_OverReservations(t *testing.T) {
 		subscriber,
 		scaffold.clusterClient,
 		waiter.NewNullWaiter(), // Do not need to wait in test
-		make([]ctypes.IDeployment, 0))
+		make([]ctypes.IDeployment, 0),
+		nil)
 	require.NoError(t, err)
 	require.NotNil(t, inv)
 
@@ -719,3 +770,111 @@ func TestInventory_OverReservations(t *testing.T) {
 	// No ports used yet
 	require.Equal(t, uint(1000-countOfRandomPortService), inv.availableExternalPorts) // nolint: gosec
 }
+
+func TestInventory_PreemptRestoredDeployment(t *testing.T) {
+	scaffold := makeInventoryScaffold(t, 10)
+	defer scaffold.bus.Close()
+	lid0 := scaffold.leaseIDs[0]
+	lid1 := scaffold.leaseIDs[1]
+
+	subscriber, err := scaffold.bus.Subscribe()
+	require.NoError(t, err)
+	defer subscriber.Close()
+
+	group := manifest.Group{
+		Name: "nameForGroup",
+		Services: []manifest.Service{
+			{
+				Count: 1,
+				Resources: types.Resources{
+					ID:     1,
+					CPU:    &types.CPU{Units: types.NewResourceValue(4000)},
+					GPU:    &types.GPU{Units: types.NewResourceValue(0)},
+					Memory: &types.Memory{Quantity: types.NewResourceValue(30 * unit.Gi)},
+					Storage: types.Volumes{
+						types.Storage{
+							Name:     "default",
+							Quantity: types.NewResourceValue(10 * unit.Gi),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// preemptible deployment running on the node before restart
+	deployment := &ctypes.Deployment{
+		Lid:    lid0,
+		MGroup: &group,
+		CParams: crd.ClusterSettings{
+			SchedulerParams: []*crd.SchedulerParams{
+				{
+					Preemptible: true,
+					Placement:   &crd.SchedulerPlacement{Replicas: []string{"nodeA"}},
+				},
+			},
+		},
+	}
+
+	config := Config{
+		InventoryResourcePollPeriod:     5 * time.Second,
+		InventoryResourceDebugFrequency: 1,
+		InventoryExternalPortQuantity:   1000,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	nullInv := cinventory.NewNull(ctx, "nodeA")
+	require.True(t, nullInv.Commit(&group))
+
+	bus := tpubsub.New(ctx, 1000)
+	preemptedch := bus.Sub(ptypes.PubSubTopicReservationPreempted)
+
+	ctx = context.WithValue(ctx, fromctx.CtxKeyPubSub, bus)
+	ctx = context.WithValue(ctx, fromctx.CtxKeyAkashClientSet, aclient.Interface(afake.NewSimpleClientset()))
+	ctx = context.WithValue(ctx, cfromctx.CtxKeyClientInventory, nullInv)
+
+	inv, err := newInventoryService(
+		ctx,
+		config,
+		testutil.Logger(t),
+		subscriber,
+		scaffold.clusterClient,
+		waiter.NewNullWaiter(),
+		[]ctypes.IDeployment{deployment},
+		nil)
+	require.NoError(t, err)
+
+	err = scaffold.bus.Publish(event.ClusterDeployment{
+		LeaseID: lid0,
+		Group:   &group,
+		Status:  event.ClusterDeploymentDeployed,
+	})
+	require.NoError(t, err)
+
+	// full price order fits only on capacity of the preemptible deployment, which is held until lease is won
+	reservation, err := inv.reserve(lid1.OrderID(), &group)
+	require.NoError(t, err)
+	require.NotNil(t, reservation)
+
+	select {
+	case <-preemptedch:
+		t.Fatal("preemptible deployment evicted before lease is won")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = inv.lookup(lid1.OrderID(), &group)
+	require.NoError(t, err)
+
+	select {
+	case ev := <-preemptedch:
+		preempted := ev.(event.ReservationPreempted)
+		require.Equal(t, lid0.OrderID(), preempted.OrderID)
+		require.Equal(t, lid1.OrderID(), preempted.PreemptedBy)
+	case <-time.After(5 * time.Second):
+		t.Fatal("preemptible deployment not evicted")
+	}
+
+	cancel()
+	close(scaffold.donech)
+	<-inv.lc.Done()
+}
@@ -3,14 +3,19 @@ package builder
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	manitypes "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
 	"github.com/akash-network/node/testutil"
 
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
 	"github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
 )
 
@@ -282,3 +287,531 @@ func TestLocalServiceBuilderWithLocalServices(t *testing.T) {
 	require.Equal(t, ports[0].TargetPort, intstr.FromInt(2000))
 	require.Equal(t, ports[0].Name, "1-2001")
 }
+
+func TestWorkloadAffinityPlacement(t *testing.T) {
+	myLog := testutil.Logger(t)
+	mySettings := NewDefaultSettings()
+
+	nodes := []string{"node1", "node2"}
+
+	build := func(placement *v2beta2.SchedulerPlacement) *corev1.NodeAffinity {
+		cdep := &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name: "myservice",
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{
+					{
+						Placement: placement,
+					},
+				},
+			},
+		}
+
+		workload := NewWorkloadBuilder(myLog, mySettings, cdep, 0)
+
+		return workload.affinity().NodeAffinity
+	}
+
+	pin := corev1.NodeSelectorRequirement{
+		Key:      nodeNameField,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   nodes,
+	}
+
+	affinity := build(nil)
+	require.Empty(t, affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+	require.Empty(t, affinity.PreferredDuringSchedulingIgnoredDuringExecution)
+
+	affinity = build(&v2beta2.SchedulerPlacement{Nodes: nodes, Required: true})
+	require.Equal(t, []corev1.NodeSelectorRequirement{pin}, affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+	require.Empty(t, affinity.PreferredDuringSchedulingIgnoredDuringExecution)
+
+	affinity = build(&v2beta2.SchedulerPlacement{Nodes: nodes})
+	require.Empty(t, affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+	require.Len(t, affinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	require.Equal(t, []corev1.NodeSelectorRequirement{pin}, affinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Preference.MatchFields)
+
+	// akash managed nodes are still required
+	require.Equal(t, AkashManagedLabelName, affinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key)
+}
+
+func TestWorkloadCommitPool(t *testing.T) {
+	myLog := testutil.Logger(t)
+	mySettings := NewDefaultSettings()
+	mySettings.CPUCommitLevel = 2
+	mySettings.MemoryCommitLevel = 2
+
+	build := func(commit *v2beta2.SchedulerCommit) Workload {
+		cdep := &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name: "myservice",
+						Resources: types.Resources{
+							CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						},
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{
+					{
+						Commit: commit,
+					},
+				},
+			},
+		}
+
+		return NewWorkloadBuilder(myLog, mySettings, cdep, 0)
+	}
+
+	// global commit levels apply without pool
+	workload := build(nil)
+	container := workload.container()
+	require.Equal(t, int64(500), container.Resources.Requests.Cpu().MilliValue())
+	require.Equal(t, int64(512), container.Resources.Requests.Memory().Value())
+	require.Len(t, workload.affinity().NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+
+	workload = build(&v2beta2.SchedulerCommit{
+		Pool: "bare-metal",
+		MatchLabels: map[string]string{
+			"pool": "dedicated",
+			"arch": "amd64",
+		},
+		CPU:    1,
+		Memory: 1,
+	})
+
+	container = workload.container()
+	require.Equal(t, int64(1000), container.Resources.Requests.Cpu().MilliValue())
+	require.Equal(t, int64(1024), container.Resources.Requests.Memory().Value())
+
+	require.Equal(t, []corev1.NodeSelectorRequirement{
+		{
+			Key:      AkashManagedLabelName,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"true"},
+		},
+		{
+			Key:      "arch",
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"amd64"},
+		},
+		{
+			Key:      "pool",
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"dedicated"},
+		},
+	}, workload.affinity().NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions)
+
+	// default pool stays off nodes carrying all labels of any pool
+	workload = build(&v2beta2.SchedulerCommit{
+		CPU:    2,
+		Memory: 2,
+		ExcludeLabels: []map[string]string{
+			{"pool": "dedicated", "arch": "amd64"},
+			{"pool": "burstable"},
+		},
+	})
+
+	managed := corev1.NodeSelectorRequirement{
+		Key:      AkashManagedLabelName,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"true"},
+	}
+	burstable := corev1.NodeSelectorRequirement{
+		Key:      "pool",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"burstable"},
+	}
+
+	terms := workload.affinity().NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 2)
+	require.Equal(t, []corev1.NodeSelectorRequirement{
+		managed,
+		{Key: "arch", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"amd64"}},
+		burstable,
+	}, terms[0].MatchExpressions)
+	require.Equal(t, []corev1.NodeSelectorRequirement{
+		managed,
+		{Key: "pool", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"dedicated"}},
+		burstable,
+	}, terms[1].MatchExpressions)
+}
+
+func TestWorkloadStorageClassCommitLevel(t *testing.T) {
+	myLog := testutil.Logger(t)
+	mySettings := NewDefaultSettings()
+
+	cdep := &ClusterDeployment{
+		Lid: testutil.LeaseID(t),
+		Group: &manitypes.Group{
+			Services: manitypes.Services{
+				manitypes.Service{
+					Name: "myservice",
+					Resources: types.Resources{
+						CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+						Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						Storage: types.Volumes{
+							{
+								Name:     "default",
+								Quantity: types.NewResourceValue(4096),
+							},
+							{
+								Name:     "shm",
+								Quantity: types.NewResourceValue(2048),
+								Attributes: types.Attributes{
+									{Key: sdl.StorageAttributeClass, Value: sdl.StorageClassRAM},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Sparams: v2beta2.ClusterSettings{
+			SchedulerParams: []*v2beta2.SchedulerParams{
+				{
+					Commit: &v2beta2.SchedulerCommit{
+						CPU:            1,
+						Memory:         1,
+						Storage:        2,
+						StorageClasses: map[string]float64{sdl.StorageClassRAM: 4},
+					},
+				},
+			},
+		},
+	}
+
+	workload := NewWorkloadBuilder(myLog, mySettings, cdep, 0)
+	container := workload.container()
+	require.Equal(t, int64(2048), container.Resources.Requests.StorageEphemeral().Value())
+	require.Equal(t, int64(1024+512), container.Resources.Requests.Memory().Value())
+	require.Equal(t, int64(1024+2048), container.Resources.Limits.Memory().Value())
+}
+
+func TestWorkloadRAMVolumeWithoutCommitPool(t *testing.T) {
+	myLog := testutil.Logger(t)
+	mySettings := NewDefaultSettings()
+
+	cdep := &ClusterDeployment{
+		Lid: testutil.LeaseID(t),
+		Group: &manitypes.Group{
+			Services: manitypes.Services{
+				manitypes.Service{
+					Name: "myservice",
+					Resources: types.Resources{
+						CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+						Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						Storage: types.Volumes{
+							{
+								Name:     "shm",
+								Quantity: types.NewResourceValue(2048),
+								Attributes: types.Attributes{
+									{Key: sdl.StorageAttributeClass, Value: sdl.StorageClassRAM},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Sparams: v2beta2.ClusterSettings{
+			SchedulerParams: []*v2beta2.SchedulerParams{nil},
+		},
+	}
+
+	workload := NewWorkloadBuilder(myLog, mySettings, cdep, 0)
+	container := workload.container()
+	require.Equal(t, int64(1024), container.Resources.Requests.Memory().Value())
+	require.Equal(t, int64(1024+2048), container.Resources.Limits.Memory().Value())
+}
+
+func TestWorkloadPreemptiblePriorityClass(t *testing.T) {
+	myLog := testutil.Logger(t)
+	mySettings := NewDefaultSettings()
+	mySettings.PreemptiblePriorityClass = "akash-preemptible"
+
+	build := func(preemptible bool) Deployment {
+		cdep := &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name: "myservice",
+						Resources: types.Resources{
+							CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						},
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{
+					{
+						Preemptible: preemptible,
+					},
+				},
+			},
+		}
+
+		return NewDeployment(NewWorkloadBuilder(myLog, mySettings, cdep, 0))
+	}
+
+	dpl, err := build(false).Create()
+	require.NoError(t, err)
+	require.Empty(t, dpl.Spec.Template.Spec.PriorityClassName)
+
+	dpl, err = build(true).Create()
+	require.NoError(t, err)
+	require.Equal(t, "akash-preemptible", dpl.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestWorkloadHealthProbes(t *testing.T) {
+	myLog := testutil.Logger(t)
+
+	build := func(settings Settings, env []string, expose ...manitypes.ServiceExpose) corev1.Container {
+		cdep := &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name: "myservice",
+						Env:  env,
+						Resources: types.Resources{
+							CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						},
+						Expose: expose,
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{nil},
+			},
+		}
+
+		workload := NewWorkloadBuilder(myLog, settings, cdep, 0)
+
+		return workload.container()
+	}
+
+	http := manitypes.ServiceExpose{Port: 8080, ExternalPort: 80, Proto: manitypes.TCP, Global: true}
+	tcp := manitypes.ServiceExpose{Port: 5432, ExternalPort: 5432, Proto: manitypes.TCP, Global: true}
+	udp := manitypes.ServiceExpose{Port: 53, ExternalPort: 53, Proto: manitypes.UDP, Global: true}
+
+	settings := NewDefaultSettings()
+
+	container := build(settings, nil, http)
+	require.Nil(t, container.ReadinessProbe)
+
+	settings.HealthProbes.Enabled = true
+	require.NoError(t, ValidateSettings(settings))
+
+	container = build(settings, nil, http)
+	require.NotNil(t, container.ReadinessProbe)
+	require.NotNil(t, container.ReadinessProbe.TCPSocket)
+	require.Equal(t, int32(8080), container.ReadinessProbe.TCPSocket.Port.IntVal)
+	require.Equal(t, int32(10), container.LivenessProbe.PeriodSeconds)
+	require.Equal(t, int32(3), container.LivenessProbe.FailureThreshold)
+	require.Equal(t, int32(30), container.StartupProbe.FailureThreshold)
+
+	settings.HealthProbes.HTTPPath = "/healthz"
+
+	container = build(settings, nil, http)
+	require.NotNil(t, container.ReadinessProbe.HTTPGet)
+	require.Equal(t, "/healthz", container.ReadinessProbe.HTTPGet.Path)
+
+	container = build(settings, nil, udp, tcp)
+	require.NotNil(t, container.LivenessProbe.TCPSocket)
+	require.Equal(t, int32(5432), container.LivenessProbe.TCPSocket.Port.IntVal)
+
+	container = build(settings, nil, udp)
+	require.Nil(t, container.ReadinessProbe)
+
+	container = build(settings, []string{"AKASH_HEALTH_PROBES=false"}, http)
+	require.Nil(t, container.ReadinessProbe)
+	require.Nil(t, container.LivenessProbe)
+	require.Nil(t, container.StartupProbe)
+
+	settings.HealthProbes.HTTPPath = "healthz"
+	require.ErrorIs(t, ValidateSettings(settings), ErrSettingsValidation)
+}
+
+func TestServiceCredentialsSealed(t *testing.T) {
+	myLog := testutil.Logger(t)
+
+	key, err := v2beta2.ParseCredentialsKey("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+
+	sealer, err := v2beta2.NewCredentialsSealer(key)
+	require.NoError(t, err)
+
+	cdep := &ClusterDeployment{
+		Lid: testutil.LeaseID(t),
+		Group: &manitypes.Group{
+			Services: manitypes.Services{
+				manitypes.Service{
+					Name: "myservice",
+					Credentials: &manitypes.ServiceImageCredentials{
+						Host:     "ghcr.io",
+						Username: "foo",
+						Password: "bar",
+					},
+				},
+			},
+		},
+		Sparams: v2beta2.ClusterSettings{
+			SchedulerParams: []*v2beta2.SchedulerParams{nil},
+		},
+	}
+
+	settings := NewDefaultSettings()
+	settings.CredentialsSealer = sealer
+
+	m, err := BuildManifest(myLog, settings, testKubeClientNs, cdep).Create()
+	require.NoError(t, err)
+
+	creds := m.Spec.Group.Services[0].Credentials
+	require.True(t, creds.IsSealed())
+	require.Empty(t, creds.Password)
+
+	workload := NewWorkloadBuilder(myLog, settings, cdep, 0)
+
+	secret, err := NewServiceCredentials(workload, creds).Create()
+	require.NoError(t, err)
+	require.Equal(t, "docker-creds-myservice", secret.Name)
+	require.Contains(t, string(secret.Data[corev1.DockerConfigJsonKey]), encodeAuth("foo", "bar"))
+
+	_, err = NewServiceCredentials(NewWorkloadBuilder(myLog, NewDefaultSettings(), cdep, 0), creds).Create()
+	require.ErrorIs(t, err, ErrKubeBuilder)
+}
+
+func TestWorkloadTermination(t *testing.T) {
+	myLog := testutil.Logger(t)
+
+	build := func(settings Settings, env ...string) *appsv1.Deployment {
+		cdep := &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name: "myservice",
+						Env:  env,
+						Resources: types.Resources{
+							CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						},
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{nil},
+			},
+		}
+
+		dpl, err := NewDeployment(NewWorkloadBuilder(myLog, settings, cdep, 0)).Create()
+		require.NoError(t, err)
+
+		return dpl
+	}
+
+	settings := NewDefaultSettings()
+	require.NoError(t, ValidateSettings(settings))
+
+	dpl := build(settings)
+	require.Equal(t, int64(30), *dpl.Spec.Template.Spec.TerminationGracePeriodSeconds)
+	require.Nil(t, dpl.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	dpl = build(settings, "AKASH_TERMINATION_GRACE_PERIOD=90s", "AKASH_PRE_STOP_EXEC=nginx -s quit")
+	require.Equal(t, int64(90), *dpl.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	lifecycle := dpl.Spec.Template.Spec.Containers[0].Lifecycle
+	require.NotNil(t, lifecycle)
+	require.Equal(t, []string{"/bin/sh", "-c", "nginx -s quit"}, lifecycle.PreStop.Exec.Command)
+
+	dpl = build(settings, "AKASH_TERMINATION_GRACE_PERIOD=1h")
+	require.Equal(t, int64(300), *dpl.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	dpl = build(settings, "AKASH_TERMINATION_GRACE_PERIOD=soon")
+	require.Equal(t, int64(30), *dpl.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	settings.Termination.TeardownTimeout = time.Minute
+	require.ErrorIs(t, ValidateSettings(settings), ErrSettingsValidation)
+
+	settings.Termination.TeardownTimeout = 0
+	require.NoError(t, ValidateSettings(settings))
+
+	settings.Termination.MaxGracePeriod = 10 * time.Second
+	require.ErrorIs(t, ValidateSettings(settings), ErrSettingsValidation)
+}
+
+func TestWorkloadRollout(t *testing.T) {
+	myLog := testutil.Logger(t)
+
+	cdep := func(strategy ctypes.RolloutStrategy) *ClusterDeployment {
+		return &ClusterDeployment{
+			Lid: testutil.LeaseID(t),
+			Group: &manitypes.Group{
+				Services: manitypes.Services{
+					manitypes.Service{
+						Name:  "myservice",
+						Count: 1,
+						Resources: types.Resources{
+							CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+							Memory: &types.Memory{Quantity: types.NewResourceValue(1024)},
+						},
+					},
+				},
+			},
+			Sparams: v2beta2.ClusterSettings{
+				SchedulerParams: []*v2beta2.SchedulerParams{{Rollout: strategy}},
+			},
+		}
+	}
+
+	deployment := func(strategy ctypes.RolloutStrategy) *appsv1.Deployment {
+		dpl, err := NewDeployment(NewWorkloadBuilder(myLog, NewDefaultSettings(), cdep(strategy), 0)).Create()
+		require.NoError(t, err)
+
+		return dpl
+	}
+
+	dpl := deployment("")
+	require.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, dpl.Spec.Strategy.Type)
+	require.Equal(t, intstr.FromInt32(0), *dpl.Spec.Strategy.RollingUpdate.MaxSurge)
+	require.Equal(t, intstr.FromInt32(1), *dpl.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	require.Equal(t, "rolling", dpl.Annotations[AkashRolloutStrategy])
+
+	dpl = deployment(ctypes.RolloutRecreate)
+	require.Equal(t, appsv1.RecreateDeploymentStrategyType, dpl.Spec.Strategy.Type)
+	require.Nil(t, dpl.Spec.Strategy.RollingUpdate)
+
+	dpl = deployment(ctypes.RolloutSurge)
+	require.Equal(t, intstr.FromInt32(1), *dpl.Spec.Strategy.RollingUpdate.MaxSurge)
+	require.Equal(t, intstr.FromInt32(0), *dpl.Spec.Strategy.RollingUpdate.MaxUnavailable)
+
+	dpl = deployment(ctypes.RolloutBlueGreen)
+	require.Equal(t, intstr.FromString("100%"), *dpl.Spec.Strategy.RollingUpdate.MaxSurge)
+	require.Equal(t, "bluegreen", dpl.Annotations[AkashRolloutStrategy])
+
+	// existing deployment switches strategy on update
+	dpl, err := NewDeployment(NewWorkloadBuilder(myLog, NewDefaultSettings(), cdep(ctypes.RolloutRecreate), 0)).Update(dpl)
+	require.NoError(t, err)
+	require.Equal(t, appsv1.RecreateDeploymentStrategyType, dpl.Spec.Strategy.Type)
+	require.Equal(t, "recreate", dpl.Annotations[AkashRolloutStrategy])
+
+	sset, err := BuildStatefulSet(NewWorkloadBuilder(myLog, NewDefaultSettings(), cdep(ctypes.RolloutBlueGreen), 0)).Create()
+	require.NoError(t, err)
+	require.Equal(t, int32(0), *sset.Spec.UpdateStrategy.RollingUpdate.Partition)
+	require.Equal(t, intstr.FromInt32(1), *sset.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable)
+	require.Equal(t, "rolling", sset.Annotations[AkashRolloutStrategy])
+}
@@ -4,7 +4,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type Deployment interface {
@@ -34,25 +33,17 @@ func (b *deployment) Create() (*appsv1.Deployment, error) { // nolint:golint,unp
 
 	revisionHistoryLimit := int32(10)
 
-	maxSurge := intstr.FromInt32(0)
-	maxUnavailable := intstr.FromInt32(1)
-
 	kdeployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   b.Name(),
-			Labels: b.labels(),
+			Name:        b.Name(),
+			Labels:      b.labels(),
+			Annotations: rolloutAnnotations(nil, b.rollout()),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Selector: &metav1.LabelSelector{
 				MatchLabels: b.selectorLabels(),
 			},
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &maxUnavailable,
-					MaxSurge:       &maxSurge,
-				},
-			},
+			Strategy:             deploymentStrategy(b.rollout()),
 			RevisionHistoryLimit: &revisionHistoryLimit,
 			Replicas:             b.replicas(),
 			Template: corev1.PodTemplateSpec{
@@ -60,15 +51,17 @@ func (b *deployment) Create() (*appsv1.Deployment, error) { // nolint:golint,unp
 					Labels: b.labels(),
 				},
 				Spec: corev1.PodSpec{
-					Affinity:         b.affinity(),
-					RuntimeClassName: b.runtimeClass(),
+					Affinity:          b.affinity(),
+					RuntimeClassName:  b.runtimeClass(),
+					PriorityClassName: b.priorityClass(),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: &falseValue,
 					},
-					AutomountServiceAccountToken: &falseValue,
-					Containers:                   []corev1.Container{b.container()},
-					ImagePullSecrets:             b.secretsRefs,
-					Volumes:                      b.volumesObjs,
+					AutomountServiceAccountToken:  &falseValue,
+					TerminationGracePeriodSeconds: b.terminationGracePeriod(),
+					Containers:                    []corev1.Container{b.container()},
+					ImagePullSecrets:              b.secretsRefs,
+					Volumes:                       b.volumesObjs,
 				},
 			},
 		},
@@ -79,11 +72,15 @@ func (b *deployment) Create() (*appsv1.Deployment, error) { // nolint:golint,unp
 
 func (b *deployment) Update(obj *appsv1.Deployment) (*appsv1.Deployment, error) { // nolint:golint,unparam
 	obj.Labels = updateAkashLabels(obj.Labels, b.labels())
+	obj.Annotations = rolloutAnnotations(obj.Annotations, b.rollout())
 	obj.Spec.Selector.MatchLabels = b.selectorLabels()
+	obj.Spec.Strategy = deploymentStrategy(b.rollout())
 	obj.Spec.Replicas = b.replicas()
 	obj.Spec.Template.Labels = b.labels()
 	obj.Spec.Template.Spec.Affinity = b.affinity()
 	obj.Spec.Template.Spec.RuntimeClassName = b.runtimeClass()
+	obj.Spec.Template.Spec.PriorityClassName = b.priorityClass()
+	obj.Spec.Template.Spec.TerminationGracePeriodSeconds = b.terminationGracePeriod()
 	obj.Spec.Template.Spec.Containers = []corev1.Container{b.container()}
 	obj.Spec.Template.Spec.ImagePullSecrets = b.imagePullSecrets()
 
@@ -43,6 +43,11 @@ func (b *manifest) Create() (*crd.Manifest, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if err = b.sealCredentials(obj, nil); err != nil {
+		return nil, err
+	}
+
 	obj.Labels = b.labels()
 	return obj, nil
 }
@@ -52,11 +57,27 @@ func (b *manifest) Update(obj *crd.Manifest) (*crd.Manifest, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if err = b.sealCredentials(m, obj); err != nil {
+		return nil, err
+	}
+
 	obj.Spec = m.Spec
 	obj.Labels = b.labels()
 	return obj, nil
 }
 
+// sealCredentials seals registry credentials of the manifest when provider holds credentials key
+func (b *manifest) sealCredentials(obj *crd.Manifest, prev *crd.Manifest) error {
+	if b.settings.CredentialsSealer == nil {
+		return nil
+	}
+
+	_, err := b.settings.CredentialsSealer.SealManifest(obj, prev)
+
+	return err
+}
+
 func (b *manifest) NS() string {
 	return b.mns
 }
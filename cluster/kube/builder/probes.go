@@ -0,0 +1,121 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mani "github.com/akash-network/akash-api/go/manifest/v2beta2"
+)
+
+// envVarAkashHealthProbes set to false in service environment opts the service out of health probes
+const envVarAkashHealthProbes = "AKASH_HEALTH_PROBES"
+
+// ProbeSettings configures health probes derived from ports services expose
+type ProbeSettings struct {
+	// Enabled derives probes for every service exposing TCP port
+	Enabled bool
+	// HTTPPath requested on ports exposed through ingress, connection to the port is probed when empty
+	HTTPPath string
+	// Period of every probe
+	Period time.Duration
+	// Timeout of single probe
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failures pod is marked unready and restarted after
+	FailureThreshold int32
+	// StartupTimeout is how long containers have to start before they are restarted
+	StartupTimeout time.Duration
+}
+
+func (s ProbeSettings) validate() error {
+	if !s.Enabled {
+		return nil
+	}
+
+	if s.HTTPPath != "" && !strings.HasPrefix(s.HTTPPath, "/") {
+		return fmt.Errorf("%w: health probe path %q must be absolute", ErrSettingsValidation, s.HTTPPath)
+	}
+
+	if s.Period < time.Second || s.Timeout < time.Second {
+		return fmt.Errorf("%w: health probe period and timeout must be at least 1s", ErrSettingsValidation)
+	}
+
+	if s.FailureThreshold < 1 {
+		return fmt.Errorf("%w: health probe failure threshold must be positive", ErrSettingsValidation)
+	}
+
+	if s.StartupTimeout < s.Period {
+		return fmt.Errorf("%w: health probe startup timeout must be at least one period", ErrSettingsValidation)
+	}
+
+	return nil
+}
+
+// probes returns readiness, liveness and startup probes of the service container, all nil when
+// probes are disabled, service opted out of them or exposes no TCP port
+func (b *Workload) probes(service *mani.Service) (*corev1.Probe, *corev1.Probe, *corev1.Probe) {
+	settings := b.settings.HealthProbes
+	if !settings.Enabled || probesOptedOut(service.Env) {
+		return nil, nil, nil
+	}
+
+	var handler *corev1.ProbeHandler
+
+	for _, expose := range service.Expose {
+		if expose.Proto != mani.TCP {
+			continue
+		}
+
+		port := intstr.FromInt32(int32(expose.Port)) // nolint: gosec
+
+		if expose.IsIngress() && settings.HTTPPath != "" {
+			handler = &corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: settings.HTTPPath, Port: port},
+			}
+		} else {
+			handler = &corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: port},
+			}
+		}
+
+		break
+	}
+
+	if handler == nil {
+		return nil, nil, nil
+	}
+
+	probe := func(failures int32) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler:     *handler.DeepCopy(),
+			PeriodSeconds:    int32(settings.Period / time.Second),  // nolint: gosec
+			TimeoutSeconds:   int32(settings.Timeout / time.Second), // nolint: gosec
+			SuccessThreshold: 1,
+			FailureThreshold: failures,
+		}
+	}
+
+	startupFailures := int32((settings.StartupTimeout + settings.Period - 1) / settings.Period) // nolint: gosec
+
+	return probe(settings.FailureThreshold), probe(settings.FailureThreshold), probe(startupFailures)
+}
+
+// probesOptedOut checks whether service environment disables health probes
+func probesOptedOut(env []string) bool {
+	for _, val := range env {
+		name, value, _ := strings.Cut(val, "=")
+		if name != envVarAkashHealthProbes {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+
+		return err == nil && !enabled
+	}
+
+	return false
+}
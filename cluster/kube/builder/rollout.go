@@ -0,0 +1,89 @@
+package builder
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+// AkashRolloutStrategy annotates workloads with the strategy they are updated with
+const AkashRolloutStrategy = "akash.network/rollout.strategy"
+
+// rollout returns rollout strategy provider decided for the service, rolling update when none
+func (b *Workload) rollout() ctypes.RolloutStrategy {
+	params := b.deployment.ClusterParams().SchedulerParams[b.serviceIdx]
+	if params == nil || params.Rollout == "" {
+		return ctypes.RolloutRolling
+	}
+
+	return params.Rollout
+}
+
+// deploymentStrategy returns update strategy of deployment rolling out with the strategy
+func deploymentStrategy(strategy ctypes.RolloutStrategy) appsv1.DeploymentStrategy {
+	maxSurge := intstr.FromInt32(0)
+	maxUnavailable := intstr.FromInt32(1)
+
+	switch strategy {
+	case ctypes.RolloutRecreate:
+		return appsv1.DeploymentStrategy{
+			Type: appsv1.RecreateDeploymentStrategyType,
+		}
+	case ctypes.RolloutSurge:
+		maxSurge = intstr.FromInt32(1)
+		maxUnavailable = intstr.FromInt32(0)
+	case ctypes.RolloutBlueGreen:
+		maxSurge = intstr.FromString("100%")
+		maxUnavailable = intstr.FromInt32(0)
+	}
+
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	}
+}
+
+// statefulSetRollout returns strategy stateful set rolls out with. Volumes of stateful replicas
+// can not be shared with surge ones, so surge strategies fall back to rolling update
+func statefulSetRollout(strategy ctypes.RolloutStrategy) ctypes.RolloutStrategy {
+	if strategy == ctypes.RolloutRecreate {
+		return strategy
+	}
+
+	return ctypes.RolloutRolling
+}
+
+// statefulSetStrategy returns update strategy of stateful set rolling out with the strategy.
+// Partition is reset so the whole set rolls out, recreate replaces all replicas at once
+// where cluster supports max unavailable of stateful sets
+func statefulSetStrategy(strategy ctypes.RolloutStrategy) appsv1.StatefulSetUpdateStrategy {
+	partition := int32(0)
+	maxUnavailable := intstr.FromInt32(1)
+
+	if statefulSetRollout(strategy) == ctypes.RolloutRecreate {
+		maxUnavailable = intstr.FromString("100%")
+	}
+
+	return appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition:      &partition,
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
+
+// rolloutAnnotations records rollout strategy in workload annotations, lease status reports it
+func rolloutAnnotations(annotations map[string]string, strategy ctypes.RolloutStrategy) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	annotations[AkashRolloutStrategy] = string(strategy)
+
+	return annotations
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"strings"
 
-	mani "github.com/akash-network/akash-api/go/manifest/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
 )
 
+var errCredentialsSealerMissing = fmt.Errorf("%w: credentials are sealed and no credentials key is configured", ErrKubeBuilder)
+
 type ServiceCredentials interface {
 	workloadBase
 	Create() (*corev1.Secret, error)
@@ -21,10 +24,12 @@ type serviceCredentials struct {
 	Workload
 	// ns          string
 	// serviceName string
-	credentials *mani.ServiceImageCredentials
+	credentials *crd.ManifestServiceCredentials
 }
 
-func NewServiceCredentials(workload Workload, credentials *mani.ServiceImageCredentials) ServiceCredentials {
+// NewServiceCredentials builds registry secret of the service from credentials as stored in the manifest,
+// sealed credentials are unsealed only when the secret is built
+func NewServiceCredentials(workload Workload, credentials *crd.ManifestServiceCredentials) ServiceCredentials {
 	return &serviceCredentials{
 		Workload:    workload,
 		credentials: credentials,
@@ -33,7 +38,11 @@ func NewServiceCredentials(workload Workload, credentials *mani.ServiceImageCred
 
 func (b serviceCredentials) Name() string {
 	svc := &b.deployment.ManifestGroup().Services[b.serviceIdx]
-	return fmt.Sprintf("docker-creds-%v", svc.Name)
+	return serviceCredentialsName(svc.Name)
+}
+
+func serviceCredentialsName(service string) string {
+	return fmt.Sprintf("docker-creds-%v", service)
 }
 
 func (b serviceCredentials) Create() (*corev1.Secret, error) {
@@ -87,15 +96,28 @@ type dockerCredentials struct {
 }
 
 func (b serviceCredentials) encodeSecret() ([]byte, error) {
+	credentials := *b.credentials
+
+	if credentials.IsSealed() {
+		if b.settings.CredentialsSealer == nil {
+			return nil, errCredentialsSealerMissing
+		}
+
+		var err error
+		if credentials, err = b.settings.CredentialsSealer.Unseal(credentials); err != nil {
+			return nil, err
+		}
+	}
+
 	entry := dockerCredentialsEntry{
-		Username: strings.TrimSpace(b.credentials.Username),
-		Password: strings.TrimSpace(b.credentials.Password),
-		Email:    strings.TrimSpace(b.credentials.Email),
-		Auth:     encodeAuth(strings.TrimSpace(b.credentials.Username), strings.TrimSpace(b.credentials.Password)),
+		Username: strings.TrimSpace(credentials.Username),
+		Password: strings.TrimSpace(credentials.Password),
+		Email:    strings.TrimSpace(credentials.Email),
+		Auth:     encodeAuth(strings.TrimSpace(credentials.Username), strings.TrimSpace(credentials.Password)),
 	}
 	creds := dockerCredentials{
 		Auths: map[string]dockerCredentialsEntry{
-			b.credentials.Host: entry,
+			credentials.Host: entry,
 		},
 	}
 
@@ -2,11 +2,14 @@ package builder
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	vutil "github.com/akash-network/node/util/validation"
+
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
 )
 
 // Settings configures k8s object generation such that it is customized to the
@@ -41,8 +44,20 @@ type Settings struct {
 
 	DeploymentRuntimeClass string
 
+	// PriorityClass of preemptible workloads, pods keep default priority when empty
+	PreemptiblePriorityClass string
+
 	// Name of the image pull secret to use in pod spec
 	DockerImagePullSecretsName string
+
+	// HealthProbes of tenant containers
+	HealthProbes ProbeSettings
+
+	// Termination of tenant workloads
+	Termination TerminationSettings
+
+	// CredentialsSealer seals registry credentials stored in manifests, those are stored in plain when nil
+	CredentialsSealer *crd.CredentialsSealer
 }
 
 var ErrSettingsValidation = errors.New("settings validation")
@@ -58,7 +73,11 @@ func ValidateSettings(settings Settings) error {
 		}
 	}
 
-	return nil
+	if err := settings.HealthProbes.validate(); err != nil {
+		return err
+	}
+
+	return settings.Termination.validate()
 }
 
 func NewDefaultSettings() Settings {
@@ -67,6 +86,17 @@ func NewDefaultSettings() Settings {
 		DeploymentIngressStaticHosts:   false,
 		DeploymentIngressExposeLBHosts: false,
 		NetworkPoliciesEnabled:         false,
+		HealthProbes: ProbeSettings{
+			Period:           10 * time.Second,
+			Timeout:          5 * time.Second,
+			FailureThreshold: 3,
+			StartupTimeout:   5 * time.Minute,
+		},
+		Termination: TerminationSettings{
+			GracePeriod:     30 * time.Second,
+			MaxGracePeriod:  5 * time.Minute,
+			TeardownTimeout: 5 * time.Minute,
+		},
 	}
 }
 
@@ -4,7 +4,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type StatefulSet interface {
@@ -34,22 +33,14 @@ func (b *statefulSet) Create() (*appsv1.StatefulSet, error) { // nolint:golint,u
 
 	revisionHistoryLimit := int32(1)
 
-	partition := int32(0)
-	maxUnavailable := intstr.FromInt32(1)
-
 	kdeployment := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   b.Name(),
-			Labels: b.labels(),
+			Name:        b.Name(),
+			Labels:      b.labels(),
+			Annotations: rolloutAnnotations(nil, statefulSetRollout(b.rollout())),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
-					Partition:      &partition,
-					MaxUnavailable: &maxUnavailable,
-				},
-			},
+			UpdateStrategy: statefulSetStrategy(b.rollout()),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: b.selectorLabels(),
 			},
@@ -60,15 +51,17 @@ func (b *statefulSet) Create() (*appsv1.StatefulSet, error) { // nolint:golint,u
 					Labels: b.labels(),
 				},
 				Spec: corev1.PodSpec{
-					Affinity:         b.affinity(),
-					RuntimeClassName: b.runtimeClass(),
+					Affinity:          b.affinity(),
+					RuntimeClassName:  b.runtimeClass(),
+					PriorityClassName: b.priorityClass(),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: &falseValue,
 					},
-					AutomountServiceAccountToken: &falseValue,
-					Containers:                   []corev1.Container{b.container()},
-					ImagePullSecrets:             b.secretsRefs,
-					Volumes:                      b.volumesObjs,
+					AutomountServiceAccountToken:  &falseValue,
+					TerminationGracePeriodSeconds: b.terminationGracePeriod(),
+					Containers:                    []corev1.Container{b.container()},
+					ImagePullSecrets:              b.secretsRefs,
+					Volumes:                       b.volumesObjs,
 				},
 			},
 			VolumeClaimTemplates: b.pvcsObjs,
@@ -80,11 +73,15 @@ func (b *statefulSet) Create() (*appsv1.StatefulSet, error) { // nolint:golint,u
 
 func (b *statefulSet) Update(obj *appsv1.StatefulSet) (*appsv1.StatefulSet, error) { // nolint:golint,unparam
 	obj.Labels = updateAkashLabels(obj.Labels, b.labels())
+	obj.Annotations = rolloutAnnotations(obj.Annotations, statefulSetRollout(b.rollout()))
+	obj.Spec.UpdateStrategy = statefulSetStrategy(b.rollout())
 	obj.Spec.Replicas = b.replicas()
 	obj.Spec.Selector.MatchLabels = b.selectorLabels()
 	obj.Spec.Template.Labels = b.labels()
 	obj.Spec.Template.Spec.Affinity = b.affinity()
 	obj.Spec.Template.Spec.RuntimeClassName = b.runtimeClass()
+	obj.Spec.Template.Spec.PriorityClassName = b.priorityClass()
+	obj.Spec.Template.Spec.TerminationGracePeriodSeconds = b.terminationGracePeriod()
 	obj.Spec.Template.Spec.Containers = []corev1.Container{b.container()}
 	obj.Spec.Template.Spec.ImagePullSecrets = b.imagePullSecrets()
 	obj.Spec.VolumeClaimTemplates = b.persistentVolumeClaims()
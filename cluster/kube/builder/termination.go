@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	mani "github.com/akash-network/akash-api/go/manifest/v2beta2"
+)
+
+const (
+	// envVarAkashTerminationGracePeriod in service environment requests grace period of its containers, e.g. 90s
+	envVarAkashTerminationGracePeriod = "AKASH_TERMINATION_GRACE_PERIOD"
+	// envVarAkashPreStopExec in service environment is shell command containers run before they are stopped
+	envVarAkashPreStopExec = "AKASH_PRE_STOP_EXEC"
+)
+
+// TerminationSettings configures how tenant workloads are shut down
+type TerminationSettings struct {
+	// GracePeriod of services not requesting one
+	GracePeriod time.Duration
+	// MaxGracePeriod is the upper bound of grace period services can request
+	MaxGracePeriod time.Duration
+	// TeardownTimeout is how long lease teardown waits for workloads scaled to zero to shut down
+	// before lease namespace is deleted. Namespace is deleted right away when zero,
+	// otherwise it must be at least MaxGracePeriod so services get the grace period they requested
+	TeardownTimeout time.Duration
+}
+
+func (s TerminationSettings) validate() error {
+	if s.GracePeriod < 0 || s.TeardownTimeout < 0 {
+		return fmt.Errorf("%w: termination grace period and teardown timeout must not be negative", ErrSettingsValidation)
+	}
+
+	if s.MaxGracePeriod < s.GracePeriod {
+		return fmt.Errorf("%w: max termination grace period must be at least termination grace period", ErrSettingsValidation)
+	}
+
+	if s.TeardownTimeout != 0 && s.TeardownTimeout < s.MaxGracePeriod {
+		return fmt.Errorf("%w: teardown timeout must be at least max termination grace period", ErrSettingsValidation)
+	}
+
+	return nil
+}
+
+// terminationGracePeriod returns grace period in seconds requested by the service, bound by the provider limit
+func (b *Workload) terminationGracePeriod() *int64 {
+	service := &b.deployment.ManifestGroup().Services[b.serviceIdx]
+	settings := b.settings.Termination
+
+	period := settings.GracePeriod
+
+	if val, exists := serviceEnv(service, envVarAkashTerminationGracePeriod); exists {
+		requested, err := time.ParseDuration(val)
+		if err != nil || requested < 0 {
+			b.log.Info("ignoring invalid termination grace period", "service", service.Name, "value", val)
+		} else {
+			period = min(requested, settings.MaxGracePeriod)
+		}
+	}
+
+	seconds := int64(period / time.Second)
+
+	return &seconds
+}
+
+// lifecycle returns lifecycle hooks of the service container, nil when service requests none
+func (b *Workload) lifecycle(service *mani.Service) *corev1.Lifecycle {
+	command, exists := serviceEnv(service, envVarAkashPreStopExec)
+	if !exists || strings.TrimSpace(command) == "" {
+		return nil
+	}
+
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/sh", "-c", command},
+			},
+		},
+	}
+}
+
+// serviceEnv looks up variable in the service environment
+func serviceEnv(service *mani.Service, name string) (string, bool) {
+	for _, val := range service.Env {
+		if key, value, _ := strings.Cut(val, "="); key == name {
+			return value, true
+		}
+	}
+
+	return "", false
+}
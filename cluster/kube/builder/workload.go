@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -21,6 +22,10 @@ const (
 	ResourceGPUAMD    = corev1.ResourceName("amd.com/gpu")
 	GPUVendorNvidia   = "nvidia"
 	GPUVendorAMD      = "amd"
+
+	// nodeNameField selects nodes reservation was placed on by their name
+	nodeNameField             = "metadata.name"
+	placementPreferenceWeight = 100
 )
 
 type workloadBase interface {
@@ -73,6 +78,7 @@ func (b *Workload) container() corev1.Container {
 
 	service := &b.deployment.ManifestGroup().Services[b.serviceIdx]
 	sparams := b.deployment.ClusterParams().SchedulerParams[b.serviceIdx]
+	commit := b.commitLevels(sparams)
 
 	kcontainer := corev1.Container{
 		Name:    service.Name,
@@ -92,7 +98,7 @@ func (b *Workload) container() corev1.Container {
 	}
 
 	if cpu := service.Resources.CPU; cpu != nil {
-		requestedCPU := sdlutil.ComputeCommittedResources(b.settings.CPUCommitLevel, cpu.Units)
+		requestedCPU := sdlutil.ComputeCommittedResources(commit.CPU, cpu.Units)
 		kcontainer.Resources.Requests[corev1.ResourceCPU] = resource.NewScaledQuantity(int64(requestedCPU.Value()), resource.Milli).DeepCopy() // nolint: gosec
 		kcontainer.Resources.Limits[corev1.ResourceCPU] = resource.NewScaledQuantity(int64(cpu.Units.Value()), resource.Milli).DeepCopy()      // nolint: gosec
 	}
@@ -113,12 +119,13 @@ func (b *Workload) container() corev1.Container {
 		//  - can specify GPU limits without specifying requests, because Kubernetes will use the limit as the request value by default.
 		//  - can specify GPU in both limits and requests but these two values must be equal.
 		//  - cannot specify GPU requests without specifying limits.
-		requestedGPU := sdlutil.ComputeCommittedResources(b.settings.GPUCommitLevel, gpu.Units)
+		requestedGPU := sdlutil.ComputeCommittedResources(commit.GPU, gpu.Units)
 		kcontainer.Resources.Requests[resourceName] = resource.NewQuantity(int64(requestedGPU.Value()), resource.DecimalSI).DeepCopy() // nolint: gosec
 		kcontainer.Resources.Limits[resourceName] = resource.NewQuantity(int64(gpu.Units.Value()), resource.DecimalSI).DeepCopy()      // nolint: gosec
 	}
 
 	var requestedMem uint64
+	var requestedVolumesMem uint64
 
 	for _, ephemeral := range service.Resources.Storage {
 		attr := ephemeral.Attributes.Find(sdl.StorageAttributePersistent)
@@ -128,20 +135,25 @@ func (b *Workload) container() corev1.Container {
 
 		if !persistent {
 			if class == "" {
-				requestedStorage := sdlutil.ComputeCommittedResources(b.settings.StorageCommitLevel, ephemeral.Quantity)
+				requestedStorage := sdlutil.ComputeCommittedResources(storageCommitLevel(commit, class), ephemeral.Quantity)
 				kcontainer.Resources.Requests[corev1.ResourceEphemeralStorage] = resource.NewQuantity(int64(requestedStorage.Value()), resource.DecimalSI).DeepCopy() // nolint: gosec
 				kcontainer.Resources.Limits[corev1.ResourceEphemeralStorage] = resource.NewQuantity(int64(ephemeral.Quantity.Value()), resource.DecimalSI).DeepCopy() // nolint: gosec
 			} else if class == "ram" {
 				requestedMem += ephemeral.Quantity.Value()
+				// inventory of commit pool reserves ram volumes out of node memory at the volume commit level,
+				// without commit pool they are left out of the request
+				if sparams != nil && sparams.Commit != nil {
+					requestedVolumesMem += sdlutil.ComputeCommittedResources(storageCommitLevel(commit, class), ephemeral.Quantity).Value()
+				}
 			}
 		}
 	}
 
 	// fixme: ram is never expected to be nil
 	if mem := service.Resources.Memory; mem != nil {
-		requestedRAM := sdlutil.ComputeCommittedResources(b.settings.MemoryCommitLevel, mem.Quantity)
-		kcontainer.Resources.Requests[corev1.ResourceMemory] = resource.NewQuantity(int64(requestedRAM.Value()), resource.DecimalSI).DeepCopy()            // nolint: gosec
-		kcontainer.Resources.Limits[corev1.ResourceMemory] = resource.NewQuantity(int64(mem.Quantity.Value()+requestedMem), resource.DecimalSI).DeepCopy() // nolint: gosec
+		requestedRAM := sdlutil.ComputeCommittedResources(commit.Memory, mem.Quantity)
+		kcontainer.Resources.Requests[corev1.ResourceMemory] = resource.NewQuantity(int64(requestedRAM.Value()+requestedVolumesMem), resource.DecimalSI).DeepCopy() // nolint: gosec
+		kcontainer.Resources.Limits[corev1.ResourceMemory] = resource.NewQuantity(int64(mem.Quantity.Value()+requestedMem), resource.DecimalSI).DeepCopy()          // nolint: gosec
 	}
 
 	if service.Params != nil {
@@ -174,9 +186,27 @@ func (b *Workload) container() corev1.Container {
 		})
 	}
 
+	kcontainer.ReadinessProbe, kcontainer.LivenessProbe, kcontainer.StartupProbe = b.probes(service)
+	kcontainer.Lifecycle = b.lifecycle(service)
+
 	return kcontainer
 }
 
+// commitLevels returns commit levels of the node pool inventory committed service resources in,
+// falling back to the global ones
+func (b *Workload) commitLevels(sparams *crd.SchedulerParams) crd.SchedulerCommit {
+	if sparams != nil && sparams.Commit != nil {
+		return *sparams.Commit
+	}
+
+	return crd.SchedulerCommit{
+		CPU:     b.settings.CPUCommitLevel,
+		GPU:     b.settings.GPUCommitLevel,
+		Memory:  b.settings.MemoryCommitLevel,
+		Storage: b.settings.StorageCommitLevel,
+	}
+}
+
 // Return RAM volumes
 func (b *Workload) volumes() []corev1.Volume {
 	var volumes []corev1.Volume // nolint:prealloc
@@ -268,6 +298,16 @@ func (b *Workload) runtimeClass() *string {
 	return effectiveRuntimeClassName
 }
 
+// priorityClass returns PriorityClass of preemptible services so they yield to full price workloads
+func (b *Workload) priorityClass() string {
+	params := b.deployment.ClusterParams().SchedulerParams[b.serviceIdx]
+	if params == nil || !params.Preemptible {
+		return ""
+	}
+
+	return b.settings.PreemptiblePriorityClass
+}
+
 func (b *Workload) replicas() *int32 {
 	replicas := new(int32)
 	*replicas = int32(b.deployment.ManifestGroup().Services[b.serviceIdx].Count) // nolint: gosec
@@ -293,6 +333,10 @@ func (b *Workload) affinity() *corev1.Affinity {
 		selectors = append(selectors, nodeSelectorsFromResources(svc.Resources)...)
 	}
 
+	if svc != nil && svc.Commit != nil {
+		selectors = append(selectors, nodeSelectorsFromCommit(svc.Commit)...)
+	}
+
 	for _, storage := range service.Resources.Storage {
 		attr := storage.Attributes.Find(sdl.StorageAttributePersistent)
 		if persistent, valid := attr.AsBool(); !valid || !persistent {
@@ -311,15 +355,43 @@ func (b *Workload) affinity() *corev1.Affinity {
 		}
 
 	}
+
+	term := corev1.NodeSelectorTerm{
+		MatchExpressions: selectors,
+	}
+
+	var preferred []corev1.PreferredSchedulingTerm
+
+	if svc != nil && svc.Placement != nil && len(svc.Placement.Nodes) > 0 {
+		pin := corev1.NodeSelectorRequirement{
+			Key:      nodeNameField,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   svc.Placement.Nodes,
+		}
+
+		if svc.Placement.Required {
+			term.MatchFields = append(term.MatchFields, pin)
+		} else {
+			preferred = append(preferred, corev1.PreferredSchedulingTerm{
+				Weight: placementPreferenceWeight,
+				Preference: corev1.NodeSelectorTerm{
+					MatchFields: []corev1.NodeSelectorRequirement{pin},
+				},
+			})
+		}
+	}
+
+	terms := []corev1.NodeSelectorTerm{term}
+	if svc != nil && svc.Commit != nil {
+		terms = nodeSelectorTermsExcluding(term, svc.Commit.ExcludeLabels)
+	}
+
 	affinity := &corev1.Affinity{
 		NodeAffinity: &corev1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
-				NodeSelectorTerms: []corev1.NodeSelectorTerm{
-					{
-						MatchExpressions: selectors,
-					},
-				},
+				NodeSelectorTerms: terms,
 			},
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
 		},
 	}
 
@@ -368,6 +440,78 @@ func nodeSelectorsFromResources(res *crd.SchedulerResources) []corev1.NodeSelect
 	return selectors
 }
 
+// storageCommitLevel returns commit level of volume of the class, class specific one taking precedence
+func storageCommitLevel(commit crd.SchedulerCommit, class string) float64 {
+	if level, exists := commit.StorageClasses[class]; exists {
+		return level
+	}
+
+	return commit.Storage
+}
+
+// nodeSelectorTermsExcluding expands term into terms keeping replicas off nodes of the pools.
+// Node is off the pool when it misses any of the pool labels, so each term rules out one label of every pool
+func nodeSelectorTermsExcluding(term corev1.NodeSelectorTerm, pools []map[string]string) []corev1.NodeSelectorTerm {
+	terms := []corev1.NodeSelectorTerm{term}
+
+	for _, labels := range pools {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		sort.Strings(keys)
+
+		expanded := make([]corev1.NodeSelectorTerm, 0, len(terms)*len(keys))
+
+		for _, term := range terms {
+			for _, key := range keys {
+				excluding := *term.DeepCopy()
+				excluding.MatchExpressions = append(excluding.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values: []string{
+						labels[key],
+					},
+				})
+
+				expanded = append(expanded, excluding)
+			}
+		}
+
+		terms = expanded
+	}
+
+	return terms
+}
+
+// nodeSelectorsFromCommit keeps replicas on nodes of the pool their resources were committed with
+func nodeSelectorsFromCommit(commit *crd.SchedulerCommit) []corev1.NodeSelectorRequirement {
+	keys := make([]string, 0, len(commit.MatchLabels))
+	for key := range commit.MatchLabels {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	selectors := make([]corev1.NodeSelectorRequirement, 0, len(keys))
+	for _, key := range keys {
+		selectors = append(selectors, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values: []string{
+				commit.MatchLabels[key],
+			},
+		})
+	}
+
+	return selectors
+}
+
 func (b *Workload) labels() map[string]string {
 	obj := b.builder.labels()
 	obj[AkashManifestServiceLabelName] = b.deployment.ManifestGroup().Services[b.serviceIdx].Name
@@ -387,7 +531,7 @@ func (b *Workload) imagePullSecrets() []corev1.LocalObjectReference {
 
 	service := &b.deployment.ManifestGroup().Services[b.serviceIdx]
 	if service.Credentials != nil {
-		sname = NewServiceCredentials(*b, service.Credentials).Name()
+		sname = serviceCredentialsName(service.Name)
 	}
 
 	if sname == "" {
@@ -342,6 +342,10 @@ func (c *client) Deploy(ctx context.Context, deployment ctypes.IDeployment) (err
 		return
 	}
 
+	if err = c.relaxMissingPlacement(ctx, cdeployment); err != nil {
+		return
+	}
+
 	lid := cdeployment.LeaseID()
 	group := cdeployment.ManifestGroup()
 
@@ -391,16 +395,16 @@ func (c *client) Deploy(ctx context.Context, deployment ctypes.IDeployment) (err
 		}
 	}
 
-	var resourceVersion string
-
+	// manifest as stored in the cluster
+	smani := po.omani
 	if po.nmani != nil {
-		resourceVersion = po.nmani.ResourceVersion
+		smani = po.nmani
 	} else if po.umani != nil {
-		resourceVersion = po.umani.ResourceVersion
-	} else {
-		resourceVersion = po.omani.ResourceVersion
+		smani = po.umani
 	}
 
+	resourceVersion := smani.ResourceVersion
+
 	if cdeployment.GetResourceVersion() == "" {
 		cdeployment.SetResourceVersion(resourceVersion)
 	}
@@ -416,7 +420,7 @@ func (c *client) Deploy(ctx context.Context, deployment ctypes.IDeployment) (err
 		svc := &deploymentService{}
 
 		if service.Credentials != nil {
-			svc.credentials = builder.NewServiceCredentials(workload, service.Credentials)
+			svc.credentials = builder.NewServiceCredentials(workload, storedCredentials(smani, service))
 		}
 
 		persistent := false
@@ -562,6 +566,12 @@ func (c *client) Deploy(ctx context.Context, deployment ctypes.IDeployment) (err
 func (c *client) TeardownLease(ctx context.Context, lid mtypes.LeaseID) error {
 	c.log.Info("tearing down lease", "lease", lid)
 
+	if timeout := teardownTimeout(ctx); timeout > 0 {
+		if err := c.scaleDownLease(ctx, lid, timeout); err != nil && !kerrors.IsNotFound(err) {
+			c.log.Error("teardown lease: unable to scale down workloads", "ns", builder.LidNS(lid), "error", err)
+		}
+	}
+
 	_, result := wrapKubeCall("namespaces-delete", func() (interface{}, error) {
 		return nil, c.kc.CoreV1().Namespaces().Delete(ctx, builder.LidNS(lid), metav1.DeleteOptions{})
 	})
@@ -966,6 +976,18 @@ exposeCheckLoop:
 	return result, nil
 }
 
+// storedCredentials returns registry credentials of the service as stored in the manifest,
+// falling back to the ones deployment came with when manifest has none
+func storedCredentials(smani *crd.Manifest, service *mapi.Service) *crd.ManifestServiceCredentials {
+	for _, svc := range smani.Spec.Group.Services {
+		if svc.Name == service.Name && svc.Credentials != nil {
+			return svc.Credentials
+		}
+	}
+
+	return crd.NewManifestServiceCredentials(service.Credentials)
+}
+
 func (c *client) leaseExists(ctx context.Context, lid mtypes.LeaseID) error {
 	_, err := wrapKubeCall("namespace-get", func() (*corev1.Namespace, error) {
 		return c.kc.CoreV1().Namespaces().Get(ctx, builder.LidNS(lid), metav1.GetOptions{})
@@ -1019,6 +1041,7 @@ func (c *client) deploymentsForLease(ctx context.Context, lid mtypes.LeaseID) (m
 				UpdatedReplicas:    deployment.Status.UpdatedReplicas,
 				ReadyReplicas:      deployment.Status.ReadyReplicas,
 				AvailableReplicas:  deployment.Status.AvailableReplicas,
+				Rollout:            deploymentRollout(&deployment),
 			}
 		}
 	}
@@ -1034,6 +1057,7 @@ func (c *client) deploymentsForLease(ctx context.Context, lid mtypes.LeaseID) (m
 				UpdatedReplicas:    statefulset.Status.UpdatedReplicas,
 				ReadyReplicas:      statefulset.Status.ReadyReplicas,
 				AvailableReplicas:  statefulset.Status.CurrentReplicas,
+				Rollout:            statefulSetRollout(&statefulset),
 			}
 		}
 	}
@@ -1043,6 +1067,11 @@ func (c *client) deploymentsForLease(ctx context.Context, lid mtypes.LeaseID) (m
 		return nil, kubeclienterrors.ErrNoDeploymentForLease
 	}
 
+	// status is still reported when failures can not be classified
+	if err = c.serviceFailures(ctx, lid, serviceStatus); err != nil {
+		c.log.Error("classifying service failures", "lease-ns", builder.LidNS(lid), "err", err)
+	}
+
 	return serviceStatus, nil
 }
 
@@ -0,0 +1,194 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+const (
+	// failureEventController is reporting controller of lease events raised by deployment monitor
+	failureEventController = "akash.network/deployment-monitor"
+	failureEventAction     = "Monitor"
+)
+
+// ReportServiceFailure records failure of the service as warning event in the lease namespace,
+// tenant sees it along with the rest of lease events
+func (c *client) ReportServiceFailure(ctx context.Context, lid mtypes.LeaseID, service string, failure ctypes.ServiceFailure) error {
+	ns := builder.LidNS(lid)
+	now := time.Now()
+
+	regarding := corev1.ObjectReference{
+		Kind:       "Namespace",
+		APIVersion: "v1",
+		Name:       ns,
+	}
+
+	if failure.Pod != "" {
+		regarding = corev1.ObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Namespace:  ns,
+			Name:       failure.Pod,
+		}
+	}
+
+	evt := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", service, now.UnixNano()),
+			Namespace: ns,
+			Labels: map[string]string{
+				builder.AkashManagedLabelName:         builder.ValTrue,
+				builder.AkashManifestServiceLabelName: service,
+			},
+		},
+		EventTime:           metav1.NewMicroTime(now),
+		ReportingController: failureEventController,
+		ReportingInstance:   c.ns,
+		Action:              failureEventAction,
+		Reason:              string(failure.Reason),
+		Regarding:           regarding,
+		Note:                failure.Message,
+		Type:                corev1.EventTypeWarning,
+	}
+
+	_, err := wrapKubeCall("events-create", func() (*eventsv1.Event, error) {
+		return c.kc.EventsV1().Events(ns).Create(ctx, evt, metav1.CreateOptions{})
+	})
+
+	return err
+}
+
+// serviceFailures classifies failures of lease pods into status of services they belong to
+func (c *client) serviceFailures(ctx context.Context, lid mtypes.LeaseID, status map[string]*ctypes.ServiceStatus) error {
+	ns := builder.LidNS(lid)
+
+	pods, err := wrapKubeCall("pods-list", func() (*corev1.PodList, error) {
+		return c.kc.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return err
+	}
+
+	pvcs, err := wrapKubeCall("pvcs-list", func() (*corev1.PersistentVolumeClaimList, error) {
+		return c.kc.CoreV1().PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return err
+	}
+
+	claims := make(map[string]corev1.PersistentVolumeClaimPhase, len(pvcs.Items))
+	for _, pvc := range pvcs.Items {
+		claims[pvc.Name] = pvc.Status.Phase
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		svc, exists := status[pod.Labels[builder.AkashManifestServiceLabelName]]
+		if !exists {
+			continue
+		}
+
+		svc.Failures = append(svc.Failures, podFailures(pod, claims)...)
+	}
+
+	return nil
+}
+
+// podFailures classifies why the pod is not running, at most one failure of each reason is reported
+func podFailures(pod *corev1.Pod, claims map[string]corev1.PersistentVolumeClaimPhase) []ctypes.ServiceFailure {
+	var result []ctypes.ServiceFailure
+
+	seen := make(map[ctypes.FailureReason]bool)
+	add := func(reason ctypes.FailureReason, message string) {
+		if seen[reason] {
+			return
+		}
+
+		seen[reason] = true
+		result = append(result, ctypes.ServiceFailure{
+			Reason:  reason,
+			Pod:     pod.Name,
+			Message: message,
+		})
+	}
+
+	if pod.Status.Phase == corev1.PodPending {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.PodScheduled || cond.Status != corev1.ConditionFalse || cond.Reason != corev1.PodReasonUnschedulable {
+				continue
+			}
+
+			if claim := podPendingClaim(pod, claims); claim != "" {
+				add(ctypes.FailureVolumePending, fmt.Sprintf("persistent volume claim %s is pending", claim))
+			} else {
+				add(ctypes.FailureUnschedulable, cond.Message)
+			}
+		}
+	}
+
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	probed := make(map[string]bool, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		probed[container.Name] = container.ReadinessProbe != nil
+	}
+
+	for _, cstatus := range statuses {
+		if cstatus.State.Running != nil && !cstatus.Ready && probed[cstatus.Name] {
+			add(ctypes.FailureProbe, fmt.Sprintf("container %s does not pass its health probes", cstatus.Name))
+			continue
+		}
+
+		if terminated := cstatus.State.Terminated; terminated != nil && terminated.Reason == ctypes.FailureOOMKilled.String() {
+			add(ctypes.FailureOOMKilled, fmt.Sprintf("container %s exceeded its memory", cstatus.Name))
+			continue
+		}
+
+		waiting := cstatus.State.Waiting
+		if waiting == nil {
+			continue
+		}
+
+		switch waiting.Reason {
+		case "ErrImagePull", ctypes.FailureImagePull.String():
+			add(ctypes.FailureImagePull, waiting.Message)
+		case ctypes.FailureCrashLoop.String():
+			// container restarting after running out of memory is reported as such
+			if last := cstatus.LastTerminationState.Terminated; last != nil && last.Reason == ctypes.FailureOOMKilled.String() {
+				add(ctypes.FailureOOMKilled, fmt.Sprintf("container %s exceeded its memory", cstatus.Name))
+			} else {
+				add(ctypes.FailureCrashLoop, waiting.Message)
+			}
+		}
+	}
+
+	return result
+}
+
+// podPendingClaim returns name of the pod volume claim not bound yet, empty if none
+func podPendingClaim(pod *corev1.Pod, claims map[string]corev1.PersistentVolumeClaimPhase) string {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		if phase, exists := claims[vol.PersistentVolumeClaim.ClaimName]; !exists || phase == corev1.ClaimPending {
+			return vol.PersistentVolumeClaim.ClaimName
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,183 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/akash-network/node/testutil"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+func failuresTestPod(name string, status corev1.PodStatus, claims ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: status,
+	}
+
+	for _, claim := range claims {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: claim,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		})
+	}
+
+	return pod
+}
+
+func failuresTestWaiting(reason string, last *corev1.ContainerStateTerminated) corev1.PodStatus {
+	return corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:                 "app",
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+				LastTerminationState: corev1.ContainerState{Terminated: last},
+			},
+		},
+	}
+}
+
+func TestPodFailures(t *testing.T) {
+	unschedulable := corev1.PodStatus{
+		Phase: corev1.PodPending,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available",
+			},
+		},
+	}
+
+	claims := map[string]corev1.PersistentVolumeClaimPhase{
+		"bound":   corev1.ClaimBound,
+		"pending": corev1.ClaimPending,
+	}
+
+	tests := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected []ctypes.FailureReason
+	}{
+		{
+			name: "running",
+			pod:  failuresTestPod("web-0", corev1.PodStatus{Phase: corev1.PodRunning}),
+		},
+		{
+			name:     "image pull",
+			pod:      failuresTestPod("web-0", failuresTestWaiting("ErrImagePull", nil)),
+			expected: []ctypes.FailureReason{ctypes.FailureImagePull},
+		},
+		{
+			name:     "crash loop",
+			pod:      failuresTestPod("web-0", failuresTestWaiting("CrashLoopBackOff", &corev1.ContainerStateTerminated{Reason: "Error"})),
+			expected: []ctypes.FailureReason{ctypes.FailureCrashLoop},
+		},
+		{
+			name:     "oom killed",
+			pod:      failuresTestPod("web-0", failuresTestWaiting("CrashLoopBackOff", &corev1.ContainerStateTerminated{Reason: "OOMKilled"})),
+			expected: []ctypes.FailureReason{ctypes.FailureOOMKilled},
+		},
+		{
+			name:     "unschedulable",
+			pod:      failuresTestPod("web-0", unschedulable, "bound"),
+			expected: []ctypes.FailureReason{ctypes.FailureUnschedulable},
+		},
+		{
+			name:     "volume pending",
+			pod:      failuresTestPod("web-0", unschedulable, "bound", "pending"),
+			expected: []ctypes.FailureReason{ctypes.FailureVolumePending},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var reasons []ctypes.FailureReason
+			for _, failure := range podFailures(test.pod, claims) {
+				require.Equal(t, test.pod.Name, failure.Pod)
+				reasons = append(reasons, failure.Reason)
+			}
+
+			require.Equal(t, test.expected, reasons)
+		})
+	}
+}
+
+func TestLeaseStatusWithFailures(t *testing.T) {
+	lid := testutil.LeaseID(t)
+	ns := builder.LidNS(lid)
+
+	lns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ns,
+		},
+	}
+
+	depl := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: ns,
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas: 1,
+		},
+	}
+
+	pod := failuresTestPod("web-0", failuresTestWaiting("ImagePullBackOff", nil))
+	pod.Namespace = ns
+	pod.Labels = map[string]string{builder.AkashManifestServiceLabelName: "web"}
+
+	kc := clientForTest(t, []runtime.Object{lns, depl, pod}, []runtime.Object{}).(*client)
+
+	ctx := context.WithValue(context.Background(), builder.SettingsKey, builder.Settings{
+		ClusterPublicHostname: "meow.com",
+	})
+
+	status, err := kc.LeaseStatus(ctx, lid)
+	require.NoError(t, err)
+	require.Equal(t, []ctypes.ServiceFailure{{Reason: ctypes.FailureImagePull, Pod: "web-0"}}, status["web"].Failures)
+
+	require.NoError(t, kc.ReportServiceFailure(ctx, lid, "web", status["web"].Failures[0]))
+
+	events, err := kc.kc.EventsV1().Events(ns).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 1)
+	require.Equal(t, string(ctypes.FailureImagePull), events.Items[0].Reason)
+	require.Equal(t, corev1.EventTypeWarning, events.Items[0].Type)
+	require.Equal(t, "Pod", events.Items[0].Regarding.Kind)
+	require.Equal(t, "web-0", events.Items[0].Regarding.Name)
+	require.Equal(t, "web", events.Items[0].Labels[builder.AkashManifestServiceLabelName])
+}
+
+func TestPodFailuresProbe(t *testing.T) {
+	pod := failuresTestPod("web-0", corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{
+			{
+				Name:  "web",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			},
+		},
+	})
+
+	require.Empty(t, podFailures(pod, nil))
+
+	pod.Spec.Containers = []corev1.Container{{Name: "web", ReadinessProbe: &corev1.Probe{}}}
+
+	failures := podFailures(pod, nil)
+	require.Len(t, failures, 1)
+	require.Equal(t, ctypes.FailureProbe, failures[0].Reason)
+}
@@ -0,0 +1,158 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+// SetNodeMaintenance records maintenance in the node annotation. Leases running on the node
+// get migration notice on their manifests when maintenance asks for it
+func (c *client) SetNodeMaintenance(ctx context.Context, maintenance ctypes.NodeMaintenance) error {
+	val, err := json.Marshal(maintenance)
+	if err != nil {
+		return err
+	}
+
+	if err = c.annotateNode(ctx, maintenance.Node, string(val)); err != nil {
+		return err
+	}
+
+	if !maintenance.NotifyLeases {
+		return nil
+	}
+
+	manifests, err := c.nodeLeaseManifests(ctx, maintenance.Node)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range manifests {
+		if err = c.annotateManifest(ctx, name, string(val)); err != nil {
+			return err
+		}
+
+		c.log.Info("lease notified of node maintenance", "manifest", name, "node", maintenance.Node)
+	}
+
+	return nil
+}
+
+// ClearNodeMaintenance removes maintenance annotation of the node and migration notices it raised
+func (c *client) ClearNodeMaintenance(ctx context.Context, node string) error {
+	if err := c.annotateNode(ctx, node, ""); err != nil {
+		return err
+	}
+
+	manifests, err := wrapKubeCall("manifests-list", func() (*crd.ManifestList, error) {
+		return c.ac.AkashV2beta2().Manifests(c.ns).List(ctx, metav1.ListOptions{})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests.Items {
+		val, exists := manifest.Annotations[ctypes.MigrationNoticeAnnotation]
+		if !exists {
+			continue
+		}
+
+		notice := ctypes.NodeMaintenance{}
+		if err = json.Unmarshal([]byte(val), &notice); err == nil && notice.Node != node {
+			continue
+		}
+
+		if err = c.annotateManifest(ctx, manifest.Name, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nodeLeaseManifests returns names of manifests of leases with pods on the node.
+// Lease namespace and its manifest share the name
+func (c *client) nodeLeaseManifests(ctx context.Context, node string) ([]string, error) {
+	pods, err := wrapKubeCall("pods-list", func() (*corev1.PodList, error) {
+		return c.kc.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", builder.AkashManagedLabelName, builder.ValTrue),
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+
+	for _, pod := range pods.Items {
+		if seen[pod.Namespace] {
+			continue
+		}
+
+		seen[pod.Namespace] = true
+		result = append(result, pod.Namespace)
+	}
+
+	return result, nil
+}
+
+// annotateNode sets maintenance annotation of the node, empty value removes it
+func (c *client) annotateNode(ctx context.Context, node string, val string) error {
+	patch, err := annotationPatch(ctypes.MaintenanceAnnotation, val)
+	if err != nil {
+		return err
+	}
+
+	_, err = wrapKubeCall("nodes-patch", func() (*corev1.Node, error) {
+		return c.kc.CoreV1().Nodes().Patch(ctx, node, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	})
+
+	return err
+}
+
+// annotateManifest sets migration notice annotation of the manifest, empty value removes it
+func (c *client) annotateManifest(ctx context.Context, name string, val string) error {
+	patch, err := annotationPatch(ctypes.MigrationNoticeAnnotation, val)
+	if err != nil {
+		return err
+	}
+
+	_, err = wrapKubeCall("manifests-patch", func() (*crd.Manifest, error) {
+		return c.ac.AkashV2beta2().Manifests(c.ns).Patch(ctx, name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	})
+
+	// lease may be gone in the meantime
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
+
+// annotationPatch returns merge patch setting the annotation, or removing it when value is empty
+func annotationPatch(key string, val string) ([]byte, error) {
+	var value interface{}
+	if val != "" {
+		value = val
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				key: value,
+			},
+		},
+	})
+}
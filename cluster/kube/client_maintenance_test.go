@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func TestNodeMaintenance(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+		},
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-0",
+			Namespace: "lease-a",
+			Labels:    map[string]string{builder.AkashManagedLabelName: builder.ValTrue},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node1",
+		},
+	}
+
+	manifests := []runtime.Object{
+		&crd.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "lease-a", Namespace: testKubeClientNs}},
+		&crd.Manifest{ObjectMeta: metav1.ObjectMeta{Name: "lease-b", Namespace: testKubeClientNs}},
+	}
+
+	kc := clientForTest(t, []runtime.Object{node, pod}, manifests).(*client)
+	ctx := context.Background()
+
+	maintenance := ctypes.NodeMaintenance{
+		Node:         "node1",
+		Reason:       "kernel upgrade",
+		NotifyLeases: true,
+	}
+
+	require.NoError(t, kc.SetNodeMaintenance(ctx, maintenance))
+
+	knode, err := kc.kc.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	stored := ctypes.NodeMaintenance{}
+	require.NoError(t, json.Unmarshal([]byte(knode.Annotations[ctypes.MaintenanceAnnotation]), &stored))
+	require.Equal(t, maintenance, stored)
+
+	notified, err := kc.ac.AkashV2beta2().Manifests(testKubeClientNs).Get(ctx, "lease-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Contains(t, notified.Annotations, ctypes.MigrationNoticeAnnotation)
+
+	other, err := kc.ac.AkashV2beta2().Manifests(testKubeClientNs).Get(ctx, "lease-b", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, other.Annotations, ctypes.MigrationNoticeAnnotation)
+
+	require.NoError(t, kc.ClearNodeMaintenance(ctx, "node1"))
+
+	knode, err = kc.kc.CoreV1().Nodes().Get(ctx, "node1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, knode.Annotations, ctypes.MaintenanceAnnotation)
+
+	notified, err = kc.ac.AkashV2beta2().Manifests(testKubeClientNs).Get(ctx, "lease-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, notified.Annotations, ctypes.MigrationNoticeAnnotation)
+}
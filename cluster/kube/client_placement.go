@@ -0,0 +1,87 @@
+package kube
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+// relaxMissingPlacement falls back to scheduler placement for services required to run on pinned nodes
+// any of which exists no more. Otherwise, replicas placed on the missing nodes would stay pending forever
+func (c *client) relaxMissingPlacement(ctx context.Context, cdeployment builder.IClusterDeployment) error {
+	// slice is shared with the deployment, so replaced params are picked up by the workload builders
+	sparams := cdeployment.ClusterParams().SchedulerParams
+	group := cdeployment.ManifestGroup()
+
+	for idx, params := range sparams {
+		missing, err := c.missingPinnedNode(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		if !missing {
+			continue
+		}
+
+		c.log.Info("pinned nodes are gone, falling back to scheduler placement",
+			"lease", cdeployment.LeaseID(),
+			"service", group.Services[idx].Name,
+			"nodes", params.Placement.Nodes)
+
+		relaxed := params.DeepCopy()
+		relaxed.Placement.Required = false
+
+		sparams[idx] = relaxed
+	}
+
+	return nil
+}
+
+// MissingPlacement reports whether any node services of the deployment are required to run on is gone.
+// Deploying the deployment again relaxes placement of such services
+func (c *client) MissingPlacement(ctx context.Context, deployment ctypes.IDeployment) (bool, error) {
+	var sparams []*crd.SchedulerParams
+
+	switch cparams := deployment.ClusterParams().(type) {
+	case crd.ClusterSettings:
+		sparams = cparams.SchedulerParams
+	case crd.ReservationClusterSettings:
+		for _, params := range cparams {
+			sparams = append(sparams, params)
+		}
+	}
+
+	for _, params := range sparams {
+		missing, err := c.missingPinnedNode(ctx, params)
+		if err != nil || missing {
+			return missing, err
+		}
+	}
+
+	return false, nil
+}
+
+// missingPinnedNode reports whether service is required to run on pinned nodes any of which is gone
+func (c *client) missingPinnedNode(ctx context.Context, params *crd.SchedulerParams) (bool, error) {
+	if params == nil || params.Placement == nil || !params.Placement.Required {
+		return false, nil
+	}
+
+	for _, node := range params.Placement.Nodes {
+		_, err := c.kc.CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
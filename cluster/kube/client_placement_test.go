@@ -0,0 +1,100 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	manifest "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	"github.com/akash-network/node/testutil"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func TestRelaxMissingPlacement(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+		},
+	}
+
+	kc := clientForTest(t, []runtime.Object{node}, nil).(*client)
+
+	present := &crd.SchedulerParams{
+		Placement: &crd.SchedulerPlacement{Nodes: []string{"node1"}, Required: true},
+	}
+	partial := &crd.SchedulerParams{
+		Placement: &crd.SchedulerPlacement{Nodes: []string{"node1", "node2"}, Required: true},
+	}
+	gone := &crd.SchedulerParams{
+		Placement: &crd.SchedulerPlacement{Nodes: []string{"node3"}, Required: true},
+	}
+
+	cdep := &builder.ClusterDeployment{
+		Lid: testutil.LeaseID(t),
+		Group: &manifest.Group{
+			Services: manifest.Services{
+				{Name: "present"},
+				{Name: "gone"},
+				{Name: "unpinned"},
+				{Name: "partial"},
+			},
+		},
+		Sparams: crd.ClusterSettings{
+			SchedulerParams: []*crd.SchedulerParams{present, gone, nil, partial},
+		},
+	}
+
+	missing, err := kc.MissingPlacement(context.Background(), &ctypes.Deployment{
+		Lid:     cdep.Lid,
+		MGroup:  cdep.Group,
+		CParams: cdep.Sparams,
+	})
+	require.NoError(t, err)
+	require.True(t, missing)
+
+	require.NoError(t, kc.relaxMissingPlacement(context.Background(), cdep))
+
+	sparams := cdep.ClusterParams().SchedulerParams
+	require.Same(t, present, sparams[0])
+	require.False(t, sparams[1].Placement.Required)
+	require.Equal(t, []string{"node3"}, sparams[1].Placement.Nodes)
+	require.Nil(t, sparams[2])
+	require.False(t, sparams[3].Placement.Required)
+
+	// original params are not modified
+	require.True(t, gone.Placement.Required)
+}
+
+func TestMissingPlacement(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+		},
+	}
+
+	kc := clientForTest(t, []runtime.Object{node}, nil).(*client)
+
+	deployment := &ctypes.Deployment{
+		Lid: testutil.LeaseID(t),
+		MGroup: &manifest.Group{
+			Services: manifest.Services{{Name: "pinned"}, {Name: "preferred"}},
+		},
+		CParams: crd.ClusterSettings{
+			SchedulerParams: []*crd.SchedulerParams{
+				{Placement: &crd.SchedulerPlacement{Nodes: []string{"node1"}, Required: true}},
+				{Placement: &crd.SchedulerPlacement{Nodes: []string{"node2"}}},
+			},
+		},
+	}
+
+	missing, err := kc.MissingPlacement(context.Background(), deployment)
+	require.NoError(t, err)
+	require.False(t, missing)
+}
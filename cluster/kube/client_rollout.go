@@ -0,0 +1,56 @@
+package kube
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+// rolloutStrategy returns strategy workload is annotated with, workloads created before strategies were
+// recorded roll out with rolling update
+func rolloutStrategy(annotations map[string]string) ctypes.RolloutStrategy {
+	if strategy, exists := annotations[builder.AkashRolloutStrategy]; exists {
+		return ctypes.RolloutStrategy(strategy)
+	}
+
+	return ctypes.RolloutRolling
+}
+
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+// deploymentRollout reports rollout of the deployment, complete the same way kubectl rollout status decides
+func deploymentRollout(obj *appsv1.Deployment) *ctypes.RolloutStatus {
+	desired := desiredReplicas(obj.Spec.Replicas)
+
+	return &ctypes.RolloutStatus{
+		Strategy: rolloutStrategy(obj.Annotations),
+		Desired:  desired,
+		Updated:  obj.Status.UpdatedReplicas,
+		Complete: obj.Status.ObservedGeneration >= obj.Generation &&
+			obj.Status.UpdatedReplicas == desired &&
+			obj.Status.Replicas == desired &&
+			obj.Status.AvailableReplicas == desired,
+	}
+}
+
+// statefulSetRollout reports rollout of the stateful set, complete once every replica runs the update revision
+func statefulSetRollout(obj *appsv1.StatefulSet) *ctypes.RolloutStatus {
+	desired := desiredReplicas(obj.Spec.Replicas)
+
+	return &ctypes.RolloutStatus{
+		Strategy: rolloutStrategy(obj.Annotations),
+		Desired:  desired,
+		Updated:  obj.Status.UpdatedReplicas,
+		Complete: obj.Status.ObservedGeneration >= obj.Generation &&
+			obj.Status.UpdatedReplicas == desired &&
+			obj.Status.ReadyReplicas == desired &&
+			obj.Status.UpdateRevision == obj.Status.CurrentRevision,
+	}
+}
@@ -0,0 +1,65 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+func TestDeploymentRollout(t *testing.T) {
+	replicas := int32(2)
+
+	obj := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Generation:  2,
+			Annotations: map[string]string{builder.AkashRolloutStrategy: string(ctypes.RolloutSurge)},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           3,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  3,
+		},
+	}
+
+	require.Equal(t, &ctypes.RolloutStatus{Strategy: ctypes.RolloutSurge, Desired: 2, Updated: 1}, deploymentRollout(obj))
+
+	obj.Status.Replicas = 2
+	obj.Status.UpdatedReplicas = 2
+	obj.Status.AvailableReplicas = 2
+	require.True(t, deploymentRollout(obj).Complete)
+
+	// update not observed by controller yet
+	obj.Generation = 3
+	require.False(t, deploymentRollout(obj).Complete)
+
+	obj.Annotations = nil
+	require.Equal(t, ctypes.RolloutRolling, deploymentRollout(obj).Strategy)
+}
+
+func TestStatefulSetRollout(t *testing.T) {
+	replicas := int32(1)
+
+	obj := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        1,
+			ReadyReplicas:   1,
+			UpdatedReplicas: 1,
+			CurrentRevision: "db-1",
+			UpdateRevision:  "db-2",
+		},
+	}
+
+	require.False(t, statefulSetRollout(obj).Complete)
+
+	obj.Status.CurrentRevision = "db-2"
+	require.True(t, statefulSetRollout(obj).Complete)
+}
@@ -0,0 +1,126 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+)
+
+// teardownPollInterval is how often teardown checks whether lease pods are gone
+var teardownPollInterval = time.Second
+
+var scaleToZeroPatch = []byte(`{"spec":{"replicas":0}}`)
+
+// teardownTimeout returns how long teardown waits for workloads of the lease to shut down,
+// zero when settings are not available
+func teardownTimeout(ctx context.Context) time.Duration {
+	settings, valid := ctx.Value(builder.SettingsKey).(builder.Settings)
+	if !valid {
+		return 0
+	}
+
+	return settings.Termination.TeardownTimeout
+}
+
+// scaleDownLease scales workloads of the lease to zero and waits until their pods are gone or timeout expires,
+// so containers shut down gracefully and run their pre-stop hooks while the rest of the namespace still exists
+func (c *client) scaleDownLease(ctx context.Context, lid mtypes.LeaseID, timeout time.Duration) error {
+	ns := builder.LidNS(lid)
+
+	deployments, err := wrapKubeCall("deployments-list", func() ([]string, error) {
+		list, err := c.kc.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=true", builder.AkashManagedLabelName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(list.Items))
+		for _, obj := range list.Items {
+			names = append(names, obj.Name)
+		}
+
+		return names, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range deployments {
+		_, err = wrapKubeCall("deployments-patch", func() (interface{}, error) {
+			return c.kc.AppsV1().Deployments(ns).Patch(ctx, name, k8stypes.MergePatchType, scaleToZeroPatch, metav1.PatchOptions{})
+		})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	statefulSets, err := wrapKubeCall("statefulsets-list", func() ([]string, error) {
+		list, err := c.kc.AppsV1().StatefulSets(ns).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=true", builder.AkashManagedLabelName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(list.Items))
+		for _, obj := range list.Items {
+			names = append(names, obj.Name)
+		}
+
+		return names, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range statefulSets {
+		_, err = wrapKubeCall("statefulsets-patch", func() (interface{}, error) {
+			return c.kc.AppsV1().StatefulSets(ns).Patch(ctx, name, k8stypes.MergePatchType, scaleToZeroPatch, metav1.PatchOptions{})
+		})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	expired := time.After(timeout)
+	ticker := time.NewTicker(teardownPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pods, err := wrapKubeCall("pods-list", func() (int, error) {
+			list, err := c.kc.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("%s=true", builder.AkashManagedLabelName),
+			})
+			if err != nil {
+				return 0, err
+			}
+
+			return len(list.Items), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if pods == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-expired:
+			c.log.Info("teardown lease: workloads did not shut down in time", "ns", ns, "pods", pods, "timeout", timeout)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
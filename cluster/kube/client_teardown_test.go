@@ -0,0 +1,98 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/akash-network/node/testutil"
+
+	"github.com/akash-network/provider/cluster/kube/builder"
+)
+
+func TestTeardownLeaseScalesDown(t *testing.T) {
+	lid := testutil.LeaseID(t)
+	ns := builder.LidNS(lid)
+
+	labels := map[string]string{builder.AkashManagedLabelName: "true"}
+	replicas := int32(2)
+
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: ns},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns, Labels: labels},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: ns, Labels: labels},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: ns, Labels: labels},
+		},
+	}
+
+	kc := clientForTest(t, objects, []runtime.Object{}).(*client)
+
+	settings := builder.NewDefaultSettings()
+	settings.Termination.TeardownTimeout = 50 * time.Millisecond
+
+	teardownPollInterval = 10 * time.Millisecond
+	defer func() {
+		teardownPollInterval = time.Second
+	}()
+
+	ctx := context.WithValue(context.Background(), builder.SettingsKey, settings)
+
+	start := time.Now()
+	require.NoError(t, kc.TeardownLease(ctx, lid))
+	require.GreaterOrEqual(t, time.Since(start), settings.Termination.TeardownTimeout)
+
+	depl, err := kc.kc.AppsV1().Deployments(ns).Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), *depl.Spec.Replicas)
+
+	sset, err := kc.kc.AppsV1().StatefulSets(ns).Get(ctx, "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), *sset.Spec.Replicas)
+
+	_, err = kc.kc.CoreV1().Namespaces().Get(ctx, ns, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func TestTeardownLeaseWithoutTimeout(t *testing.T) {
+	lid := testutil.LeaseID(t)
+	ns := builder.LidNS(lid)
+
+	replicas := int32(1)
+
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: ns},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns, Labels: map[string]string{builder.AkashManagedLabelName: "true"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	}
+
+	kc := clientForTest(t, objects, []runtime.Object{}).(*client)
+
+	require.NoError(t, kc.TeardownLease(context.Background(), lid))
+
+	depl, err := kc.kc.AppsV1().Deployments(ns).Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), *depl.Spec.Replicas)
+
+	_, err = kc.kc.CoreV1().Namespaces().Get(context.Background(), ns, metav1.GetOptions{})
+	require.True(t, kerrors.IsNotFound(err))
+}
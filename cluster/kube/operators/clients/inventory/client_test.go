@@ -32,6 +32,7 @@ import (
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 
 	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
 	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
 	"github.com/akash-network/provider/testutil"
 	"github.com/akash-network/provider/tools/fromctx"
@@ -41,6 +42,7 @@ type testReservation struct {
 	resources         dtypes.GroupSpec
 	adjustedResources dtypes.ResourceUnits
 	cparams           interface{}
+	placement         map[uint32][]string
 }
 
 type testInventoryServer struct {
@@ -59,6 +61,7 @@ var (
 )
 
 var _ ctypes.Reservation = (*testReservation)(nil)
+var _ ctypes.ReservationPlacement = (*testReservation)(nil)
 
 func (r *testReservation) OrderID() mtypes.OrderID {
 	return mtypes.OrderID{}
@@ -88,6 +91,14 @@ func (r *testReservation) ClusterParams() interface{} {
 	return r.cparams
 }
 
+func (r *testReservation) SetPlacement(val map[uint32][]string) {
+	r.placement = val
+}
+
+func (r *testReservation) Placement() map[uint32][]string {
+	return r.placement
+}
+
 // type proxyCallback func(req *http.Request) (*http.Response, error)
 type inventoryScaffold struct {
 	ctx   context.Context
@@ -438,6 +449,78 @@ func TestInventoryMultipleReplicasFulFilled1(t *testing.T) {
 
 	require.True(t, exists)
 	require.Nil(t, sparams)
+
+	// each replica is placed on a node
+	require.Len(t, reservation.placement[reservation.resources.Resources[0].ID], 2)
+}
+
+func TestInventoryDryRunKeepsCapacity(t *testing.T) {
+	scaffold := makeInventoryScaffold(t)
+	cl, err := NewClient(scaffold.ctx)
+	require.NoError(t, err)
+	require.NotNil(t, cl)
+
+	scaffold.gInv.invch <- inventoryV1.Cluster{
+		Nodes: multipleReplicasGenNodes(),
+	}
+
+	inv := waitForInventory(t, cl.ResultChan())
+	require.NotNil(t, inv)
+
+	before := inv.Metrics()
+
+	reservation := multipleReplicasGenReservations(100000, 0, 2)
+	err = inv.Adjust(reservation, ctypes.WithDryRun())
+	require.NoError(t, err)
+	require.NotNil(t, reservation.GetAllocatedResources())
+	require.NotEmpty(t, reservation.placement)
+
+	require.Equal(t, before, inv.Metrics())
+}
+
+func TestInventoryPlacementStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		expected string
+	}{
+		{strategy: cinventory.PlacementFirstFit, expected: "node1"},
+		{strategy: cinventory.PlacementBinPack, expected: "node1"},
+		{strategy: cinventory.PlacementSpread, expected: "node3"},
+		{strategy: cinventory.PlacementGPUPreserving, expected: "node1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			strategy, err := cinventory.NewPlacementStrategy(test.strategy)
+			require.NoError(t, err)
+
+			inv := newInventory(inventoryV1.Cluster{
+				Nodes: multipleReplicasGenNodes(),
+			})
+
+			reservation := multipleReplicasGenReservations(1000, 0, 1)
+			err = inv.Adjust(reservation, ctypes.WithPlacementStrategy(strategy))
+			require.NoError(t, err)
+			require.Equal(t, []string{test.expected}, reservation.placement[reservation.resources.Resources[0].ID])
+		})
+	}
+
+	// gpu-preserving keeps cpu-only work off the gpu node even when it would fit there first
+	nodes := multipleReplicasGenNodes()
+	nodes[0], nodes[1] = nodes[1], nodes[0]
+
+	strategy, err := cinventory.NewPlacementStrategy(cinventory.PlacementGPUPreserving)
+	require.NoError(t, err)
+
+	inv := newInventory(inventoryV1.Cluster{Nodes: nodes})
+	reservation := multipleReplicasGenReservations(1000, 0, 1)
+	require.NoError(t, inv.Adjust(reservation, ctypes.WithPlacementStrategy(strategy)))
+	require.Equal(t, []string{"node1"}, reservation.placement[reservation.resources.Resources[0].ID])
+
+	inv = newInventory(inventoryV1.Cluster{Nodes: nodes})
+	reservation = multipleReplicasGenReservations(1000, 0, 1)
+	require.NoError(t, inv.Adjust(reservation))
+	require.Equal(t, []string{"node2"}, reservation.placement[reservation.resources.Resources[0].ID])
 }
 
 func TestInventoryMultipleReplicasFulFilled2(t *testing.T) {
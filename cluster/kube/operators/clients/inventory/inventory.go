@@ -1,6 +1,7 @@
 package inventory
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -42,7 +43,7 @@ func (inv *inventory) Dup() ctypes.Inventory {
 // tryAdjust cluster inventory
 // It returns two boolean values. First indicates if node-wide resources satisfy (true) requirements
 // Seconds indicates if cluster-wide resources satisfy (true) requirements
-func (inv *inventory) tryAdjust(node int, res *types.Resources) (*crd.SchedulerParams, bool, bool) {
+func (inv *inventory) tryAdjust(node int, res *types.Resources, headroom ctypes.Headroom) (*crd.SchedulerParams, bool, bool) {
 	nd := inv.Nodes[node].Dup()
 	sparams := &crd.SchedulerParams{}
 
@@ -105,6 +106,10 @@ func (inv *inventory) tryAdjust(node int, res *types.Resources) (*crd.SchedulerP
 		}
 	}
 
+	if !cinventory.NodeKeepsHeadroom(headroom, &nd, res) {
+		return nil, false, true
+	}
+
 	// all requirements for current group have been satisfied
 	// commit and move on
 	inv.Nodes[node] = nd
@@ -216,6 +221,45 @@ func (inv *inventory) Adjust(reservation ctypes.ReservationGroup, opts ...ctypes
 		cfg = opt(cfg)
 	}
 
+	err := inv.adjust(reservation, cfg)
+	if !errors.Is(err, ctypes.ErrInsufficientCapacity) || len(cfg.Preemptible) == 0 {
+		return err
+	}
+
+	rp, valid := reservation.(ctypes.ReservationPreemption)
+	if !valid {
+		return err
+	}
+
+	trial := *cfg
+	trial.DryRun = true
+
+	preempted := cinventory.Preempt(&inv.Cluster, cfg.Preemptible, func(cluster *inventoryV1.Cluster) bool {
+		return newInventory(*cluster).adjust(reservation, &trial) == nil
+	})
+	if len(preempted) == 0 {
+		return err
+	}
+
+	released := inv.dup()
+	for _, candidate := range preempted {
+		cinventory.ReleaseReservation(&released.Cluster, candidate)
+	}
+
+	if err = released.adjust(reservation, cfg); err != nil {
+		return err
+	}
+
+	if !cfg.DryRun {
+		*inv = released
+	}
+
+	rp.SetPreempted(preempted)
+
+	return nil
+}
+
+func (inv *inventory) adjust(reservation ctypes.ReservationGroup, cfg *ctypes.InventoryOptions) error {
 	origResources := reservation.Resources().GetResourceUnits()
 	resources := make(dtypes.ResourceUnits, 0, len(origResources))
 	adjustedResources := make(dtypes.ResourceUnits, 0, len(origResources))
@@ -233,13 +277,16 @@ func (inv *inventory) Adjust(reservation ctypes.ReservationGroup, opts ...ctypes
 	}
 
 	cparams := make(crd.ReservationClusterSettings)
+	placement := make(map[uint32][]string)
 
 	currInventory := inv.dup()
 
+	order := cinventory.PlacementOrder(cfg.Placement, currInventory.Nodes, origResources)
+
 	var err error
 
 nodes:
-	for nodeIdx := range currInventory.Nodes {
+	for _, nodeIdx := range order {
 		for i := len(resources) - 1; i >= 0; i-- {
 			adjustedGroup := false
 
@@ -253,7 +300,7 @@ nodes:
 			}
 
 			for ; resources[i].Count > 0; resources[i].Count-- {
-				sparams, nStatus, cStatus := currInventory.tryAdjust(nodeIdx, adjusted)
+				sparams, nStatus, cStatus := currInventory.tryAdjust(nodeIdx, adjusted, cfg.Headroom)
 				if !cStatus {
 					// cannot satisfy cluster-wide resources, stop lookup
 					break nodes
@@ -264,6 +311,8 @@ nodes:
 					continue nodes
 				}
 
+				placement[adjusted.ID] = append(placement[adjusted.ID], currInventory.Nodes[nodeIdx].Name)
+
 				// at this point we expect all replicas of the same service to produce
 				// same adjusted resource units as well as cluster params
 				if adjustedGroup {
@@ -292,6 +341,10 @@ nodes:
 		}
 	}
 
+	if len(resources) == 0 && !cinventory.ClusterKeepsHeadroom(cfg.Headroom, currInventory.Metrics(), origResources) {
+		return ctypes.ErrInsufficientCapacity
+	}
+
 	if len(resources) == 0 {
 		if !cfg.DryRun {
 			*inv = currInventory
@@ -300,6 +353,10 @@ nodes:
 		reservation.SetAllocatedResources(adjustedResources)
 		reservation.SetClusterParams(cparams)
 
+		if rp, valid := reservation.(ctypes.ReservationPlacement); valid {
+			rp.SetPlacement(placement)
+		}
+
 		return nil
 	}
 
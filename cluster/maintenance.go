@@ -0,0 +1,111 @@
+package cluster
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/tendermint/tendermint/libs/log"
+	corev1 "k8s.io/api/core/v1"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+)
+
+// nodesMaintenance returns maintenance of annotated and cordoned nodes sorted by node name
+func nodesMaintenance(log log.Logger, nodes []*corev1.Node) []ctypes.NodeMaintenance {
+	var result []ctypes.NodeMaintenance
+
+	for _, node := range nodes {
+		maintenance, exists, err := parseNodeMaintenance(node)
+		if err != nil {
+			log.Error("invalid node maintenance annotation, keeping node in maintenance", "node", node.Name, "err", err)
+		}
+
+		if exists {
+			result = append(result, maintenance)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Node < result[j].Node
+	})
+
+	return result
+}
+
+// parseNodeMaintenance reads maintenance of the node from its annotation and cordon status.
+// Node annotated with invalid maintenance is in maintenance indefinitely
+func parseNodeMaintenance(node *corev1.Node) (ctypes.NodeMaintenance, bool, error) {
+	val, annotated := node.Annotations[ctypes.MaintenanceAnnotation]
+	if !annotated && !node.Spec.Unschedulable {
+		return ctypes.NodeMaintenance{}, false, nil
+	}
+
+	maintenance := ctypes.NodeMaintenance{}
+
+	var err error
+	if annotated {
+		if err = json.Unmarshal([]byte(val), &maintenance); err != nil {
+			maintenance = ctypes.NodeMaintenance{}
+		}
+	}
+
+	maintenance.Node = node.Name
+	maintenance.Cordoned = node.Spec.Unschedulable
+
+	return maintenance, true, err
+}
+
+// maintenance returns nodes in maintenance or scheduled for it
+func (is *inventoryService) maintenance() []ctypes.NodeMaintenance {
+	if is.nodeMaintenance == nil {
+		return nil
+	}
+
+	return is.nodeMaintenance()
+}
+
+// skipMaintenance wraps placement strategy to leave out nodes maintenance is in effect on
+func (is *inventoryService) skipMaintenance(strategy ctypes.PlacementStrategy) ctypes.PlacementStrategy {
+	now := time.Now()
+	excluded := make(map[string]bool)
+
+	for _, maintenance := range is.maintenance() {
+		if maintenance.InEffect(now) {
+			excluded[maintenance.Node] = true
+		}
+	}
+
+	if len(excluded) == 0 {
+		return strategy
+	}
+
+	return maintenancePlacement{
+		strategy: strategy,
+		excluded: excluded,
+	}
+}
+
+// maintenancePlacement limits nodes of the wrapped placement strategy to the ones not in maintenance
+type maintenancePlacement struct {
+	strategy ctypes.PlacementStrategy
+	excluded map[string]bool
+}
+
+var _ ctypes.PlacementStrategy = (*maintenancePlacement)(nil)
+
+func (p maintenancePlacement) Order(nodes inventoryV1.Nodes, resources dtypes.ResourceUnits) []int {
+	order := cinventory.PlacementOrder(p.strategy, nodes, resources)
+	res := order[:0]
+
+	for _, idx := range order {
+		if !p.excluded[nodes[idx].Name] {
+			res = append(res, idx)
+		}
+	}
+
+	return res
+}
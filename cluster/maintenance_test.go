@@ -0,0 +1,122 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/akash-network/node/testutil"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+	cfromctx "github.com/akash-network/provider/cluster/types/v1beta3/fromctx"
+)
+
+func maintenanceTestNode(name string, annotation string, cordoned bool) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.NodeSpec{
+			Unschedulable: cordoned,
+		},
+	}
+
+	if annotation != "" {
+		node.Annotations = map[string]string{ctypes.MaintenanceAnnotation: annotation}
+	}
+
+	return node
+}
+
+func TestNodesMaintenance(t *testing.T) {
+	nodes := []*corev1.Node{
+		maintenanceTestNode("nodeD", "", false),
+		maintenanceTestNode("nodeC", `{"reason":"disk replacement","end":"2030-01-01T00:00:00Z","notify_leases":true}`, false),
+		maintenanceTestNode("nodeB", "", true),
+		maintenanceTestNode("nodeA", "{invalid", false),
+	}
+
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, []ctypes.NodeMaintenance{
+		{Node: "nodeA"},
+		{Node: "nodeB", Cordoned: true},
+		{Node: "nodeC", Reason: "disk replacement", End: &end, NotifyLeases: true},
+	}, nodesMaintenance(testutil.Logger(t), nodes))
+}
+
+func TestInventory_WatchNodesOfEveryCluster(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodeA := maintenanceTestNode("nodeA", "", true)
+	nodeB := maintenanceTestNode("nodeB", "", true)
+	nodeB.Labels = map[string]string{"pool": "gpu"}
+
+	ctx = context.WithValue(ctx, cfromctx.CtxKeyKubeClientBackends, map[string]kubernetes.Interface{
+		PrimaryBackend: kfake.NewSimpleClientset(nodeA),
+		"remote":       kfake.NewSimpleClientset(nodeB),
+	})
+
+	is := &inventoryService{log: testutil.Logger(t)}
+	is.watchNodes(ctx)
+
+	require.Len(t, is.nodeInformers, 2)
+
+	for _, factory := range is.nodeInformers {
+		factory.WaitForCacheSync(ctx.Done())
+	}
+
+	require.Equal(t, map[string]string{"pool": "gpu"}, is.nodeLabels("nodeB"))
+	require.Nil(t, is.nodeLabels("nodeC"))
+	require.Equal(t, []ctypes.NodeMaintenance{
+		{Node: "nodeA", Cordoned: true},
+		{Node: "nodeB", Cordoned: true},
+	}, is.maintenance())
+}
+
+func TestNodeMaintenanceInEffect(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	require.True(t, ctypes.NodeMaintenance{}.InEffect(now))
+	require.True(t, ctypes.NodeMaintenance{Start: &future, End: &future}.InEffect(now))
+	require.False(t, ctypes.NodeMaintenance{End: &past}.InEffect(now))
+	require.True(t, ctypes.NodeMaintenance{End: &past, Cordoned: true}.InEffect(now))
+}
+
+func TestInventory_SkipMaintenance(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	maintenance := []ctypes.NodeMaintenance{
+		{Node: "nodeA"},
+		{Node: "nodeB", End: &past},
+	}
+
+	is := &inventoryService{
+		log:   testutil.Logger(t),
+		pools: Config{}.commitPools(),
+		nodeMaintenance: func() []ctypes.NodeMaintenance {
+			return maintenance
+		},
+	}
+
+	inv := <-cinventory.NewNull(context.Background(), "nodeA", "nodeB").ResultChan()
+
+	// nodeA is in maintenance, maintenance window of nodeB is over
+	res, err := is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(1000, ""))
+	require.NoError(t, err)
+	require.Equal(t, []string{"nodeB"}, res.placement[1])
+
+	maintenance = append(maintenance[:1], ctypes.NodeMaintenance{Node: "nodeB", Cordoned: true})
+
+	_, err = is.adjustReservation(inv, testutil.OrderID(t), commitTestGroup(1000, ""))
+	require.ErrorIs(t, err, ctypes.ErrInsufficientCapacity)
+}
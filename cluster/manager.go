@@ -62,6 +62,7 @@ type deploymentManager struct {
 	wg                  sync.WaitGroup
 	updatech            chan ctypes.IDeployment
 	teardownch          chan struct{}
+	preemptch           chan event.ReservationPreempted
 	currentHostnames    map[string]struct{}
 	log                 log.Logger
 	lc                  lifecycle.Lifecycle
@@ -86,6 +87,7 @@ func newDeploymentManager(s *service, deployment ctypes.IDeployment, isNewLease
 		wg:                  sync.WaitGroup{},
 		updatech:            make(chan ctypes.IDeployment),
 		teardownch:          make(chan struct{}),
+		preemptch:           make(chan event.ReservationPreempted),
 		log:                 logger,
 		lc:                  lifecycle.New(),
 		hostnameService:     s.HostnameService(),
@@ -130,6 +132,16 @@ func (dm *deploymentManager) teardown() error {
 	}
 }
 
+// preempt closes the lease and tears its deployment down as inventory evicted its reservation
+func (dm *deploymentManager) preempt(ev event.ReservationPreempted) error {
+	select {
+	case dm.preemptch <- ev:
+		return nil
+	case <-dm.lc.ShuttingDown():
+		return ErrNotRunning
+	}
+}
+
 func (dm *deploymentManager) handleUpdate(ctx context.Context) <-chan error {
 	switch dm.state {
 	case dsDeployActive:
@@ -210,16 +222,15 @@ loop:
 
 		case <-dm.teardownch:
 			dm.log.Debug("teardown request")
-			dm.stopMonitor()
-			switch dm.state {
-			case dsDeployActive:
-				dm.state = dsTeardownPending
-			case dsDeployPending:
-				dm.state = dsTeardownPending
-			case dsDeployComplete:
-				// start teardown
-				runch = dm.startTeardown()
-			case dsTeardownActive, dsTeardownPending, dsTeardownComplete:
+			if newch := dm.handleTeardown(); newch != nil {
+				runch = newch
+			}
+		case ev := <-dm.preemptch:
+			dm.log.Info("lease preempted, closing lease", "preempted-by", ev.PreemptedBy)
+			deploymentCounter.WithLabelValues("preempt", "start").Inc()
+			dm.closeLease()
+			if newch := dm.handleTeardown(); newch != nil {
+				runch = newch
 			}
 		}
 	}
@@ -248,6 +259,37 @@ loop:
 	dm.log.Info("shutdown complete")
 }
 
+func (dm *deploymentManager) handleTeardown() <-chan error {
+	dm.stopMonitor()
+	switch dm.state {
+	case dsDeployActive:
+		dm.state = dsTeardownPending
+	case dsDeployPending:
+		dm.state = dsTeardownPending
+	case dsDeployComplete:
+		// start teardown
+		return dm.startTeardown()
+	case dsTeardownActive, dsTeardownPending, dsTeardownComplete:
+	}
+
+	return nil
+}
+
+// closeLease closes bid of the lease on chain in the background
+func (dm *deploymentManager) closeLease() {
+	dm.wg.Add(1)
+	go func() {
+		defer dm.wg.Done()
+
+		if _, err := closeBid(context.Background(), dm.session, dm.deployment.LeaseID().BidID()); err != nil {
+			dm.log.Error("closing preempted lease", "err", err)
+			return
+		}
+
+		dm.log.Info("preempted lease closed")
+	}()
+}
+
 func (dm *deploymentManager) startMonitor() {
 	dm.wg.Add(1)
 	dm.monitor = newDeploymentMonitor(dm)
@@ -310,7 +352,7 @@ func (dm *deploymentManager) startTeardown() <-chan error {
 	dm.state = dsTeardownActive
 	return dm.do(func() error {
 		// Don't use a context tied to the lifecycle, as we don't want to cancel Kubernetes operations
-		return dm.doTeardown(context.Background())
+		return dm.doTeardown(fromctx.ApplyToContext(context.Background(), dm.config.ClusterSettings))
 	})
 }
 
@@ -97,6 +97,53 @@ func (_c *Client_AllHostnames_Call) RunAndReturn(run func(context.Context) ([]ho
 	return _c
 }
 
+// ClearNodeMaintenance provides a mock function with given fields: ctx, node
+func (_m *Client) ClearNodeMaintenance(ctx context.Context, node string) error {
+	ret := _m.Called(ctx, node)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearNodeMaintenance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, node)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_ClearNodeMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearNodeMaintenance'
+type Client_ClearNodeMaintenance_Call struct {
+	*mock.Call
+}
+
+// ClearNodeMaintenance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - node string
+func (_e *Client_Expecter) ClearNodeMaintenance(ctx interface{}, node interface{}) *Client_ClearNodeMaintenance_Call {
+	return &Client_ClearNodeMaintenance_Call{Call: _e.mock.On("ClearNodeMaintenance", ctx, node)}
+}
+
+func (_c *Client_ClearNodeMaintenance_Call) Run(run func(ctx context.Context, node string)) *Client_ClearNodeMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_ClearNodeMaintenance_Call) Return(_a0 error) *Client_ClearNodeMaintenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ClearNodeMaintenance_Call) RunAndReturn(run func(context.Context, string) error) *Client_ClearNodeMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ConnectHostnameToDeployment provides a mock function with given fields: ctx, directive
 func (_m *Client) ConnectHostnameToDeployment(ctx context.Context, directive hostname.ConnectToDeploymentDirective) error {
 	ret := _m.Called(ctx, directive)
@@ -898,6 +945,63 @@ func (_c *Client_LeaseStatus_Call) RunAndReturn(run func(context.Context, v1beta
 	return _c
 }
 
+// MissingPlacement provides a mock function with given fields: ctx, deployment
+func (_m *Client) MissingPlacement(ctx context.Context, deployment v1beta3.IDeployment) (bool, error) {
+	ret := _m.Called(ctx, deployment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MissingPlacement")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1beta3.IDeployment) (bool, error)); ok {
+		return rf(ctx, deployment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, v1beta3.IDeployment) bool); ok {
+		r0 = rf(ctx, deployment)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, v1beta3.IDeployment) error); ok {
+		r1 = rf(ctx, deployment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_MissingPlacement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MissingPlacement'
+type Client_MissingPlacement_Call struct {
+	*mock.Call
+}
+
+// MissingPlacement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deployment v1beta3.IDeployment
+func (_e *Client_Expecter) MissingPlacement(ctx interface{}, deployment interface{}) *Client_MissingPlacement_Call {
+	return &Client_MissingPlacement_Call{Call: _e.mock.On("MissingPlacement", ctx, deployment)}
+}
+
+func (_c *Client_MissingPlacement_Call) Run(run func(ctx context.Context, deployment v1beta3.IDeployment)) *Client_MissingPlacement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(v1beta3.IDeployment))
+	})
+	return _c
+}
+
+func (_c *Client_MissingPlacement_Call) Return(_a0 bool, _a1 error) *Client_MissingPlacement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_MissingPlacement_Call) RunAndReturn(run func(context.Context, v1beta3.IDeployment) (bool, error)) *Client_MissingPlacement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ObserveHostnameState provides a mock function with given fields: ctx
 func (_m *Client) ObserveHostnameState(ctx context.Context) (<-chan hostname.ResourceEvent, error) {
 	ret := _m.Called(ctx)
@@ -1255,6 +1359,55 @@ func (_c *Client_RemoveHostnameFromDeployment_Call) RunAndReturn(run func(contex
 	return _c
 }
 
+// ReportServiceFailure provides a mock function with given fields: ctx, lID, service, failure
+func (_m *Client) ReportServiceFailure(ctx context.Context, lID v1beta4.LeaseID, service string, failure v1beta3.ServiceFailure) error {
+	ret := _m.Called(ctx, lID, service, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportServiceFailure")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1beta4.LeaseID, string, v1beta3.ServiceFailure) error); ok {
+		r0 = rf(ctx, lID, service, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_ReportServiceFailure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReportServiceFailure'
+type Client_ReportServiceFailure_Call struct {
+	*mock.Call
+}
+
+// ReportServiceFailure is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lID v1beta4.LeaseID
+//   - service string
+//   - failure v1beta3.ServiceFailure
+func (_e *Client_Expecter) ReportServiceFailure(ctx interface{}, lID interface{}, service interface{}, failure interface{}) *Client_ReportServiceFailure_Call {
+	return &Client_ReportServiceFailure_Call{Call: _e.mock.On("ReportServiceFailure", ctx, lID, service, failure)}
+}
+
+func (_c *Client_ReportServiceFailure_Call) Run(run func(ctx context.Context, lID v1beta4.LeaseID, service string, failure v1beta3.ServiceFailure)) *Client_ReportServiceFailure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(v1beta4.LeaseID), args[2].(string), args[3].(v1beta3.ServiceFailure))
+	})
+	return _c
+}
+
+func (_c *Client_ReportServiceFailure_Call) Return(_a0 error) *Client_ReportServiceFailure_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_ReportServiceFailure_Call) RunAndReturn(run func(context.Context, v1beta4.LeaseID, string, v1beta3.ServiceFailure) error) *Client_ReportServiceFailure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ServiceStatus provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Client) ServiceStatus(_a0 context.Context, _a1 v1beta4.LeaseID, _a2 string) (*v1beta3.ServiceStatus, error) {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -1315,6 +1468,53 @@ func (_c *Client_ServiceStatus_Call) RunAndReturn(run func(context.Context, v1be
 	return _c
 }
 
+// SetNodeMaintenance provides a mock function with given fields: ctx, maintenance
+func (_m *Client) SetNodeMaintenance(ctx context.Context, maintenance v1beta3.NodeMaintenance) error {
+	ret := _m.Called(ctx, maintenance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNodeMaintenance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, v1beta3.NodeMaintenance) error); ok {
+		r0 = rf(ctx, maintenance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_SetNodeMaintenance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNodeMaintenance'
+type Client_SetNodeMaintenance_Call struct {
+	*mock.Call
+}
+
+// SetNodeMaintenance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - maintenance v1beta3.NodeMaintenance
+func (_e *Client_Expecter) SetNodeMaintenance(ctx interface{}, maintenance interface{}) *Client_SetNodeMaintenance_Call {
+	return &Client_SetNodeMaintenance_Call{Call: _e.mock.On("SetNodeMaintenance", ctx, maintenance)}
+}
+
+func (_c *Client_SetNodeMaintenance_Call) Run(run func(ctx context.Context, maintenance v1beta3.NodeMaintenance)) *Client_SetNodeMaintenance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(v1beta3.NodeMaintenance))
+	})
+	return _c
+}
+
+func (_c *Client_SetNodeMaintenance_Call) Return(_a0 error) *Client_SetNodeMaintenance_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_SetNodeMaintenance_Call) RunAndReturn(run func(context.Context, v1beta3.NodeMaintenance) error) *Client_SetNodeMaintenance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TeardownLease provides a mock function with given fields: _a0, _a1
 func (_m *Client) TeardownLease(_a0 context.Context, _a1 v1beta4.LeaseID) error {
 	ret := _m.Called(_a0, _a1)
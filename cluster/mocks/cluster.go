@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	v1beta3 "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	typesv1beta3 "github.com/akash-network/provider/cluster/types/v1beta3"
 	mock "github.com/stretchr/testify/mock"
@@ -23,6 +25,63 @@ func (_m *Cluster) EXPECT() *Cluster_Expecter {
 	return &Cluster_Expecter{mock: &_m.Mock}
 }
 
+// OwnerUsage provides a mock function with given fields: ctx, owner
+func (_m *Cluster) OwnerUsage(ctx context.Context, owner string) (typesv1beta3.OwnerUsage, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OwnerUsage")
+	}
+
+	var r0 typesv1beta3.OwnerUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (typesv1beta3.OwnerUsage, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) typesv1beta3.OwnerUsage); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		r0 = ret.Get(0).(typesv1beta3.OwnerUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cluster_OwnerUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OwnerUsage'
+type Cluster_OwnerUsage_Call struct {
+	*mock.Call
+}
+
+// OwnerUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *Cluster_Expecter) OwnerUsage(ctx interface{}, owner interface{}) *Cluster_OwnerUsage_Call {
+	return &Cluster_OwnerUsage_Call{Call: _e.mock.On("OwnerUsage", ctx, owner)}
+}
+
+func (_c *Cluster_OwnerUsage_Call) Run(run func(ctx context.Context, owner string)) *Cluster_OwnerUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Cluster_OwnerUsage_Call) Return(_a0 typesv1beta3.OwnerUsage, _a1 error) *Cluster_OwnerUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Cluster_OwnerUsage_Call) RunAndReturn(run func(context.Context, string) (typesv1beta3.OwnerUsage, error)) *Cluster_OwnerUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Reserve provides a mock function with given fields: _a0, _a1
 func (_m *Cluster) Reserve(_a0 v1beta4.OrderID, _a1 v1beta3.ResourceGroup) (typesv1beta3.Reservation, error) {
 	ret := _m.Called(_a0, _a1)
@@ -82,6 +141,65 @@ func (_c *Cluster_Reserve_Call) RunAndReturn(run func(v1beta4.OrderID, v1beta3.R
 	return _c
 }
 
+// ReserveDryRun provides a mock function with given fields: _a0, _a1
+func (_m *Cluster) ReserveDryRun(_a0 v1beta4.OrderID, _a1 v1beta3.ResourceGroup) (typesv1beta3.Reservation, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveDryRun")
+	}
+
+	var r0 typesv1beta3.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(v1beta4.OrderID, v1beta3.ResourceGroup) (typesv1beta3.Reservation, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(v1beta4.OrderID, v1beta3.ResourceGroup) typesv1beta3.Reservation); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(typesv1beta3.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(v1beta4.OrderID, v1beta3.ResourceGroup) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Cluster_ReserveDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveDryRun'
+type Cluster_ReserveDryRun_Call struct {
+	*mock.Call
+}
+
+// ReserveDryRun is a helper method to define mock.On call
+//   - _a0 v1beta4.OrderID
+//   - _a1 v1beta3.ResourceGroup
+func (_e *Cluster_Expecter) ReserveDryRun(_a0 interface{}, _a1 interface{}) *Cluster_ReserveDryRun_Call {
+	return &Cluster_ReserveDryRun_Call{Call: _e.mock.On("ReserveDryRun", _a0, _a1)}
+}
+
+func (_c *Cluster_ReserveDryRun_Call) Run(run func(_a0 v1beta4.OrderID, _a1 v1beta3.ResourceGroup)) *Cluster_ReserveDryRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(v1beta4.OrderID), args[1].(v1beta3.ResourceGroup))
+	})
+	return _c
+}
+
+func (_c *Cluster_ReserveDryRun_Call) Return(_a0 typesv1beta3.Reservation, _a1 error) *Cluster_ReserveDryRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Cluster_ReserveDryRun_Call) RunAndReturn(run func(v1beta4.OrderID, v1beta3.ResourceGroup) (typesv1beta3.Reservation, error)) *Cluster_ReserveDryRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Unreserve provides a mock function with given fields: _a0
 func (_m *Cluster) Unreserve(_a0 v1beta4.OrderID) error {
 	ret := _m.Called(_a0)
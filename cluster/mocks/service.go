@@ -244,6 +244,63 @@ func (_c *Service_HostnameService_Call) RunAndReturn(run func() v1beta3.Hostname
 	return _c
 }
 
+// OwnerUsage provides a mock function with given fields: ctx, owner
+func (_m *Service) OwnerUsage(ctx context.Context, owner string) (v1beta3.OwnerUsage, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OwnerUsage")
+	}
+
+	var r0 v1beta3.OwnerUsage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (v1beta3.OwnerUsage, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) v1beta3.OwnerUsage); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		r0 = ret.Get(0).(v1beta3.OwnerUsage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_OwnerUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OwnerUsage'
+type Service_OwnerUsage_Call struct {
+	*mock.Call
+}
+
+// OwnerUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *Service_Expecter) OwnerUsage(ctx interface{}, owner interface{}) *Service_OwnerUsage_Call {
+	return &Service_OwnerUsage_Call{Call: _e.mock.On("OwnerUsage", ctx, owner)}
+}
+
+func (_c *Service_OwnerUsage_Call) Run(run func(ctx context.Context, owner string)) *Service_OwnerUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Service_OwnerUsage_Call) Return(_a0 v1beta3.OwnerUsage, _a1 error) *Service_OwnerUsage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_OwnerUsage_Call) RunAndReturn(run func(context.Context, string) (v1beta3.OwnerUsage, error)) *Service_OwnerUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ready provides a mock function with given fields:
 func (_m *Service) Ready() <-chan struct{} {
 	ret := _m.Called()
@@ -350,6 +407,65 @@ func (_c *Service_Reserve_Call) RunAndReturn(run func(v1beta4.OrderID, deploymen
 	return _c
 }
 
+// ReserveDryRun provides a mock function with given fields: _a0, _a1
+func (_m *Service) ReserveDryRun(_a0 v1beta4.OrderID, _a1 deploymentv1beta3.ResourceGroup) (v1beta3.Reservation, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveDryRun")
+	}
+
+	var r0 v1beta3.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(v1beta4.OrderID, deploymentv1beta3.ResourceGroup) (v1beta3.Reservation, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(v1beta4.OrderID, deploymentv1beta3.ResourceGroup) v1beta3.Reservation); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1beta3.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(v1beta4.OrderID, deploymentv1beta3.ResourceGroup) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Service_ReserveDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveDryRun'
+type Service_ReserveDryRun_Call struct {
+	*mock.Call
+}
+
+// ReserveDryRun is a helper method to define mock.On call
+//   - _a0 v1beta4.OrderID
+//   - _a1 deploymentv1beta3.ResourceGroup
+func (_e *Service_Expecter) ReserveDryRun(_a0 interface{}, _a1 interface{}) *Service_ReserveDryRun_Call {
+	return &Service_ReserveDryRun_Call{Call: _e.mock.On("ReserveDryRun", _a0, _a1)}
+}
+
+func (_c *Service_ReserveDryRun_Call) Run(run func(_a0 v1beta4.OrderID, _a1 deploymentv1beta3.ResourceGroup)) *Service_ReserveDryRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(v1beta4.OrderID), args[1].(deploymentv1beta3.ResourceGroup))
+	})
+	return _c
+}
+
+func (_c *Service_ReserveDryRun_Call) Return(_a0 v1beta3.Reservation, _a1 error) *Service_ReserveDryRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Service_ReserveDryRun_Call) RunAndReturn(run func(v1beta4.OrderID, deploymentv1beta3.ResourceGroup) (v1beta3.Reservation, error)) *Service_ReserveDryRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Status provides a mock function with given fields: _a0
 func (_m *Service) Status(_a0 context.Context) (*v1beta3.Status, error) {
 	ret := _m.Called(_a0)
@@ -2,18 +2,16 @@ package cluster
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"time"
 
-	aclient "github.com/akash-network/akash-api/go/node/client/v1beta2"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/boz/go-lifecycle"
 	"github.com/tendermint/tendermint/libs/log"
 
-	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	"github.com/akash-network/node/pubsub"
 	"github.com/akash-network/node/util/runner"
 
@@ -27,16 +25,34 @@ var (
 	deploymentHealthCheckCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "provider_deployment_monitor_health",
 	}, []string{"state"})
+
+	deploymentFailureCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "provider_deployment_monitor_failure",
+		Help: "The total number of service failures reported to tenants by reason",
+	}, []string{"reason"})
 )
 
+// monitorCheck is result of a single deployment health check
+type monitorCheck struct {
+	healthy  bool
+	failures []ctypes.ServiceFailure
+	// placementMissing is set when unschedulable replicas are pinned to nodes which are gone
+	placementMissing bool
+}
+
 type deploymentMonitor struct {
 	bus     pubsub.Bus
 	session session.Session
 	client  Client
 
 	deployment ctypes.IDeployment
+	// redeploy deploys the deployment again, relaxing placement pinned to missing nodes
+	redeploy func(ctypes.IDeployment) error
 
 	attempts uint
+	// reported holds service failures tenant has been notified of, keyed by service and reason.
+	// Accessed by the check in progress only
+	reported map[string]bool
 	log      log.Logger
 	lc       lifecycle.Lifecycle
 
@@ -49,6 +65,7 @@ func newDeploymentMonitor(dm *deploymentManager) *deploymentMonitor {
 		session:    dm.session,
 		client:     dm.client,
 		deployment: dm.deployment,
+		redeploy:   dm.update,
 		log:        dm.log.With("cmp", "deployment-monitor"),
 		lc:         lifecycle.New(),
 		config:     dm.config,
@@ -102,7 +119,8 @@ loop:
 
 			var currStatus event.ClusterDeploymentStatus
 
-			healthy := result.Value().(bool)
+			check := result.Value().(monitorCheck)
+			healthy := check.healthy
 
 			if healthy {
 				currStatus = event.ClusterDeploymentDeployed
@@ -124,6 +142,16 @@ loop:
 				prevStatus = currStatus
 			}
 
+			if !healthy && check.placementMissing && m.redeploy != nil {
+				// manager restarts the monitor once deployed again
+				m.log.Info("pinned nodes are gone, deploying again")
+				if err := m.redeploy(m.deployment); err != nil {
+					m.log.Error("deploying again", "err", err)
+				}
+
+				m.redeploy = nil
+			}
+
 			if !healthy {
 				if m.attempts <= m.config.MonitorMaxRetries {
 					// unhealthy.  retry
@@ -131,9 +159,18 @@ loop:
 					break
 				}
 
-				m.log.Error("deployment failed.  closing lease.")
-				deploymentHealthCheckCounter.WithLabelValues("failed").Inc()
-				closech = m.runCloseLease(ctx)
+				switch policy := m.config.MonitorFailurePolicies.Policy(check.failures); policy {
+				case ctypes.FailurePolicyRetry:
+					m.log.Info("deployment failing, retrying", "failures", check.failures)
+					tickch = m.scheduleRetry()
+				case ctypes.FailurePolicyNotify:
+					m.log.Info("deployment failing, keeping lease", "failures", check.failures)
+					tickch = m.scheduleHealthcheck()
+				default:
+					m.log.Error("deployment failed.  closing lease.", "failures", check.failures)
+					deploymentHealthCheckCounter.WithLabelValues("failed").Inc()
+					closech = m.runCloseLease(ctx)
+				}
 			}
 		case <-closech:
 			closech = nil
@@ -157,28 +194,47 @@ func (m *deploymentMonitor) runCheck(ctx context.Context) <-chan runner.Result {
 	})
 }
 
-func (m *deploymentMonitor) doCheck(ctx context.Context) (bool, error) {
+func (m *deploymentMonitor) doCheck(ctx context.Context) (monitorCheck, error) {
 	ctx = fromctx.ApplyToContext(ctx, m.config.ClusterSettings)
 
 	status, err := m.client.LeaseStatus(ctx, m.deployment.LeaseID())
 
 	if err != nil {
 		m.log.Error("lease status", "err", err)
-		return false, err
+		return monitorCheck{}, err
 	}
 
 	badsvc := 0
+	failures := make(map[string][]ctypes.ServiceFailure)
 
 	for _, spec := range m.deployment.ManifestGroup().Services {
 		service, foundService := status[spec.Name]
 		if foundService {
-			if uint32(service.Available) < spec.Count { // nolint: gosec
+			// replicas passing readiness probes are the ones serving traffic
+			if uint32(service.ReadyReplicas) < spec.Count { // nolint: gosec
 				badsvc++
-				m.log.Debug("service available replicas below target",
+				m.log.Debug("service ready replicas below target",
 					"service", spec.Name,
-					"available", service.Available,
+					"ready", service.ReadyReplicas,
 					"target", spec.Count,
 				)
+
+				if len(service.Failures) != 0 {
+					failures[spec.Name] = service.Failures
+				}
+			} else if service.Rollout != nil && !service.Rollout.Complete {
+				// enough replicas are serving while the rest are replaced, failures of new replicas are
+				// reported to the tenant without failing the deployment
+				m.log.Info("service rollout in progress",
+					"service", spec.Name,
+					"strategy", service.Rollout.Strategy,
+					"updated", service.Rollout.Updated,
+					"desired", service.Rollout.Desired,
+				)
+
+				if len(service.Failures) != 0 {
+					failures[spec.Name] = service.Failures
+				}
 			}
 		}
 
@@ -188,16 +244,75 @@ func (m *deploymentMonitor) doCheck(ctx context.Context) (bool, error) {
 		}
 	}
 
-	return badsvc == 0, nil
+	result := monitorCheck{
+		healthy: badsvc == 0,
+	}
+
+	if unschedulable(failures) {
+		result.placementMissing, err = m.client.MissingPlacement(ctx, m.deployment)
+		if err != nil {
+			m.log.Error("checking pinned nodes", "err", err)
+		}
+	}
+
+	for _, sfailures := range failures {
+		result.failures = append(result.failures, sfailures...)
+	}
+
+	m.reportFailures(ctx, failures)
+
+	return result, nil
+}
+
+// unschedulable reports whether replicas of any service cannot be scheduled
+func unschedulable(failures map[string][]ctypes.ServiceFailure) bool {
+	for _, sfailures := range failures {
+		for _, failure := range sfailures {
+			if failure.Reason == ctypes.FailureUnschedulable {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// reportFailures notifies tenant of service failures not reported by previous check.
+// Failure cleared and raised again is reported again
+func (m *deploymentMonitor) reportFailures(ctx context.Context, failures map[string][]ctypes.ServiceFailure) {
+	reported := make(map[string]bool)
+
+	for service, sfailures := range failures {
+		for _, failure := range sfailures {
+			key := fmt.Sprintf("%s/%s", service, failure.Reason)
+			if reported[key] {
+				continue
+			}
+
+			reported[key] = true
+
+			if m.reported[key] {
+				continue
+			}
+
+			deploymentFailureCounter.WithLabelValues(string(failure.Reason)).Inc()
+			m.log.Info("service failure", "service", service, "reason", failure.Reason, "pod", failure.Pod, "message", failure.Message)
+
+			if err := m.client.ReportServiceFailure(ctx, m.deployment.LeaseID(), service, failure); err != nil {
+				m.log.Error("reporting service failure", "service", service, "reason", failure.Reason, "err", err)
+				// try again with the next check
+				delete(reported, key)
+			}
+		}
+	}
+
+	m.reported = reported
 }
 
 func (m *deploymentMonitor) runCloseLease(ctx context.Context) <-chan runner.Result {
 	return runner.Do(func() runner.Result {
 		// TODO: retry, timeout
-		msg := &mtypes.MsgCloseBid{
-			BidID: m.deployment.LeaseID().BidID(),
-		}
-		res, err := m.session.Client().Tx().Broadcast(ctx, []sdk.Msg{msg}, aclient.WithResultCodeAsError())
+		res, err := closeBid(ctx, m.session, m.deployment.LeaseID().BidID())
 		if err != nil {
 			m.log.Error("closing deployment", "err", err)
 		} else {
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"context"
 	"testing"
 
 	"github.com/boz/go-lifecycle"
@@ -120,7 +121,7 @@ func TestMonitorSendsClusterDeploymentDeployed(t *testing.T) {
 		ObservedGeneration: 0,
 		Replicas:           0,
 		UpdatedReplicas:    0,
-		ReadyReplicas:      0,
+		ReadyReplicas:      3,
 		AvailableReplicas:  0,
 	}
 	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(statusResult, nil)
@@ -148,3 +149,169 @@ func TestMonitorSendsClusterDeploymentDeployed(t *testing.T) {
 
 	monitor.lc.Shutdown(nil)
 }
+
+func TestFailurePolicies(t *testing.T) {
+	policies, err := ctypes.ParseFailurePolicies(map[string]string{
+		string(ctypes.FailureUnschedulable): string(ctypes.FailurePolicyRetry),
+		string(ctypes.FailureVolumePending): string(ctypes.FailurePolicyNotify),
+	})
+	require.NoError(t, err)
+
+	unschedulable := ctypes.ServiceFailure{Reason: ctypes.FailureUnschedulable}
+	pending := ctypes.ServiceFailure{Reason: ctypes.FailureVolumePending}
+	crash := ctypes.ServiceFailure{Reason: ctypes.FailureCrashLoop}
+
+	require.Equal(t, ctypes.FailurePolicyClose, policies.Policy(nil))
+	require.Equal(t, ctypes.FailurePolicyRetry, policies.Policy([]ctypes.ServiceFailure{unschedulable}))
+	require.Equal(t, ctypes.FailurePolicyNotify, policies.Policy([]ctypes.ServiceFailure{unschedulable, pending}))
+	require.Equal(t, ctypes.FailurePolicyClose, policies.Policy([]ctypes.ServiceFailure{pending, crash}))
+
+	_, err = ctypes.ParseFailurePolicies(map[string]string{"Evicted": string(ctypes.FailurePolicyRetry)})
+	require.ErrorIs(t, err, ctypes.ErrFailureReasonInvalid)
+
+	_, err = ctypes.ParseFailurePolicies(map[string]string{string(ctypes.FailureCrashLoop): "ignore"})
+	require.ErrorIs(t, err, ctypes.ErrFailurePolicyInvalid)
+}
+
+func TestMonitorReportsFailuresOnce(t *testing.T) {
+	const serviceName = "test"
+
+	group := &manifest.Group{
+		Services: manifest.Services{{Name: serviceName, Count: 1}},
+	}
+
+	deployment := &ctypes.Deployment{
+		Lid:    testutil.LeaseID(t),
+		MGroup: group,
+	}
+
+	failure := ctypes.ServiceFailure{Reason: ctypes.FailureCrashLoop, Pod: "test-0"}
+	failing := map[string]*ctypes.ServiceStatus{
+		serviceName: {Name: serviceName, Total: 1, Failures: []ctypes.ServiceFailure{failure}},
+	}
+	healthy := map[string]*ctypes.ServiceStatus{
+		serviceName: {Name: serviceName, Available: 1, Total: 1, ReadyReplicas: 1},
+	}
+
+	client := &mocks.Client{}
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(failing, nil).Twice()
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(healthy, nil).Once()
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(failing, nil).Once()
+	client.On("ReportServiceFailure", mock.Anything, deployment.LeaseID(), serviceName, failure).Return(nil).Twice()
+
+	monitor := &deploymentMonitor{
+		client:     client,
+		deployment: deployment,
+		log:        testutil.Logger(t),
+		config:     NewDefaultConfig(),
+	}
+
+	ctx := context.Background()
+
+	for _, expected := range []bool{false, false, true, false} {
+		check, err := monitor.doCheck(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expected, check.healthy)
+
+		if !expected {
+			require.Equal(t, []ctypes.ServiceFailure{failure}, check.failures)
+		}
+	}
+
+	client.AssertExpectations(t)
+}
+
+func TestMonitorDetectsMissingPlacement(t *testing.T) {
+	const serviceName = "test"
+
+	deployment := &ctypes.Deployment{
+		Lid: testutil.LeaseID(t),
+		MGroup: &manifest.Group{
+			Services: manifest.Services{{Name: serviceName, Count: 1}},
+		},
+	}
+
+	failure := ctypes.ServiceFailure{Reason: ctypes.FailureUnschedulable, Pod: "test-0"}
+
+	client := &mocks.Client{}
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(map[string]*ctypes.ServiceStatus{
+		serviceName: {Name: serviceName, Total: 1, Failures: []ctypes.ServiceFailure{failure}},
+	}, nil)
+	client.On("ReportServiceFailure", mock.Anything, deployment.LeaseID(), serviceName, failure).Return(nil).Once()
+	client.On("MissingPlacement", mock.Anything, deployment).Return(true, nil).Once()
+
+	monitor := &deploymentMonitor{
+		client:     client,
+		deployment: deployment,
+		log:        testutil.Logger(t),
+		config:     NewDefaultConfig(),
+	}
+
+	check, err := monitor.doCheck(context.Background())
+	require.NoError(t, err)
+	require.False(t, check.healthy)
+	require.True(t, check.placementMissing)
+
+	client.AssertExpectations(t)
+}
+
+func TestMonitorChecksReadiness(t *testing.T) {
+	const serviceName = "test"
+
+	deployment := &ctypes.Deployment{
+		Lid: testutil.LeaseID(t),
+		MGroup: &manifest.Group{
+			Services: manifest.Services{{Name: serviceName, Count: 2}},
+		},
+	}
+
+	client := &mocks.Client{}
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(map[string]*ctypes.ServiceStatus{
+		serviceName: {Name: serviceName, Available: 2, Total: 2, ReadyReplicas: 1},
+	}, nil)
+
+	monitor := &deploymentMonitor{
+		client:     client,
+		deployment: deployment,
+		log:        testutil.Logger(t),
+		config:     NewDefaultConfig(),
+	}
+
+	check, err := monitor.doCheck(context.Background())
+	require.NoError(t, err)
+	require.False(t, check.healthy)
+}
+
+func TestMonitorHealthyDuringRollout(t *testing.T) {
+	const serviceName = "test"
+
+	deployment := &ctypes.Deployment{
+		Lid: testutil.LeaseID(t),
+		MGroup: &manifest.Group{
+			Services: manifest.Services{{Name: serviceName, Count: 1}},
+		},
+	}
+
+	rollout := &ctypes.RolloutStatus{Strategy: ctypes.RolloutBlueGreen, Desired: 1}
+	failures := []ctypes.ServiceFailure{{Reason: ctypes.FailureCrashLoop, Pod: "test-new"}}
+
+	client := &mocks.Client{}
+	client.On("LeaseStatus", mock.Anything, deployment.LeaseID()).Return(map[string]*ctypes.ServiceStatus{
+		serviceName: {Name: serviceName, Available: 1, Total: 2, ReadyReplicas: 1, Rollout: rollout, Failures: failures},
+	}, nil)
+	client.On("ReportServiceFailure", mock.Anything, deployment.LeaseID(), serviceName, failures[0]).Return(nil).Once()
+
+	monitor := &deploymentMonitor{
+		client:     client,
+		deployment: deployment,
+		log:        testutil.Logger(t),
+		config:     NewDefaultConfig(),
+	}
+
+	// old replica keeps serving while the new one fails
+	check, err := monitor.doCheck(context.Background())
+	require.NoError(t, err)
+	require.True(t, check.healthy)
+	require.Equal(t, failures, check.failures)
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,84 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+const (
+	// PlacementPinningNone leaves placement to the kubernetes scheduler
+	PlacementPinningNone = "none"
+	// PlacementPinningPreferred prefers nodes inventory reserved resources on
+	PlacementPinningPreferred = "preferred"
+	// PlacementPinningRequired schedules replicas only onto nodes inventory reserved resources on.
+	// Deployment falls back to the scheduler choice once any of the nodes is gone
+	PlacementPinningRequired = "required"
+)
+
+var errInvalidPlacementPinning = errors.New("invalid placement pinning")
+
+// PlacementPinningModes lists values accepted by Config.InventoryPlacementPinning
+var PlacementPinningModes = []string{
+	PlacementPinningNone,
+	PlacementPinningPreferred,
+	PlacementPinningRequired,
+}
+
+func validatePlacementPinning(mode string) error {
+	switch mode {
+	case "", PlacementPinningNone, PlacementPinningPreferred, PlacementPinningRequired:
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", errInvalidPlacementPinning, mode)
+}
+
+// pinPlacement carries nodes inventory placed reservation on into its cluster params
+func pinPlacement(res *reservation, mode string) {
+	if mode == "" || mode == PlacementPinningNone {
+		return
+	}
+
+	cparams, valid := res.clusterParams.(crd.ReservationClusterSettings)
+	if !valid {
+		return
+	}
+
+	for id, nodes := range res.placement {
+		if len(nodes) == 0 {
+			continue
+		}
+
+		sparams := cparams[id]
+		if sparams == nil {
+			sparams = &crd.SchedulerParams{}
+			cparams[id] = sparams
+		}
+
+		sparams.Placement = &crd.SchedulerPlacement{
+			Nodes:    uniqueNodes(nodes),
+			Required: mode == PlacementPinningRequired,
+		}
+	}
+}
+
+func uniqueNodes(nodes []string) []string {
+	set := make(map[string]struct{}, len(nodes))
+	res := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		if _, exists := set[node]; exists {
+			continue
+		}
+
+		set[node] = struct{}{}
+		res = append(res, node)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,52 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func TestPinPlacement(t *testing.T) {
+	gpuParams := &crd.SchedulerParams{
+		Resources: &crd.SchedulerResources{
+			GPU: &crd.SchedulerResourceGPU{Vendor: "nvidia", Model: "a100"},
+		},
+	}
+
+	mkres := func() *reservation {
+		return &reservation{
+			clusterParams: crd.ReservationClusterSettings{
+				1: nil,
+				2: gpuParams.DeepCopy(),
+			},
+			placement: map[uint32][]string{
+				1: {"node2", "node1", "node2"},
+				2: {"node3"},
+			},
+		}
+	}
+
+	res := mkres()
+	pinPlacement(res, PlacementPinningNone)
+	require.Equal(t, mkres().clusterParams, res.clusterParams)
+
+	res = mkres()
+	pinPlacement(res, PlacementPinningRequired)
+
+	cparams := res.clusterParams.(crd.ReservationClusterSettings)
+	require.Equal(t, &crd.SchedulerPlacement{Nodes: []string{"node1", "node2"}, Required: true}, cparams[1].Placement)
+	require.Equal(t, &crd.SchedulerPlacement{Nodes: []string{"node3"}, Required: true}, cparams[2].Placement)
+	require.Equal(t, gpuParams.Resources, cparams[2].Resources)
+
+	res = mkres()
+	pinPlacement(res, PlacementPinningPreferred)
+
+	cparams = res.clusterParams.(crd.ReservationClusterSettings)
+	require.False(t, cparams[1].Placement.Required)
+
+	require.NoError(t, validatePlacementPinning(""))
+	require.NoError(t, validatePlacementPinning(PlacementPinningRequired))
+	require.ErrorIs(t, validatePlacementPinning("always"), errInvalidPlacementPinning)
+}
@@ -0,0 +1,209 @@
+package cluster
+
+import (
+	"context"
+
+	manifest "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	aclient "github.com/akash-network/akash-api/go/node/client/v1beta2"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	"github.com/akash-network/provider/event"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+	"github.com/akash-network/provider/session"
+)
+
+var (
+	inventoryPreemptions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "provider_inventory_preemptions",
+		Help: "preemptible reservations evicted to fit reservations of full price orders",
+	})
+)
+
+// adjustPreempting reserves resources of full price order on capacity of preemptible reservations it does not fit without.
+// Preemptible reservations are only held by the returned reservation, they are evicted once its lease is won
+func (is *inventoryService) adjustPreempting(
+	state *inventoryServiceState,
+	order mtypes.OrderID,
+	resources dtypes.ResourceGroup,
+) (*reservation, error) {
+	candidates := preemptionCandidates(state.reservations)
+	if len(candidates) == 0 || is.config.Preemptible.MatchesGroup(resources) {
+		return nil, ctypes.ErrInsufficientCapacity
+	}
+
+	return is.adjustReservation(state.inventory, order, resources, ctypes.WithPreemption(candidates...))
+}
+
+// preemptionCandidates returns preemptible reservations not yet held by another reservation,
+// most recent first so preemptible leases running the longest are evicted last
+func preemptionCandidates(reservations []*reservation) []ctypes.ReservationGroup {
+	held := make(map[ctypes.ReservationGroup]bool)
+	for _, r := range reservations {
+		for _, rg := range r.Preempted() {
+			held[rg] = true
+		}
+	}
+
+	var result []ctypes.ReservationGroup
+
+	for idx := len(reservations) - 1; idx >= 0; idx-- {
+		if reservations[idx].preemptible && !held[reservations[idx]] {
+			result = append(result, reservations[idx])
+		}
+	}
+
+	return result
+}
+
+// heldPreemptions returns held reservations which are still in the state
+func heldPreemptions(state *inventoryServiceState, held []ctypes.ReservationGroup) []ctypes.ReservationGroup {
+	var result []ctypes.ReservationGroup
+
+	for _, rg := range held {
+		for _, r := range state.reservations {
+			if rg == ctypes.ReservationGroup(r) {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// evictPreempted removes reservations held by res from the state and releases the hold
+func (is *inventoryService) evictPreempted(state *inventoryServiceState, res *reservation) []event.ReservationPreempted {
+	evicted := make(map[*reservation]bool, len(res.Preempted()))
+	for _, rg := range res.Preempted() {
+		if preempted, valid := rg.(*reservation); valid {
+			evicted[preempted] = true
+		}
+	}
+
+	res.SetPreempted(nil)
+
+	result := make([]event.ReservationPreempted, 0, len(evicted))
+	reservations := make([]*reservation, 0, len(state.reservations))
+
+	for _, r := range state.reservations {
+		if !evicted[r] {
+			reservations = append(reservations, r)
+			continue
+		}
+
+		if r.allocated {
+			is.availableExternalPorts += reservationCountEndpoints(r)
+		}
+
+		is.log.Info("preemptible reservation evicted", "order", r.OrderID(), "preempted-by", res.OrderID())
+		inventoryPreemptions.Inc()
+
+		result = append(result, event.ReservationPreempted{
+			OrderID:     r.OrderID(),
+			Group:       r.Resources().GetName(),
+			PreemptedBy: res.OrderID(),
+		})
+	}
+
+	state.reservations = reservations
+
+	return result
+}
+
+// setPreemptible marks scheduler params of every resource unit of preemptible reservation
+// so its workloads are built with lower priority, recording nodes its replicas are placed on
+func setPreemptible(res *reservation) {
+	if !res.preemptible {
+		return
+	}
+
+	cparams, valid := res.clusterParams.(crd.ReservationClusterSettings)
+	if !valid {
+		return
+	}
+
+	for _, ru := range res.resources.GetResourceUnits() {
+		sparams := cparams[ru.ID]
+		if sparams == nil {
+			sparams = &crd.SchedulerParams{}
+			cparams[ru.ID] = sparams
+		}
+
+		sparams.Preemptible = true
+
+		if nodes := res.placement[ru.ID]; len(nodes) != 0 {
+			if sparams.Placement == nil {
+				sparams.Placement = &crd.SchedulerPlacement{}
+			}
+
+			sparams.Placement.Replicas = append([]string(nil), nodes...)
+		}
+	}
+}
+
+// restorePreemptible restores placement and committed resources of preemptible deployment from its cluster params,
+// so reservation rebuilt from the deployment after restart can still be preempted
+func (is *inventoryService) restorePreemptible(res *reservation, mgroup *manifest.Group, cparams interface{}) {
+	res.preemptible = preemptibleParams(cparams)
+
+	settings, valid := cparams.(crd.ClusterSettings)
+	if !res.preemptible || !valid || len(settings.SchedulerParams) != len(mgroup.Services) {
+		return
+	}
+
+	placement := make(map[uint32][]string)
+
+	for idx, svc := range mgroup.Services {
+		sparams := settings.SchedulerParams[idx]
+		if sparams == nil {
+			continue
+		}
+
+		if sparams.Placement != nil {
+			placement[svc.Resources.ID] = append(placement[svc.Resources.ID], sparams.Placement.Replicas...)
+		}
+
+		if sparams.Commit != nil {
+			res.pool = sparams.Commit.Pool
+		}
+	}
+
+	res.SetPlacement(placement)
+	res.SetAllocatedResources(is.resourcesToCommit(mgroup, is.commitPool(res.pool)).GetResourceUnits())
+}
+
+// preemptibleParams reports whether cluster params of a deployment belong to preemptible lease
+func preemptibleParams(cparams interface{}) bool {
+	var sparams []*crd.SchedulerParams
+
+	switch cparams := cparams.(type) {
+	case crd.ClusterSettings:
+		sparams = cparams.SchedulerParams
+	case crd.ReservationClusterSettings:
+		for _, params := range cparams {
+			sparams = append(sparams, params)
+		}
+	}
+
+	for _, params := range sparams {
+		if params != nil && params.Preemptible {
+			return true
+		}
+	}
+
+	return false
+}
+
+// closeBid closes bid of the order on chain, closing its lease if one was created
+func closeBid(ctx context.Context, session session.Session, bid mtypes.BidID) (interface{}, error) {
+	msg := &mtypes.MsgCloseBid{
+		BidID: bid,
+	}
+
+	return session.Client().Tx().Broadcast(ctx, []sdk.Msg{msg}, aclient.WithResultCodeAsError())
+}
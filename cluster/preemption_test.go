@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func makeReservationForPreemptionTest(t *testing.T, preemptible bool) *reservation {
+	res := newReservation(testutil.OrderID(t), &dtypes.GroupSpec{
+		Name:      "group",
+		Resources: testutil.Resources(t),
+	})
+	res.preemptible = preemptible
+
+	return res
+}
+
+func TestPreemptionCandidates(t *testing.T) {
+	oldest := makeReservationForPreemptionTest(t, true)
+	full := makeReservationForPreemptionTest(t, false)
+	newest := makeReservationForPreemptionTest(t, true)
+
+	require.Equal(t, []ctypes.ReservationGroup{newest, oldest}, preemptionCandidates([]*reservation{oldest, full, newest}))
+	require.Empty(t, preemptionCandidates([]*reservation{full}))
+
+	full.SetPreempted([]ctypes.ReservationGroup{newest})
+	require.Equal(t, []ctypes.ReservationGroup{oldest}, preemptionCandidates([]*reservation{oldest, full, newest}))
+}
+
+func TestHeldPreemptions(t *testing.T) {
+	kept := makeReservationForPreemptionTest(t, true)
+	gone := makeReservationForPreemptionTest(t, true)
+
+	state := &inventoryServiceState{
+		reservations: []*reservation{kept},
+	}
+
+	require.Equal(t, []ctypes.ReservationGroup{kept}, heldPreemptions(state, []ctypes.ReservationGroup{gone, kept}))
+	require.Empty(t, heldPreemptions(state, nil))
+}
+
+func TestEvictPreempted(t *testing.T) {
+	is := &inventoryService{log: testutil.Logger(t)}
+
+	evicted := makeReservationForPreemptionTest(t, true)
+	kept := makeReservationForPreemptionTest(t, true)
+	res := makeReservationForPreemptionTest(t, false)
+	res.SetPreempted([]ctypes.ReservationGroup{evicted})
+
+	state := &inventoryServiceState{
+		reservations: []*reservation{evicted, kept, res},
+	}
+
+	events := is.evictPreempted(state, res)
+	require.Equal(t, []*reservation{kept, res}, state.reservations)
+	require.Len(t, events, 1)
+	require.Equal(t, evicted.OrderID(), events[0].OrderID)
+	require.Equal(t, "group", events[0].Group)
+	require.Equal(t, res.OrderID(), events[0].PreemptedBy)
+	require.Empty(t, res.Preempted())
+}
+
+func TestSetPreemptible(t *testing.T) {
+	res := makeReservationForPreemptionTest(t, false)
+	res.SetClusterParams(crd.ReservationClusterSettings{})
+
+	setPreemptible(res)
+	require.False(t, preemptibleParams(res.ClusterParams()))
+
+	res.preemptible = true
+	res.SetPlacement(map[uint32][]string{res.Resources().GetResourceUnits()[0].ID: {"nodeA", "nodeA"}})
+
+	setPreemptible(res)
+	require.True(t, preemptibleParams(res.ClusterParams()))
+	require.Equal(t, []string{"nodeA", "nodeA"},
+		res.ClusterParams().(crd.ReservationClusterSettings)[res.Resources().GetResourceUnits()[0].ID].Placement.Replicas)
+
+	for _, ru := range res.Resources().GetResourceUnits() {
+		require.True(t, res.ClusterParams().(crd.ReservationClusterSettings)[ru.ID].Preemptible)
+	}
+
+	require.True(t, preemptibleParams(crd.ClusterSettings{SchedulerParams: []*crd.SchedulerParams{nil, {Preemptible: true}}}))
+	require.False(t, preemptibleParams(nil))
+}
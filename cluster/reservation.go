@@ -17,16 +17,32 @@ func newReservation(order mtypes.OrderID, resources dtypes.ResourceGroup) *reser
 }
 
 type reservation struct {
-	order             mtypes.OrderID
-	resources         dtypes.ResourceGroup
+	order     mtypes.OrderID
+	resources dtypes.ResourceGroup
+	// requested are resources of the order before conversion to the committed amount
+	requested         dtypes.ResourceGroup
 	adjustedResources dtypes.ResourceUnits
 	clusterParams     interface{}
+	placement         map[uint32][]string
 	endpointQuantity  uint
 	allocated         bool
 	ipsConfirmed      bool
+	// pool is the commit pool resources were committed and adjusted with
+	pool string
+	// restoredBid is set on reservation loaded from the store until the order claims it back
+	restoredBid *mtypes.BidID
+	// preemptible reservation may be evicted for reservations of full price orders
+	preemptible bool
+	// preempted are reservations evicted to make this one fit
+	preempted []ctypes.ReservationGroup
+	// cluster is the cluster backend reservation was placed in when provider runs several clusters
+	cluster string
 }
 
 var _ ctypes.Reservation = (*reservation)(nil)
+var _ ctypes.ReservationPlacement = (*reservation)(nil)
+var _ ctypes.ReservationPreemption = (*reservation)(nil)
+var _ ctypes.ReservationCluster = (*reservation)(nil)
 
 func (r *reservation) OrderID() mtypes.OrderID {
 	return r.order
@@ -36,6 +52,16 @@ func (r *reservation) Resources() dtypes.ResourceGroup {
 	return r.resources
 }
 
+// requestedResources returns resources order requested. Reservations rebuilt from deployments
+// hold requested resources already
+func (r *reservation) requestedResources() dtypes.ResourceGroup {
+	if r.requested != nil {
+		return r.requested
+	}
+
+	return r.resources
+}
+
 func (r *reservation) SetAllocatedResources(val dtypes.ResourceUnits) {
 	r.adjustedResources = val
 }
@@ -52,6 +78,30 @@ func (r *reservation) ClusterParams() interface{} {
 	return r.clusterParams
 }
 
+func (r *reservation) SetPlacement(val map[uint32][]string) {
+	r.placement = val
+}
+
+func (r *reservation) Placement() map[uint32][]string {
+	return r.placement
+}
+
 func (r *reservation) Allocated() bool {
 	return r.allocated
 }
+
+func (r *reservation) SetPreempted(val []ctypes.ReservationGroup) {
+	r.preempted = val
+}
+
+func (r *reservation) Preempted() []ctypes.ReservationGroup {
+	return r.preempted
+}
+
+func (r *reservation) SetCluster(val string) {
+	r.cluster = val
+}
+
+func (r *reservation) Cluster() string {
+	return r.cluster
+}
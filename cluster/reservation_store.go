@@ -0,0 +1,342 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/tendermint/tendermint/libs/log"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	"github.com/akash-network/provider/session"
+)
+
+const (
+	reservationStateVersion = 1
+	bidsQueryPageLimit      = 1000
+)
+
+var errReservationStateVersion = errors.New("unsupported reservations state version")
+
+// reservationStoreDelay is how long store waits for more updates before writing the latest one
+var reservationStoreDelay = time.Second
+
+// reservationRecord is a pending reservation as stored in the state file.
+// Group holds resources already converted to the committed amount of the commit Pool,
+// Requested holds resources of the order as requested
+type reservationRecord struct {
+	Order     mtypes.OrderID    `json:"order"`
+	Group     dtypes.GroupSpec  `json:"group"`
+	Requested *dtypes.GroupSpec `json:"requested,omitempty"`
+	Pool      string            `json:"pool,omitempty"`
+	// Preemptible is set on reservations of orders opted into preemptible leases
+	Preemptible bool `json:"preemptible,omitempty"`
+}
+
+type reservationState struct {
+	Version      int                 `json:"version"`
+	Reservations []reservationRecord `json:"reservations"`
+}
+
+// reservationStore keeps pending reservations in a local file so they survive provider restarts.
+// Allocated reservations are not stored, they are rebuilt from deployments
+type reservationStore struct {
+	path     string
+	updatech chan []byte
+}
+
+func newReservationStore(path string) *reservationStore {
+	if path == "" {
+		return nil
+	}
+
+	return &reservationStore{
+		path:     path,
+		updatech: make(chan []byte, 1),
+	}
+}
+
+func (s *reservationStore) load() ([]reservationRecord, error) {
+	buf, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var state reservationState
+	if err = json.Unmarshal(buf, &state); err != nil {
+		return nil, err
+	}
+
+	if state.Version != reservationStateVersion {
+		return nil, errReservationStateVersion
+	}
+
+	return state.Reservations, nil
+}
+
+// save replaces stored state with pending reservations right away
+func (s *reservationStore) save(reservations []*reservation) error {
+	buf, err := encodeReservations(reservations)
+	if err != nil {
+		return err
+	}
+
+	return s.write(buf)
+}
+
+// update queues pending reservations to be written by run, replacing update not written yet.
+// Store is updated from inventory service only, so queueing never blocks
+func (s *reservationStore) update(reservations []*reservation) error {
+	buf, err := encodeReservations(reservations)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-s.updatech:
+	default:
+	}
+
+	s.updatech <- buf
+
+	return nil
+}
+
+// run writes queued updates until ctx is done. Updates queued within reservationStoreDelay are written once,
+// update pending on shutdown is written before run returns
+func (s *reservationStore) run(ctx context.Context, log log.Logger) {
+	var (
+		pending []byte
+		delayc  <-chan time.Time
+	)
+
+	write := func() {
+		if err := s.write(pending); err != nil {
+			log.Error("unable to persist reservations", "err", err)
+		}
+
+		pending = nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			select {
+			case pending = <-s.updatech:
+			default:
+			}
+
+			if pending != nil {
+				write()
+			}
+
+			return
+		case pending = <-s.updatech:
+			if delayc == nil {
+				delayc = time.After(reservationStoreDelay)
+			}
+		case <-delayc:
+			delayc = nil
+
+			write()
+		}
+	}
+}
+
+func encodeReservations(reservations []*reservation) ([]byte, error) {
+	state := reservationState{
+		Version:      reservationStateVersion,
+		Reservations: make([]reservationRecord, 0, len(reservations)),
+	}
+
+	for _, res := range reservations {
+		if res.allocated {
+			continue
+		}
+
+		state.Reservations = append(state.Reservations, reservationRecord{
+			Order: res.order,
+			Group: dtypes.GroupSpec{
+				Name:      res.resources.GetName(),
+				Resources: res.resources.GetResourceUnits(),
+			},
+			Requested:   requestedRecord(res),
+			Pool:        res.pool,
+			Preemptible: res.preemptible,
+		})
+	}
+
+	return json.Marshal(&state)
+}
+
+// write replaces stored state. File is written aside and renamed
+// so crash in the middle of the write does not leave truncated state behind
+func (s *reservationStore) write(buf []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(buf); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// providerBids returns orders provider holds bids on in given state
+func providerBids(ctx context.Context, session session.Session, state mtypes.Bid_State) (map[mtypes.OrderID]struct{}, error) {
+	res := make(map[mtypes.OrderID]struct{})
+
+	var nextKey []byte
+
+	for {
+		resp, err := session.Client().Query().Bids(ctx, &mtypes.QueryBidsRequest{
+			Filters: mtypes.BidFilters{
+				Provider: session.Provider().Owner,
+				State:    state.String(),
+			},
+			Pagination: &sdkquery.PageRequest{
+				Key:   nextKey,
+				Limit: bidsQueryPageLimit,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bid := range resp.Bids {
+			res[bid.Bid.BidID.OrderID()] = struct{}{}
+		}
+
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			break
+		}
+
+		nextKey = resp.Pagination.NextKey
+	}
+
+	return res, nil
+}
+
+// restoreReservations loads stored pending reservations and keeps ones provider still holds open
+// or active bid on and which are not backed by a deployment yet. Stale entries are dropped from the store.
+// When bids cannot be queried all stored reservations are restored and the store is left as is,
+// they are checked against bids again on the next start
+func restoreReservations(
+	ctx context.Context,
+	log log.Logger,
+	session session.Session,
+	store *reservationStore,
+	deployments []ctypes.IDeployment,
+) ([]*reservation, error) {
+	if store == nil {
+		return nil, nil
+	}
+
+	records, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	bids := make(map[mtypes.OrderID]struct{})
+	verified := true
+
+	for _, state := range []mtypes.Bid_State{mtypes.BidOpen, mtypes.BidActive} {
+		res, err := providerBids(ctx, session, state)
+		if err != nil {
+			log.Error("unable to query bids, restoring stored reservations unverified", "qty", len(records), "err", err)
+			verified = false
+			break
+		}
+
+		for order := range res {
+			bids[order] = struct{}{}
+		}
+	}
+
+	deployed := make(map[mtypes.OrderID]struct{}, len(deployments))
+	for _, d := range deployments {
+		deployed[d.LeaseID().OrderID()] = struct{}{}
+	}
+
+	reservations := make([]*reservation, 0, len(records))
+
+	for _, record := range records {
+		if _, exists := deployed[record.Order]; exists {
+			continue
+		}
+
+		if _, exists := bids[record.Order]; verified && !exists {
+			log.Info("dropping stale reservation", "order", record.Order)
+			continue
+		}
+
+		group := record.Group
+
+		res := newReservation(record.Order, &group)
+		res.pool = record.Pool
+		res.preemptible = record.Preemptible
+		if record.Requested != nil {
+			res.requested = record.Requested
+		}
+		bid := mtypes.MakeBidID(record.Order, session.Provider().Address())
+		res.restoredBid = &bid
+
+		reservations = append(reservations, res)
+	}
+
+	log.Info("restored reservations", "qty", len(reservations), "stale", len(records)-len(reservations))
+
+	// stored records are kept until bids confirm which of them are stale
+	if !verified {
+		return reservations, nil
+	}
+
+	if err = store.save(reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// requestedRecord returns resources order of the reservation requested, nil when they are the reserved ones
+func requestedRecord(res *reservation) *dtypes.GroupSpec {
+	if res.requested == nil {
+		return nil
+	}
+
+	return &dtypes.GroupSpec{
+		Name:      res.requested.GetName(),
+		Resources: res.requested.GetResourceUnits(),
+	}
+}
@@ -0,0 +1,228 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	clientmocks "github.com/akash-network/akash-api/go/node/client/v1beta2/mocks"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	"github.com/akash-network/provider/session"
+)
+
+func makeReservationForStoreTest(t *testing.T, allocated bool) *reservation {
+	res := newReservation(testutil.OrderID(t), &dtypes.GroupSpec{
+		Name:      "group",
+		Resources: testutil.Resources(t),
+	})
+	res.allocated = allocated
+
+	return res
+}
+
+func TestReservationStore_SaveLoad(t *testing.T) {
+	store := newReservationStore(filepath.Join(t.TempDir(), "reservations.json"))
+
+	records, err := store.load()
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	pending := makeReservationForStoreTest(t, false)
+	pending.pool = "burstable"
+	pending.preemptible = true
+	pending.requested = &dtypes.GroupSpec{Name: "group", Resources: testutil.Resources(t)}
+	allocated := makeReservationForStoreTest(t, true)
+
+	require.NoError(t, store.save([]*reservation{pending, allocated}))
+
+	records, err = store.load()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, pending.OrderID(), records[0].Order)
+	require.Equal(t, "group", records[0].Group.GetName())
+	require.Equal(t, pending.Resources().GetResourceUnits(), records[0].Group.GetResourceUnits())
+	require.Equal(t, "burstable", records[0].Pool)
+	require.True(t, records[0].Preemptible)
+	require.Equal(t, pending.requested.GetResourceUnits(), records[0].Requested.GetResourceUnits())
+
+	require.Nil(t, newReservationStore(""))
+}
+
+func TestReservationStore_Restore(t *testing.T) {
+	store := newReservationStore(filepath.Join(t.TempDir(), "reservations.json"))
+
+	open := makeReservationForStoreTest(t, false)
+	stale := makeReservationForStoreTest(t, false)
+	deployed := makeReservationForStoreTest(t, false)
+
+	require.NoError(t, store.save([]*reservation{open, stale, deployed}))
+
+	provider := &ptypes.Provider{Owner: testutil.AccAddress(t).String()}
+
+	bidsResponse := func(orders ...mtypes.OrderID) *mtypes.QueryBidsResponse {
+		resp := &mtypes.QueryBidsResponse{}
+		for _, order := range orders {
+			resp.Bids = append(resp.Bids, mtypes.QueryBidResponse{
+				Bid: mtypes.Bid{BidID: mtypes.MakeBidID(order, provider.Address())},
+			})
+		}
+
+		return resp
+	}
+
+	bidState := func(state mtypes.Bid_State) interface{} {
+		return mock.MatchedBy(func(req *mtypes.QueryBidsRequest) bool {
+			return req.Filters.State == state.String() && req.Filters.Provider == provider.Owner
+		})
+	}
+
+	queryClient := &clientmocks.QueryClient{}
+	queryClient.On("Bids", mock.Anything, bidState(mtypes.BidOpen)).Return(bidsResponse(open.OrderID()), nil)
+	queryClient.On("Bids", mock.Anything, bidState(mtypes.BidActive)).Return(bidsResponse(deployed.OrderID()), nil)
+
+	client := &clientmocks.Client{}
+	client.On("Query").Return(queryClient)
+
+	mySession := session.New(testutil.Logger(t), client, provider, -1)
+
+	deployment := &ctypes.Deployment{Lid: mtypes.MakeLeaseID(mtypes.MakeBidID(deployed.OrderID(), provider.Address()))}
+
+	restored, err := restoreReservations(context.Background(), testutil.Logger(t), mySession, store, []ctypes.IDeployment{deployment})
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.Equal(t, open.OrderID(), restored[0].OrderID())
+	require.NotNil(t, restored[0].restoredBid)
+	require.Equal(t, mtypes.MakeBidID(open.OrderID(), provider.Address()), *restored[0].restoredBid)
+
+	// stale entries are garbage collected from the store
+	records, err := store.load()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, open.OrderID(), records[0].Order)
+}
+
+func TestReservationStore_RestoreBidsQueryFails(t *testing.T) {
+	store := newReservationStore(filepath.Join(t.TempDir(), "reservations.json"))
+
+	pending := makeReservationForStoreTest(t, false)
+	deployed := makeReservationForStoreTest(t, false)
+	require.NoError(t, store.save([]*reservation{pending, deployed}))
+
+	provider := &ptypes.Provider{Owner: testutil.AccAddress(t).String()}
+
+	queryClient := &clientmocks.QueryClient{}
+	queryClient.On("Bids", mock.Anything, mock.Anything).Return(nil, errors.New("node unavailable"))
+
+	client := &clientmocks.Client{}
+	client.On("Query").Return(queryClient)
+
+	mySession := session.New(testutil.Logger(t), client, provider, -1)
+
+	deployment := &ctypes.Deployment{Lid: mtypes.MakeLeaseID(mtypes.MakeBidID(deployed.OrderID(), provider.Address()))}
+
+	restored, err := restoreReservations(context.Background(), testutil.Logger(t), mySession, store, []ctypes.IDeployment{deployment})
+	require.NoError(t, err)
+	require.Len(t, restored, 1)
+	require.Equal(t, pending.OrderID(), restored[0].OrderID())
+	require.Equal(t, mtypes.MakeBidID(pending.OrderID(), provider.Address()), *restored[0].restoredBid)
+
+	// records survive until bids can be checked
+	records, err := store.load()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+}
+
+func TestReservationStore_Update(t *testing.T) {
+	store := newReservationStore(filepath.Join(t.TempDir(), "reservations.json"))
+
+	first := makeReservationForStoreTest(t, false)
+	second := makeReservationForStoreTest(t, false)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	donech := make(chan struct{})
+
+	go func() {
+		defer close(donech)
+		store.run(ctx, testutil.Logger(t))
+	}()
+
+	// updates are written in background
+	require.NoError(t, store.update([]*reservation{first}))
+	require.Eventually(t, func() bool {
+		records, err := store.load()
+		return err == nil && len(records) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// update pending on shutdown is written before run returns
+	require.NoError(t, store.update([]*reservation{first, second}))
+	cancel()
+	<-donech
+
+	records, err := store.load()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+}
+
+func TestInventory_RestoredReservations(t *testing.T) {
+	store := newReservationStore(filepath.Join(t.TempDir(), "reservations.json"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go store.run(ctx, testutil.Logger(t))
+
+	is := &inventoryService{
+		log:   testutil.Logger(t),
+		store: store,
+	}
+
+	claimed := makeReservationForStoreTest(t, false)
+	claimedBid := mtypes.MakeBidID(claimed.OrderID(), testutil.AccAddress(t))
+	claimed.restoredBid = &claimedBid
+
+	unclaimed := makeReservationForStoreTest(t, false)
+	unclaimedBid := mtypes.MakeBidID(unclaimed.OrderID(), testutil.AccAddress(t))
+	unclaimed.restoredBid = &unclaimedBid
+
+	state := &inventoryServiceState{
+		reservations: []*reservation{claimed, unclaimed},
+	}
+
+	ch := make(chan inventoryResponse, 1)
+	require.True(t, is.claimRestoredReservation(inventoryRequest{
+		order:     claimed.OrderID(),
+		resources: claimed.Resources(),
+		ch:        ch,
+	}, state))
+
+	resp := <-ch
+	require.NoError(t, resp.err)
+	require.Same(t, claimed, resp.value)
+	require.Nil(t, claimed.restoredBid)
+
+	// order claiming a group it has no reservation for gets a new one
+	require.False(t, is.claimRestoredReservation(inventoryRequest{
+		order:     unclaimed.OrderID(),
+		resources: &dtypes.GroupSpec{Name: "other"},
+		ch:        ch,
+	}, state))
+
+	// claimed reservation is managed by its order and is never dropped
+	is.dropRestoredReservations(state, func(mtypes.BidID) bool { return true })
+	require.Equal(t, []*reservation{claimed}, state.reservations)
+
+	require.Eventually(t, func() bool {
+		records, err := store.load()
+		return err == nil && len(records) == 1 && records[0].Order.Equals(claimed.OrderID())
+	}, 5*time.Second, 50*time.Millisecond)
+}
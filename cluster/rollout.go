@@ -0,0 +1,107 @@
+package cluster
+
+import (
+	manifest "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+// rolloutFor decides rollout strategy of reservation against inventory at the time its manifest is received.
+// Surge policy is kept only when inventory has spare capacity for one more replica of every service of the reservation
+func (is *inventoryService) rolloutFor(inv ctypes.Inventory, res *reservation) ctypes.RolloutStrategy {
+	if is.config.RolloutPolicy != ctypes.RolloutSurge {
+		return is.config.RolloutPolicy
+	}
+
+	if inv == nil {
+		return ctypes.RolloutRolling
+	}
+
+	units := res.resources.GetResourceUnits()
+	surge := make(dtypes.ResourceUnits, 0, len(units))
+
+	for _, ru := range units {
+		surge = append(surge, dtypes.ResourceUnit{
+			Resources: ru.Resources.Dup(),
+			Count:     1,
+		})
+	}
+
+	spare := newReservation(res.order, dtypes.GroupSpec{
+		Name:      res.resources.GetName(),
+		Resources: surge,
+	})
+
+	err := inv.Adjust(spare, append(is.adjustOptions(res.pool), ctypes.WithDryRun())...)
+	if err != nil {
+		is.log.Debug("no spare capacity to surge rollout, falling back to rolling update", "order", res.order)
+		return ctypes.RolloutRolling
+	}
+
+	return ctypes.RolloutSurge
+}
+
+// setRollout records rollout strategy into cluster params of the reservation, replacing the one decided
+// for previous manifest. Params are copied as deployment of previous manifest may still be reading them.
+// Services of single replica surging roll out blue/green. Nothing is recorded for rolling update, builder defaults to it
+func setRollout(res *reservation, strategy ctypes.RolloutStrategy) {
+	if strategy == ctypes.RolloutRolling {
+		strategy = ""
+	}
+
+	rollout := func(sparams *crd.SchedulerParams, count uint32) *crd.SchedulerParams {
+		if sparams == nil {
+			if strategy == "" {
+				return nil
+			}
+
+			sparams = &crd.SchedulerParams{}
+		}
+
+		sparams.Rollout = serviceRollout(strategy, count)
+
+		return sparams
+	}
+
+	switch cparams := res.clusterParams.(type) {
+	case crd.ReservationClusterSettings:
+		result := make(crd.ReservationClusterSettings, len(cparams))
+		for id, sparams := range cparams {
+			result[id] = sparams.DeepCopy()
+		}
+
+		for _, ru := range res.resources.GetResourceUnits() {
+			if sparams := rollout(result[ru.ID], ru.Count); sparams != nil {
+				result[ru.ID] = sparams
+			}
+		}
+
+		res.clusterParams = result
+	case crd.ClusterSettings:
+		// reservation rebuilt from deployment holds params of every service of its manifest group
+		mgroup, valid := res.resources.(*manifest.Group)
+		if !valid || len(mgroup.Services) != len(cparams.SchedulerParams) {
+			return
+		}
+
+		result := crd.ClusterSettings{
+			SchedulerParams: make([]*crd.SchedulerParams, len(cparams.SchedulerParams)),
+		}
+
+		for idx, svc := range mgroup.Services {
+			result.SchedulerParams[idx] = rollout(cparams.SchedulerParams[idx].DeepCopy(), svc.Count)
+		}
+
+		res.clusterParams = result
+	}
+}
+
+func serviceRollout(strategy ctypes.RolloutStrategy, count uint32) ctypes.RolloutStrategy {
+	if strategy == ctypes.RolloutSurge && count == 1 {
+		return ctypes.RolloutBlueGreen
+	}
+
+	return strategy
+}
@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	manifest "github.com/akash-network/akash-api/go/manifest/v2beta2"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/node/testutil"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+)
+
+func rolloutOf(t *testing.T, res *reservation) ctypes.RolloutStrategy {
+	t.Helper()
+
+	sparams := res.ClusterParams().(crd.ReservationClusterSettings)[1]
+	if sparams == nil {
+		return ""
+	}
+
+	return sparams.Rollout
+}
+
+func adjustRollout(t *testing.T, is *inventoryService, inv ctypes.Inventory, group dtypes.ResourceGroup) *reservation {
+	t.Helper()
+
+	res, err := is.adjustReservation(inv, testutil.OrderID(t), group)
+	require.NoError(t, err)
+
+	setRollout(res, is.rolloutFor(inv, res))
+
+	return res
+}
+
+func TestInventory_RolloutSurge(t *testing.T) {
+	is := &inventoryService{
+		log:    testutil.Logger(t),
+		pools:  Config{}.commitPools(),
+		config: Config{RolloutPolicy: ctypes.RolloutSurge},
+	}
+
+	// node has 4900m available
+	inv := <-cinventory.NewNull(context.Background(), "nodeA").ResultChan()
+
+	// 2900m left, enough to start single replica alongside the old one
+	first := adjustRollout(t, is, inv, commitTestGroup(2000, ""))
+	require.Equal(t, ctypes.RolloutBlueGreen, rolloutOf(t, first))
+
+	// 900m left, no room for surge replica
+	res := adjustRollout(t, is, inv, commitTestGroup(2000, ""))
+	require.Empty(t, rolloutOf(t, res))
+
+	// strategy is decided again with capacity left when next manifest is received
+	params := first.ClusterParams()
+	setRollout(first, is.rolloutFor(inv, first))
+	require.Empty(t, rolloutOf(t, first))
+	require.Equal(t, ctypes.RolloutBlueGreen, params.(crd.ReservationClusterSettings)[1].Rollout)
+
+	inv = <-cinventory.NewNull(context.Background(), "nodeA").ResultChan()
+
+	group := commitTestGroup(1000, "")
+	group.Resources[0].Count = 2
+
+	res = adjustRollout(t, is, inv, group)
+	require.Equal(t, ctypes.RolloutSurge, rolloutOf(t, res))
+
+	// reservation rebuilt from deployment
+	mgroup := &manifest.Group{
+		Name:     "group",
+		Services: manifest.Services{{Name: "web", Count: 1}, {Name: "db", Count: 2}},
+	}
+
+	res = newReservation(testutil.OrderID(t), mgroup)
+	res.SetClusterParams(crd.ClusterSettings{SchedulerParams: []*crd.SchedulerParams{nil, {RuntimeClass: "gvisor"}}})
+
+	setRollout(res, ctypes.RolloutSurge)
+	require.Equal(t, ctypes.RolloutBlueGreen, res.ClusterParams().(crd.ClusterSettings).SchedulerParams[0].Rollout)
+	require.Equal(t, ctypes.RolloutSurge, res.ClusterParams().(crd.ClusterSettings).SchedulerParams[1].Rollout)
+	require.Equal(t, "gvisor", res.ClusterParams().(crd.ClusterSettings).SchedulerParams[1].RuntimeClass)
+}
+
+func TestInventory_RolloutPolicy(t *testing.T) {
+	is := &inventoryService{
+		log:    testutil.Logger(t),
+		pools:  Config{}.commitPools(),
+		config: Config{RolloutPolicy: ctypes.RolloutRecreate},
+	}
+
+	inv := <-cinventory.NewNull(context.Background(), "nodeA").ResultChan()
+
+	res := adjustRollout(t, is, inv, commitTestGroup(1000, ""))
+	require.Equal(t, ctypes.RolloutRecreate, rolloutOf(t, res))
+
+	is.config.RolloutPolicy = ctypes.RolloutRolling
+
+	res = adjustRollout(t, is, inv, commitTestGroup(1000, ""))
+	require.Empty(t, rolloutOf(t, res))
+
+	_, err := ctypes.ParseRolloutPolicy("bluegreen")
+	require.ErrorIs(t, err, ctypes.ErrRolloutPolicyInvalid)
+}
@@ -76,12 +76,14 @@ type checkDeploymentExistsRequest struct {
 	responseCh chan<- mtypes.LeaseID
 }
 
-// Cluster is the interface that wraps Reserve and Unreserve methods
+// Cluster is the interface that wraps Reserve, ReserveDryRun, Unreserve and OwnerUsage methods
 //
 //go:generate mockery --name Cluster
 type Cluster interface {
 	Reserve(mtypes.OrderID, dtypes.ResourceGroup) (ctypes.Reservation, error)
+	ReserveDryRun(mtypes.OrderID, dtypes.ResourceGroup) (ctypes.Reservation, error)
 	Unreserve(mtypes.OrderID) error
+	OwnerUsage(ctx context.Context, owner string) (ctypes.OwnerUsage, error)
 }
 
 // StatusClient is the interface which includes status of service
@@ -128,7 +130,14 @@ func NewService(
 		return nil, err
 	}
 
-	inventory, err := newInventoryService(ctx, cfg, log, sub, client, waiter, deployments)
+	restored, err := restoreReservations(ctx, log, session, newReservationStore(cfg.ReservationsStatePath), deployments)
+	if err != nil {
+		log.Error("restoring reservations", "err", err)
+		sub.Close()
+		return nil, err
+	}
+
+	inventory, err := newInventoryService(ctx, cfg, log, sub, client, waiter, deployments, restored)
 	if err != nil {
 		sub.Close()
 		return nil, err
@@ -231,10 +240,18 @@ func (s *service) Reserve(order mtypes.OrderID, resources dtypes.ResourceGroup)
 	return s.inventory.reserve(order, resources)
 }
 
+func (s *service) ReserveDryRun(order mtypes.OrderID, resources dtypes.ResourceGroup) (ctypes.Reservation, error) {
+	return s.inventory.reserveDryRun(order, resources)
+}
+
 func (s *service) Unreserve(order mtypes.OrderID) error {
 	return s.inventory.unreserve(order)
 }
 
+func (s *service) OwnerUsage(ctx context.Context, owner string) (ctypes.OwnerUsage, error) {
+	return s.inventory.ownerUsage(ctx, owner)
+}
+
 func (s *service) HostnameService() ctypes.HostnameServiceClient {
 	return s.hostnames
 }
@@ -249,6 +266,16 @@ func (s *service) Status(ctx context.Context) (*ctypes.Status, error) {
 		return nil, err
 	}
 
+	headroom, err := s.inventory.headroomStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.inventory.capacityForecast(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	ch := make(chan *ctypes.Status, 1)
 
 	select {
@@ -266,6 +293,10 @@ func (s *service) Status(ctx context.Context) (*ctypes.Status, error) {
 		return nil, ctx.Err()
 	case result := <-ch:
 		result.Inventory = istatus
+		result.Headroom = headroom
+		result.Forecast = forecast
+		result.Maintenance = s.inventory.maintenance()
+		result.Clusters = s.backendsStatus(ctx)
 		return result, nil
 	}
 }
@@ -323,6 +354,7 @@ func (s *service) run(ctx context.Context, deployments []ctypes.IDeployment) {
 	bus := fromctx.MustPubSubFromCtx(ctx)
 
 	inventorych := bus.Sub(ptypes.PubSubTopicInventoryStatus)
+	preemptedch := bus.Sub(ptypes.PubSubTopicReservationPreempted)
 
 	for _, deployment := range deployments {
 		s.managers[deployment.LeaseID()] = newDeploymentManager(s, deployment, false)
@@ -415,11 +447,18 @@ loop:
 				Inventory: *inv,
 			}
 			bus.Pub(msg, []string{ptypes.PubSubTopicClusterStatus}, tpubsub.WithRetain())
+		case ev := <-preemptedch:
+			preempted, valid := ev.(event.ReservationPreempted)
+			if !valid {
+				continue
+			}
+
+			s.preemptLease(ctx, preempted)
 		case dm := <-s.managerch:
 			s.log.Info("manager done", "lease", dm.deployment.LeaseID())
 
-			// unreserve resources
-			if err := s.inventory.unreserve(dm.deployment.LeaseID().OrderID()); err != nil {
+			// unreserve resources, reservation of preempted lease is gone already
+			if err := s.inventory.unreserve(dm.deployment.LeaseID().OrderID()); err != nil && !errors.Is(err, errReservationNotFound) {
 				s.log.Error("unreserving inventory",
 					"err", err,
 					"lease", dm.deployment.LeaseID())
@@ -475,6 +514,27 @@ func (s *service) teardownLease(lid mtypes.LeaseID) {
 	}
 }
 
+// preemptLease closes lease of evicted preemptible reservation. Deployment manager of the lease
+// tears its workloads down, bid of the order not deployed yet is closed right away
+func (s *service) preemptLease(ctx context.Context, ev event.ReservationPreempted) {
+	lid := mtypes.MakeLeaseID(mtypes.MakeBidID(ev.OrderID, s.session.Provider().Address()))
+
+	if manager := s.managers[lid]; manager != nil {
+		if err := manager.preempt(ev); err != nil {
+			s.log.Error("preempting lease deployment", "err", err, "lease", lid)
+		}
+		return
+	}
+
+	s.log.Info("closing bid of preempted reservation", "order", ev.OrderID, "preempted-by", ev.PreemptedBy)
+
+	go func() {
+		if _, err := closeBid(ctx, s.session, lid.BidID()); err != nil {
+			s.log.Error("closing bid of preempted reservation", "err", err, "order", ev.OrderID)
+		}
+	}()
+}
+
 func findDeployments(
 	ctx context.Context,
 	log log.Logger,
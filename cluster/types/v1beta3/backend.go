@@ -0,0 +1,23 @@
+package v1beta3
+
+import (
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+)
+
+// ClusterBackendStatus is status of one of the clusters provider deploys leases into
+type ClusterBackendStatus struct {
+	Name string `json:"name"`
+	// Leases is the number of leases known to be deployed into the cluster
+	Leases uint32 `json:"leases"`
+	// Version of kubernetes running in the cluster, empty when cluster is unreachable
+	Version string `json:"version,omitempty"`
+	// Error reaching the cluster
+	Error     string               `json:"error,omitempty"`
+	Inventory *inventoryV1.Metrics `json:"inventory,omitempty"`
+}
+
+// InventoryBackends is implemented by inventory aggregated from several clusters
+type InventoryBackends interface {
+	// Backends returns inventory of each cluster keyed by cluster name
+	Backends() map[string]Inventory
+}
@@ -0,0 +1,119 @@
+package inventory
+
+import (
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+// headroomUsage marks resources reservation takes, headroom of the others is not checked
+// so node or cluster already short on one resource still takes workloads not using it
+type headroomUsage struct {
+	cpu              bool
+	gpu              bool
+	memory           bool
+	storageEphemeral bool
+}
+
+func (u *headroomUsage) add(res *types.Resources) {
+	u.cpu = u.cpu || (res.CPU != nil && res.CPU.Units.Value() > 0)
+	u.gpu = u.gpu || (res.GPU != nil && res.GPU.Units.Value() > 0)
+	u.memory = u.memory || (res.Memory != nil && res.Memory.Quantity.Value() > 0)
+
+	for _, storage := range res.Storage {
+		attrs, err := ParseStorageAttributes(storage.Attributes)
+		if err != nil || attrs.Persistent {
+			continue
+		}
+
+		if attrs.Class == sdl.StorageClassRAM {
+			u.memory = true
+		} else {
+			u.storageEphemeral = true
+		}
+	}
+}
+
+func (u headroomUsage) keeps(reserve ctypes.ResourceHeadroom, available ctypes.ResourceHeadroom) bool {
+	if u.cpu && available.CPU < reserve.CPU {
+		return false
+	}
+
+	if u.gpu && available.GPU < reserve.GPU {
+		return false
+	}
+
+	if u.memory && available.Memory < reserve.Memory {
+		return false
+	}
+
+	if u.storageEphemeral && available.StorageEphemeral < reserve.StorageEphemeral {
+		return false
+	}
+
+	return true
+}
+
+// NodeKeepsHeadroom checks node already adjusted for the resources still has its headroom free
+func NodeKeepsHeadroom(headroom ctypes.Headroom, nd *inventoryV1.Node, res *types.Resources) bool {
+	if headroom == nil {
+		return true
+	}
+
+	usage := headroomUsage{}
+	usage.add(res)
+
+	return usage.keeps(headroom.Node(nd), NodeAvailable(nd))
+}
+
+// ClusterKeepsHeadroom checks cluster already adjusted for the resources still has its headroom free
+func ClusterKeepsHeadroom(headroom ctypes.Headroom, metrics inventoryV1.Metrics, resources dtypes.ResourceUnits) bool {
+	if headroom == nil {
+		return true
+	}
+
+	usage := headroomUsage{}
+	for idx := range resources {
+		usage.add(&resources[idx].Resources)
+	}
+
+	available := ctypes.ResourceHeadroom{
+		CPU:              metrics.TotalAvailable.CPU,
+		GPU:              metrics.TotalAvailable.GPU,
+		Memory:           metrics.TotalAvailable.Memory,
+		StorageEphemeral: metrics.TotalAvailable.StorageEphemeral,
+	}
+
+	return usage.keeps(headroom.Cluster(metrics.TotalAllocatable), available)
+}
+
+// NodeAllocatable returns allocatable capacity of the node
+func NodeAllocatable(nd *inventoryV1.Node) ctypes.ResourceHeadroom {
+	return ctypes.ResourceHeadroom{
+		CPU:              nonNegative(nd.Resources.CPU.Quantity.Allocatable.MilliValue()),
+		GPU:              nonNegative(nd.Resources.GPU.Quantity.Allocatable.Value()),
+		Memory:           nonNegative(nd.Resources.Memory.Quantity.Allocatable.Value()),
+		StorageEphemeral: nonNegative(nd.Resources.EphemeralStorage.Allocatable.Value()),
+	}
+}
+
+// NodeAvailable returns capacity of the node not allocated yet
+func NodeAvailable(nd *inventoryV1.Node) ctypes.ResourceHeadroom {
+	return ctypes.ResourceHeadroom{
+		CPU:              nonNegative(nd.Resources.CPU.Quantity.Available().MilliValue()),
+		GPU:              nonNegative(nd.Resources.GPU.Quantity.Available().Value()),
+		Memory:           nonNegative(nd.Resources.Memory.Quantity.Available().Value()),
+		StorageEphemeral: nonNegative(nd.Resources.EphemeralStorage.Available().Value()),
+	}
+}
+
+func nonNegative(val int64) uint64 {
+	if val < 0 {
+		return 0
+	}
+
+	return uint64(val)
+}
@@ -0,0 +1,220 @@
+package inventory
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+	"golang.org/x/sync/errgroup"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+type backendUpdate struct {
+	name string
+	inv  ctypes.Inventory
+}
+
+type multiClient struct {
+	ctx     context.Context
+	group   *errgroup.Group
+	subch   chan chan ctypes.Inventory
+	clients map[string]Client
+}
+
+// multiInventory is inventory of several clusters. Node names are expected to be unique across clusters
+type multiInventory struct {
+	names    []string
+	backends map[string]ctypes.Inventory
+}
+
+var (
+	_ Client                   = (*multiClient)(nil)
+	_ ctypes.Inventory         = (*multiInventory)(nil)
+	_ ctypes.InventoryBackends = (*multiInventory)(nil)
+)
+
+// NewMultiClient aggregates inventory of several clusters keyed by cluster name.
+// Inventory is published once any of the clusters reports it, clusters not reported yet are left out
+func NewMultiClient(ctx context.Context, clients map[string]Client) Client {
+	group, ctx := errgroup.WithContext(ctx)
+
+	cl := &multiClient{
+		ctx:     ctx,
+		group:   group,
+		subch:   make(chan chan ctypes.Inventory, 1),
+		clients: clients,
+	}
+
+	group.Go(cl.run)
+
+	return cl
+}
+
+func (cl *multiClient) ResultChan() <-chan ctypes.Inventory {
+	ch := make(chan ctypes.Inventory, 1)
+
+	select {
+	case <-cl.ctx.Done():
+		close(ch)
+	case cl.subch <- ch:
+	}
+
+	return ch
+}
+
+func (cl *multiClient) run() error {
+	updatech := make(chan backendUpdate)
+
+	for name, client := range cl.clients {
+		name := name
+		resultch := client.ResultChan()
+
+		cl.group.Go(func() error {
+			for inv := range resultch {
+				select {
+				case <-cl.ctx.Done():
+					return cl.ctx.Err()
+				case updatech <- backendUpdate{name: name, inv: inv}:
+				}
+			}
+
+			return nil
+		})
+	}
+
+	latest := make(map[string]ctypes.Inventory, len(cl.clients))
+
+	var subs []chan ctypes.Inventory
+
+	defer func() {
+		for _, sub := range subs {
+			close(sub)
+		}
+	}()
+
+	for {
+		select {
+		case <-cl.ctx.Done():
+			return cl.ctx.Err()
+		case upd := <-updatech:
+			latest[upd.name] = upd.inv
+
+			for _, sub := range subs {
+				publishInventory(sub, newMultiInventory(latest))
+			}
+		case sub := <-cl.subch:
+			subs = append(subs, sub)
+
+			if len(latest) > 0 {
+				publishInventory(sub, newMultiInventory(latest))
+			}
+		}
+	}
+}
+
+// publishInventory replaces inventory subscriber has not read yet.
+// Subscriber channels are buffered and written by run loop only, so send never blocks
+func publishInventory(sub chan ctypes.Inventory, inv ctypes.Inventory) {
+	select {
+	case <-sub:
+	default:
+	}
+
+	sub <- inv
+}
+
+func newMultiInventory(backends map[string]ctypes.Inventory) *multiInventory {
+	inv := &multiInventory{
+		names:    make([]string, 0, len(backends)),
+		backends: make(map[string]ctypes.Inventory, len(backends)),
+	}
+
+	for name, backend := range backends {
+		inv.names = append(inv.names, name)
+		inv.backends[name] = backend.Dup()
+	}
+
+	sort.Strings(inv.names)
+
+	return inv
+}
+
+// Adjust reserves resources in the first cluster, by name, they fit in.
+// Reservation already placed in a cluster is adjusted in that cluster only
+func (inv *multiInventory) Adjust(res ctypes.ReservationGroup, opts ...ctypes.InventoryOption) error {
+	rc, _ := res.(ctypes.ReservationCluster)
+
+	names := inv.names
+	if rc != nil && rc.Cluster() != "" {
+		if _, exists := inv.backends[rc.Cluster()]; exists {
+			names = []string{rc.Cluster()}
+		}
+	}
+
+	err := ctypes.ErrInsufficientCapacity
+
+	for _, name := range names {
+		if err = inv.backends[name].Adjust(res, opts...); err != nil {
+			continue
+		}
+
+		if rc != nil {
+			rc.SetCluster(name)
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+func (inv *multiInventory) Metrics() inventoryV1.Metrics {
+	result := inventoryV1.Metrics{
+		TotalAllocatable: inventoryV1.MetricTotal{Storage: make(map[string]int64)},
+		TotalAvailable:   inventoryV1.MetricTotal{Storage: make(map[string]int64)},
+	}
+
+	for _, name := range inv.names {
+		metrics := inv.backends[name].Metrics()
+
+		result.Nodes = append(result.Nodes, metrics.Nodes...)
+		addMetricTotal(&result.TotalAllocatable, metrics.TotalAllocatable)
+		addMetricTotal(&result.TotalAvailable, metrics.TotalAvailable)
+	}
+
+	return result
+}
+
+func (inv *multiInventory) Snapshot() inventoryV1.Cluster {
+	result := inventoryV1.Cluster{}
+
+	for _, name := range inv.names {
+		snapshot := inv.backends[name].Snapshot()
+
+		result.Nodes = append(result.Nodes, snapshot.Nodes...)
+		result.Storage = append(result.Storage, snapshot.Storage...)
+	}
+
+	return result
+}
+
+func (inv *multiInventory) Dup() ctypes.Inventory {
+	return newMultiInventory(inv.backends)
+}
+
+func (inv *multiInventory) Backends() map[string]ctypes.Inventory {
+	return inv.backends
+}
+
+func addMetricTotal(total *inventoryV1.MetricTotal, val inventoryV1.MetricTotal) {
+	total.CPU += val.CPU
+	total.GPU += val.GPU
+	total.Memory += val.Memory
+	total.StorageEphemeral += val.StorageEphemeral
+
+	for class, size := range val.Storage {
+		total.Storage[class] += size
+	}
+}
@@ -0,0 +1,73 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+type multiTestReservation struct {
+	resources dtypes.ResourceGroup
+	allocated dtypes.ResourceUnits
+	params    interface{}
+	cluster   string
+}
+
+var _ ctypes.ReservationCluster = (*multiTestReservation)(nil)
+
+func (r *multiTestReservation) Resources() dtypes.ResourceGroup                { return r.resources }
+func (r *multiTestReservation) SetAllocatedResources(val dtypes.ResourceUnits) { r.allocated = val }
+func (r *multiTestReservation) GetAllocatedResources() dtypes.ResourceUnits    { return r.allocated }
+func (r *multiTestReservation) SetClusterParams(val interface{})               { r.params = val }
+func (r *multiTestReservation) ClusterParams() interface{}                     { return r.params }
+func (r *multiTestReservation) SetCluster(val string)                          { r.cluster = val }
+func (r *multiTestReservation) Cluster() string                                { return r.cluster }
+
+func multiTestInventory() *multiInventory {
+	return newMultiInventory(map[string]ctypes.Inventory{
+		"busy": newInventory(inventoryV1.Cluster{
+			Nodes: inventoryV1.Nodes{placementTestNode("busy-node", 9500, 0)},
+		}),
+		"idle": newInventory(inventoryV1.Cluster{
+			Nodes: inventoryV1.Nodes{placementTestNode("idle-node", 0, 0)},
+		}),
+	})
+}
+
+func TestMultiInventoryAdjust(t *testing.T) {
+	inv := multiTestInventory()
+
+	res := &multiTestReservation{resources: &dtypes.GroupSpec{Resources: placementTestResources(0)}}
+	require.NoError(t, inv.Adjust(res))
+	require.Equal(t, "idle", res.Cluster())
+
+	// reservation placed in cluster is not moved to another one
+	res = &multiTestReservation{resources: &dtypes.GroupSpec{Resources: placementTestResources(0)}, cluster: "busy"}
+	require.ErrorIs(t, inv.Adjust(res), ctypes.ErrInsufficientCapacity)
+	require.Equal(t, "busy", res.Cluster())
+
+	res = &multiTestReservation{resources: &dtypes.GroupSpec{Resources: placementTestResources(1)}}
+	require.ErrorIs(t, inv.Adjust(res), ctypes.ErrInsufficientCapacity)
+	require.Empty(t, res.Cluster())
+}
+
+func TestMultiInventoryMerge(t *testing.T) {
+	inv := multiTestInventory()
+
+	metrics := inv.Metrics()
+	require.Len(t, metrics.Nodes, 2)
+	require.Equal(t, uint64(20000), metrics.TotalAllocatable.CPU)
+	require.Equal(t, uint64(10500), metrics.TotalAvailable.CPU)
+
+	snapshot := inv.Snapshot()
+	require.Len(t, snapshot.Nodes, 2)
+	require.Equal(t, "busy-node", snapshot.Nodes[0].Name)
+	require.Equal(t, "idle-node", snapshot.Nodes[1].Name)
+
+	require.Len(t, inv.Dup().(ctypes.InventoryBackends).Backends(), 2)
+}
@@ -0,0 +1,168 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+const (
+	// PlacementFirstFit tries nodes in the order inventory reports them
+	PlacementFirstFit = "first-fit"
+	// PlacementBinPack tries most allocated nodes first, keeping large nodes free for large orders
+	PlacementBinPack = "bin-pack"
+	// PlacementSpread tries least allocated nodes first
+	PlacementSpread = "spread"
+	// PlacementGPUPreserving tries nodes without GPUs first for workloads that do not request GPUs
+	PlacementGPUPreserving = "gpu-preserving"
+)
+
+var ErrUnknownPlacementStrategy = errors.New("unknown placement strategy")
+
+// PlacementStrategies lists names accepted by NewPlacementStrategy
+var PlacementStrategies = []string{
+	PlacementFirstFit,
+	PlacementBinPack,
+	PlacementSpread,
+	PlacementGPUPreserving,
+}
+
+type firstFitPlacement struct{}
+
+type binPackPlacement struct{}
+
+type spreadPlacement struct{}
+
+type gpuPreservingPlacement struct{}
+
+var (
+	_ ctypes.PlacementStrategy = (*firstFitPlacement)(nil)
+	_ ctypes.PlacementStrategy = (*binPackPlacement)(nil)
+	_ ctypes.PlacementStrategy = (*spreadPlacement)(nil)
+	_ ctypes.PlacementStrategy = (*gpuPreservingPlacement)(nil)
+)
+
+// NewPlacementStrategy returns placement strategy by its name, empty name selects first-fit
+func NewPlacementStrategy(name string) (ctypes.PlacementStrategy, error) {
+	switch name {
+	case "", PlacementFirstFit:
+		return firstFitPlacement{}, nil
+	case PlacementBinPack:
+		return binPackPlacement{}, nil
+	case PlacementSpread:
+		return spreadPlacement{}, nil
+	case PlacementGPUPreserving:
+		return gpuPreservingPlacement{}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownPlacementStrategy, name)
+}
+
+// PlacementOrder returns order of nodes to try for the resources, falling back to first-fit when strategy is not set
+func PlacementOrder(strategy ctypes.PlacementStrategy, nodes inventoryV1.Nodes, resources dtypes.ResourceUnits) []int {
+	if strategy == nil {
+		strategy = firstFitPlacement{}
+	}
+
+	return strategy.Order(nodes, resources)
+}
+
+func (firstFitPlacement) Order(nodes inventoryV1.Nodes, _ dtypes.ResourceUnits) []int {
+	return nodeIndexes(nodes)
+}
+
+func (binPackPlacement) Order(nodes inventoryV1.Nodes, _ dtypes.ResourceUnits) []int {
+	order := nodeIndexes(nodes)
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return nodeUtilization(&nodes[order[i]]) > nodeUtilization(&nodes[order[j]])
+	})
+
+	return order
+}
+
+func (spreadPlacement) Order(nodes inventoryV1.Nodes, _ dtypes.ResourceUnits) []int {
+	order := nodeIndexes(nodes)
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return nodeUtilization(&nodes[order[i]]) < nodeUtilization(&nodes[order[j]])
+	})
+
+	return order
+}
+
+func (gpuPreservingPlacement) Order(nodes inventoryV1.Nodes, resources dtypes.ResourceUnits) []int {
+	order := nodeIndexes(nodes)
+
+	if requestsGPU(resources) {
+		return order
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return !nodeHasGPU(&nodes[order[i]]) && nodeHasGPU(&nodes[order[j]])
+	})
+
+	return order
+}
+
+func nodeIndexes(nodes inventoryV1.Nodes) []int {
+	res := make([]int, 0, len(nodes))
+	for idx := range nodes {
+		res = append(res, idx)
+	}
+
+	return res
+}
+
+// nodeUtilization is the average allocated share of node's cpu, memory and gpu, ignoring ones node does not have
+func nodeUtilization(nd *inventoryV1.Node) float64 {
+	pairs := []*inventoryV1.ResourcePair{
+		&nd.Resources.CPU.Quantity,
+		&nd.Resources.Memory.Quantity,
+		&nd.Resources.GPU.Quantity,
+	}
+
+	var total float64
+	var count int
+
+	for _, rp := range pairs {
+		if rp.Allocatable == nil || rp.Allocatable.IsZero() {
+			continue
+		}
+
+		var allocated float64
+		if rp.Allocated != nil {
+			allocated = rp.Allocated.AsApproximateFloat64()
+		}
+
+		total += allocated / rp.Allocatable.AsApproximateFloat64()
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return total / float64(count)
+}
+
+func nodeHasGPU(nd *inventoryV1.Node) bool {
+	allocatable := nd.Resources.GPU.Quantity.Allocatable
+
+	return allocatable != nil && !allocatable.IsZero()
+}
+
+func requestsGPU(resources dtypes.ResourceUnits) bool {
+	for _, res := range resources {
+		if res.GPU != nil && res.GPU.Units.Value() > 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,132 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/akash-api/go/node/types/unit"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+)
+
+func placementTestNode(name string, cpuAllocated int64, gpus int64) inventoryV1.Node {
+	return inventoryV1.Node{
+		Name: name,
+		Resources: inventoryV1.NodeResources{
+			CPU: inventoryV1.CPU{
+				Quantity: inventoryV1.NewResourcePairMilli(10000, 10000, cpuAllocated, resource.DecimalSI),
+			},
+			Memory: inventoryV1.Memory{
+				Quantity: inventoryV1.NewResourcePair(64*unit.Gi, 64*unit.Gi, 0, resource.DecimalSI),
+			},
+			GPU: inventoryV1.GPU{
+				Quantity: inventoryV1.NewResourcePair(gpus, gpus, 0, resource.DecimalSI),
+			},
+			EphemeralStorage: inventoryV1.NewResourcePair(512*unit.Gi, 512*unit.Gi, 0, resource.DecimalSI),
+			VolumesAttached:  inventoryV1.NewResourcePair(0, 0, 0, resource.DecimalSI),
+			VolumesMounted:   inventoryV1.NewResourcePair(0, 0, 0, resource.DecimalSI),
+		},
+	}
+}
+
+func placementTestResources(gpus uint64) dtypes.ResourceUnits {
+	return dtypes.ResourceUnits{
+		{
+			Resources: types.Resources{
+				ID:     1,
+				CPU:    &types.CPU{Units: types.NewResourceValue(1000)},
+				GPU:    &types.GPU{Units: types.NewResourceValue(gpus)},
+				Memory: &types.Memory{Quantity: types.NewResourceValue(unit.Gi)},
+			},
+			Count: 1,
+		},
+	}
+}
+
+func TestPlacementOrder(t *testing.T) {
+	snapshot := inventoryV1.Cluster{
+		Nodes: inventoryV1.Nodes{
+			placementTestNode("gpu-busy", 6000, 2),
+			placementTestNode("half", 5000, 0),
+			placementTestNode("empty", 0, 0),
+			placementTestNode("busy", 9000, 0),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		resources dtypes.ResourceUnits
+		expected  []string
+	}{
+		{
+			name:      "first-fit",
+			strategy:  PlacementFirstFit,
+			resources: placementTestResources(0),
+			expected:  []string{"gpu-busy", "half", "empty", "busy"},
+		},
+		{
+			name:      "default",
+			strategy:  "",
+			resources: placementTestResources(0),
+			expected:  []string{"gpu-busy", "half", "empty", "busy"},
+		},
+		{
+			name:      "bin-pack",
+			strategy:  PlacementBinPack,
+			resources: placementTestResources(0),
+			expected:  []string{"busy", "half", "gpu-busy", "empty"},
+		},
+		{
+			name:      "spread",
+			strategy:  PlacementSpread,
+			resources: placementTestResources(0),
+			expected:  []string{"empty", "gpu-busy", "half", "busy"},
+		},
+		{
+			name:      "gpu-preserving cpu-only",
+			strategy:  PlacementGPUPreserving,
+			resources: placementTestResources(0),
+			expected:  []string{"half", "empty", "busy", "gpu-busy"},
+		},
+		{
+			name:      "gpu-preserving gpu",
+			strategy:  PlacementGPUPreserving,
+			resources: placementTestResources(1),
+			expected:  []string{"gpu-busy", "half", "empty", "busy"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			strategy, err := NewPlacementStrategy(test.strategy)
+			require.NoError(t, err)
+
+			order := PlacementOrder(strategy, snapshot.Nodes, test.resources)
+
+			names := make([]string, 0, len(order))
+			for _, idx := range order {
+				names = append(names, snapshot.Nodes[idx].Name)
+			}
+
+			require.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestPlacementOrderNilStrategy(t *testing.T) {
+	nodes := inventoryV1.Nodes{
+		placementTestNode("a", 9000, 0),
+		placementTestNode("b", 0, 0),
+	}
+
+	require.Equal(t, []int{0, 1}, PlacementOrder(nil, nodes, placementTestResources(0)))
+}
+
+func TestNewPlacementStrategyUnknown(t *testing.T) {
+	_, err := NewPlacementStrategy("random")
+	require.ErrorIs(t, err, ErrUnknownPlacementStrategy)
+}
@@ -0,0 +1,138 @@
+package inventory
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/node/sdl"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+// Preempt returns candidates reservation has to evict to fit into the cluster, as decided by fits.
+// Candidates are released in the order given until the reservation fits, then the ones
+// it fits without are left out. Nil is returned when releasing all candidates does not help
+func Preempt(cluster *inventoryV1.Cluster, candidates []ctypes.ReservationGroup, fits func(*inventoryV1.Cluster) bool) []ctypes.ReservationGroup {
+	released := cluster.Dup()
+	preempted := make([]ctypes.ReservationGroup, 0, len(candidates))
+	found := false
+
+	for _, candidate := range candidates {
+		if !ReleaseReservation(released, candidate) {
+			continue
+		}
+
+		preempted = append(preempted, candidate)
+
+		if fits(released.Dup()) {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	for idx := 0; idx < len(preempted); {
+		trial := cluster.Dup()
+		for i, candidate := range preempted {
+			if i != idx {
+				ReleaseReservation(trial, candidate)
+			}
+		}
+
+		if fits(trial) {
+			preempted = append(preempted[:idx], preempted[idx+1:]...)
+			continue
+		}
+
+		idx++
+	}
+
+	return preempted
+}
+
+// ReleaseReservation returns resources reservation holds on nodes it was placed on back to the cluster.
+// It reports false for reservations without placement, those cannot be released
+func ReleaseReservation(cluster *inventoryV1.Cluster, res ctypes.ReservationGroup) bool {
+	rp, valid := res.(ctypes.ReservationPlacement)
+	if !valid || len(rp.Placement()) == 0 {
+		return false
+	}
+
+	nodes := make(map[string]int, len(cluster.Nodes))
+	for idx := range cluster.Nodes {
+		nodes[cluster.Nodes[idx].Name] = idx
+	}
+
+	placement := rp.Placement()
+
+	for _, ru := range res.GetAllocatedResources() {
+		for _, name := range placement[ru.Resources.ID] {
+			// node may have left the cluster in the meantime
+			idx, exists := nodes[name]
+			if !exists {
+				continue
+			}
+
+			releaseResources(cluster, &cluster.Nodes[idx], &ru.Resources)
+		}
+	}
+
+	return true
+}
+
+// releaseResources releases one replica of resources from the node and persistent storage classes of the cluster
+func releaseResources(cluster *inventoryV1.Cluster, nd *inventoryV1.Node, res *types.Resources) {
+	if res.CPU != nil {
+		releaseResourcePair(&nd.Resources.CPU.Quantity, resource.NewMilliQuantity(int64(res.CPU.Units.Value()), resource.DecimalSI)) // nolint: gosec
+	}
+
+	if res.GPU != nil {
+		releaseResourcePair(&nd.Resources.GPU.Quantity, resource.NewQuantity(int64(res.GPU.Units.Value()), resource.DecimalSI)) // nolint: gosec
+	}
+
+	if res.Memory != nil {
+		releaseResourcePair(&nd.Resources.Memory.Quantity, resource.NewQuantity(int64(res.Memory.Quantity.Value()), resource.DecimalSI)) // nolint: gosec
+	}
+
+	for _, storage := range res.Storage {
+		attrs, err := ParseStorageAttributes(storage.Attributes)
+		if err != nil {
+			continue
+		}
+
+		qty := resource.NewQuantity(int64(storage.Quantity.Value()), resource.DecimalSI) // nolint: gosec
+
+		if !attrs.Persistent {
+			if attrs.Class == sdl.StorageClassRAM {
+				releaseResourcePair(&nd.Resources.Memory.Quantity, qty)
+			} else {
+				releaseResourcePair(&nd.Resources.EphemeralStorage, qty)
+			}
+
+			continue
+		}
+
+		for idx := range cluster.Storage {
+			if cluster.Storage[idx].Info.Class == attrs.Class {
+				releaseResourcePair(&cluster.Storage[idx].Quantity, qty)
+				break
+			}
+		}
+	}
+}
+
+// releaseResourcePair lowers allocated amount of the pair, never below zero
+func releaseResourcePair(rp *inventoryV1.ResourcePair, qty *resource.Quantity) {
+	allocated := rp.Allocated.DeepCopy()
+	allocated.Sub(*qty)
+
+	if allocated.Sign() < 0 {
+		allocated = *resource.NewQuantity(0, allocated.Format)
+	}
+
+	rp.Allocated = &allocated
+}
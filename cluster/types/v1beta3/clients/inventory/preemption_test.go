@@ -0,0 +1,94 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+)
+
+type preemptionTestReservation struct {
+	resources dtypes.ResourceUnits
+	placement map[uint32][]string
+}
+
+func (r *preemptionTestReservation) Resources() dtypes.ResourceGroup            { return nil }
+func (r *preemptionTestReservation) SetAllocatedResources(dtypes.ResourceUnits) {}
+func (r *preemptionTestReservation) GetAllocatedResources() dtypes.ResourceUnits {
+	return r.resources
+}
+func (r *preemptionTestReservation) SetClusterParams(interface{})         {}
+func (r *preemptionTestReservation) ClusterParams() interface{}           { return nil }
+func (r *preemptionTestReservation) SetPlacement(val map[uint32][]string) { r.placement = val }
+func (r *preemptionTestReservation) Placement() map[uint32][]string       { return r.placement }
+
+func preemptionTestCandidate(node string, cpu uint64) *preemptionTestReservation {
+	return &preemptionTestReservation{
+		resources: dtypes.ResourceUnits{
+			{
+				Resources: types.Resources{
+					ID:  1,
+					CPU: &types.CPU{Units: types.NewResourceValue(cpu)},
+				},
+				Count: 1,
+			},
+		},
+		placement: map[uint32][]string{1: {node}},
+	}
+}
+
+// preemptionTestFits reports node has at least cpu millicpu available
+func preemptionTestFits(cpu int64) func(*inventoryV1.Cluster) bool {
+	return func(cluster *inventoryV1.Cluster) bool {
+		return cluster.Nodes[0].Resources.CPU.Quantity.Available().MilliValue() >= cpu
+	}
+}
+
+func TestPreempt(t *testing.T) {
+	small := preemptionTestCandidate("node", 1000)
+	big := preemptionTestCandidate("node", 4000)
+	unplaced := &preemptionTestReservation{resources: small.resources}
+
+	cluster := inventoryV1.Cluster{
+		Nodes: inventoryV1.Nodes{
+			placementTestNode("node", 9000, 0),
+		},
+	}
+
+	// releasing small alone does not help and is left out once big is released
+	preempted := Preempt(&cluster, []ctypes.ReservationGroup{unplaced, small, big}, preemptionTestFits(5000))
+	require.Equal(t, []ctypes.ReservationGroup{big}, preempted)
+
+	preempted = Preempt(&cluster, []ctypes.ReservationGroup{small, big}, preemptionTestFits(6000))
+	require.Equal(t, []ctypes.ReservationGroup{small, big}, preempted)
+
+	require.Nil(t, Preempt(&cluster, []ctypes.ReservationGroup{small, big}, preemptionTestFits(7000)))
+
+	// cluster given is left intact
+	require.Equal(t, int64(9000), cluster.Nodes[0].Resources.CPU.Quantity.Allocated.MilliValue())
+}
+
+func TestReleaseReservation(t *testing.T) {
+	cluster := inventoryV1.Cluster{
+		Nodes: inventoryV1.Nodes{
+			placementTestNode("node", 3000, 0),
+		},
+	}
+
+	require.False(t, ReleaseReservation(&cluster, &preemptionTestReservation{}))
+
+	require.True(t, ReleaseReservation(&cluster, preemptionTestCandidate("node", 1000)))
+	require.Equal(t, int64(2000), cluster.Nodes[0].Resources.CPU.Quantity.Allocated.MilliValue())
+
+	// allocated never drops below zero
+	require.True(t, ReleaseReservation(&cluster, preemptionTestCandidate("node", 5000)))
+	require.True(t, cluster.Nodes[0].Resources.CPU.Quantity.Allocated.IsZero())
+
+	// nodes left cluster are skipped
+	require.True(t, ReleaseReservation(&cluster, preemptionTestCandidate("gone", 1000)))
+}
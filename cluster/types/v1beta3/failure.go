@@ -0,0 +1,121 @@
+package v1beta3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FailureReason classifies why replicas of a service are not available
+type FailureReason string
+
+const (
+	// FailureImagePull is reported when container image can not be pulled
+	FailureImagePull FailureReason = "ImagePullBackOff"
+	// FailureCrashLoop is reported when container keeps exiting after start
+	FailureCrashLoop FailureReason = "CrashLoopBackOff"
+	// FailureOOMKilled is reported when container was killed for exceeding its memory
+	FailureOOMKilled FailureReason = "OOMKilled"
+	// FailureUnschedulable is reported when no node fits the pod
+	FailureUnschedulable FailureReason = "Unschedulable"
+	// FailureVolumePending is reported when persistent volume claim of the pod is not bound
+	FailureVolumePending FailureReason = "VolumePending"
+	// FailureProbe is reported when running container does not pass its health probes
+	FailureProbe FailureReason = "ProbeFailed"
+)
+
+// FailurePolicy is what deployment monitor does with a lease failing for given reason
+// once monitor retries are exhausted
+type FailurePolicy string
+
+const (
+	// FailurePolicyClose closes the lease
+	FailurePolicyClose FailurePolicy = "close"
+	// FailurePolicyRetry keeps checking the lease at retry period
+	FailurePolicyRetry FailurePolicy = "retry"
+	// FailurePolicyNotify keeps the lease and checks it at healthcheck period, tenant is notified with lease event only
+	FailurePolicyNotify FailurePolicy = "notify"
+)
+
+var (
+	ErrFailureReasonInvalid = errors.New("invalid failure reason")
+	ErrFailurePolicyInvalid = errors.New("invalid failure policy")
+)
+
+// FailureReasons lists every reason failures are classified with
+var FailureReasons = []FailureReason{
+	FailureImagePull,
+	FailureCrashLoop,
+	FailureOOMKilled,
+	FailureUnschedulable,
+	FailureVolumePending,
+	FailureProbe,
+}
+
+// ServiceFailure is classified failure of a replica of the service
+type ServiceFailure struct {
+	Reason FailureReason `json:"reason"`
+	// Pod is the name of failing replica, empty when failure is not tied to one
+	Pod     string `json:"pod,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// FailurePolicies maps failure reasons to policies monitor applies to them
+type FailurePolicies map[FailureReason]FailurePolicy
+
+// ParseFailurePolicies parses policies keyed by failure reason
+func ParseFailurePolicies(vals map[string]string) (FailurePolicies, error) {
+	result := make(FailurePolicies, len(vals))
+
+	for key, val := range vals {
+		reason := FailureReason(key)
+		if !reason.valid() {
+			return nil, fmt.Errorf("%w: %q", ErrFailureReasonInvalid, key)
+		}
+
+		policy := FailurePolicy(val)
+		switch policy {
+		case FailurePolicyClose, FailurePolicyRetry, FailurePolicyNotify:
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrFailurePolicyInvalid, val)
+		}
+
+		result[reason] = policy
+	}
+
+	return result, nil
+}
+
+// Policy returns the policy of most severe of the failures. Leases failing for reasons
+// not classified, or not configured, are closed
+func (p FailurePolicies) Policy(failures []ServiceFailure) FailurePolicy {
+	if len(failures) == 0 {
+		return FailurePolicyClose
+	}
+
+	result := FailurePolicyRetry
+
+	for _, failure := range failures {
+		switch policy, exists := p[failure.Reason]; {
+		case !exists, policy == FailurePolicyClose:
+			return FailurePolicyClose
+		case policy == FailurePolicyNotify:
+			result = FailurePolicyNotify
+		}
+	}
+
+	return result
+}
+
+func (r FailureReason) valid() bool {
+	for _, reason := range FailureReasons {
+		if r == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r FailureReason) String() string {
+	return string(r)
+}
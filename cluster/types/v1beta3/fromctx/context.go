@@ -3,6 +3,8 @@ package fromctx
 import (
 	"context"
 
+	"k8s.io/client-go/kubernetes"
+
 	"github.com/akash-network/provider/cluster/types/v1beta3/clients/hostname"
 	"github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
 	"github.com/akash-network/provider/cluster/types/v1beta3/clients/ip"
@@ -14,6 +16,8 @@ const (
 	CtxKeyClientIP        = CtxKey("client-ip")
 	CtxKeyClientHostname  = CtxKey("client-hostname")
 	CtxKeyClientInventory = CtxKey("client-inventory")
+	// CtxKeyKubeClientBackends is kube client of every cluster keyed by cluster name when provider runs several clusters
+	CtxKeyKubeClientBackends = CtxKey("kube-client-backends")
 )
 
 func ClientIPFromContext(ctx context.Context) ip.Client {
@@ -51,3 +55,12 @@ func ClientInventoryFromContext(ctx context.Context) inventory.Client {
 	res = val.(inventory.Client)
 	return res
 }
+
+func KubeClientBackendsFromContext(ctx context.Context) map[string]kubernetes.Interface {
+	val := ctx.Value(CtxKeyKubeClientBackends)
+	if val == nil {
+		return nil
+	}
+
+	return val.(map[string]kubernetes.Interface)
+}
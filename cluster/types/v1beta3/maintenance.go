@@ -0,0 +1,30 @@
+package v1beta3
+
+import (
+	"time"
+)
+
+const (
+	// MaintenanceAnnotation on a node holds its NodeMaintenance in json
+	MaintenanceAnnotation = "akash.network/maintenance"
+	// MigrationNoticeAnnotation on a lease manifest holds NodeMaintenance of the node lease runs on
+	MigrationNoticeAnnotation = "akash.network/migration-notice"
+)
+
+// NodeMaintenance is maintenance window of a node. Node takes no new reservations from the moment maintenance
+// is set until its window ends or maintenance is cleared, regardless of when the window starts
+type NodeMaintenance struct {
+	Node   string     `json:"node"`
+	Reason string     `json:"reason,omitempty"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+	// NotifyLeases marks leases running on the node with migration notice
+	NotifyLeases bool `json:"notify_leases,omitempty"`
+	// Cordoned is set for nodes cordoned in kubernetes, those are in maintenance until uncordoned
+	Cordoned bool `json:"cordoned,omitempty"`
+}
+
+// InEffect reports whether node is kept out of new reservations at given time
+func (m NodeMaintenance) InEffect(now time.Time) bool {
+	return m.Cordoned || m.End == nil || now.Before(*m.End)
+}
@@ -0,0 +1,66 @@
+package v1beta3
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+)
+
+var ErrPreemptibleClassInvalid = errors.New("invalid preemptible attribute, expected key=value")
+
+// PreemptibleClass is the provider attribute orders opt into preemptible leases with by requiring it.
+// Preemptible leases are bid on at lower price and are evicted when full price reservation does not fit otherwise
+type PreemptibleClass struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// ParsePreemptibleClass parses class in key=value format. Empty string disables preemptible leases
+func ParsePreemptibleClass(val string) (PreemptibleClass, error) {
+	if val == "" {
+		return PreemptibleClass{}, nil
+	}
+
+	key, value, valid := strings.Cut(val, "=")
+	if !valid || key == "" || value == "" {
+		return PreemptibleClass{}, fmt.Errorf("%w: %q", ErrPreemptibleClassInvalid, val)
+	}
+
+	return PreemptibleClass{Key: key, Value: value}, nil
+}
+
+// Enabled reports whether provider offers preemptible leases
+func (c PreemptibleClass) Enabled() bool {
+	return c.Key != ""
+}
+
+// Matches checks order requirement attributes opt into the class
+func (c PreemptibleClass) Matches(attrs atypes.Attributes) bool {
+	if !c.Enabled() {
+		return false
+	}
+
+	for _, attr := range attrs {
+		if attr.Key == c.Key && attr.Value == c.Value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchesGroup checks resources are requested by group opted into the class.
+// Resource groups without placement requirements, such as manifest groups, never match
+func (c PreemptibleClass) MatchesGroup(group dtypes.ResourceGroup) bool {
+	switch group := group.(type) {
+	case *dtypes.Group:
+		return c.Matches(group.GroupSpec.Requirements.Attributes)
+	case *dtypes.GroupSpec:
+		return c.Matches(group.Requirements.Attributes)
+	}
+
+	return false
+}
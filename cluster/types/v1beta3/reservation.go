@@ -14,6 +14,42 @@ type ReservationGroup interface {
 	ClusterParams() interface{}
 }
 
+// ReservationPlacement is implemented by reservation groups keeping track of nodes
+// replicas of each resource unit have been placed on during inventory adjustment.
+// Placement is keyed by resource unit ID and holds one node name per replica
+type ReservationPlacement interface {
+	SetPlacement(map[uint32][]string)
+	Placement() map[uint32][]string
+}
+
+// ReservationPreemption is implemented by reservation groups Adjust may evict preemptible
+// reservations for. Preempted holds candidates released to make the reservation fit
+type ReservationPreemption interface {
+	SetPreempted([]ReservationGroup)
+	Preempted() []ReservationGroup
+}
+
+// ReservationCluster is implemented by reservation groups inventory aggregated
+// from several clusters records the cluster backend they were placed in on
+type ReservationCluster interface {
+	SetCluster(string)
+	Cluster() string
+}
+
+// OwnerUsage is the amount of resources reserved with the provider by a single tenant
+type OwnerUsage struct {
+	// Leases is the number of reservations deployed for leases
+	Leases uint64
+	// Pending is the number of reservations not deployed yet
+	Pending uint64
+	// CPU is the total of millicpu across all reservations
+	CPU uint64
+	// GPU is the total of gpus across all reservations
+	GPU uint64
+	// Memory is the total of memory in bytes across all reservations
+	Memory uint64
+}
+
 // Reservation interface implements orders and resources
 //
 //go:generate mockery --name Reservation --output ./mocks
@@ -0,0 +1,46 @@
+package v1beta3
+
+import (
+	"errors"
+	"fmt"
+)
+
+// RolloutStrategy is how replicas of a service are replaced when lease manifest is updated
+type RolloutStrategy string
+
+const (
+	// RolloutRolling replaces replicas one at a time without extra capacity, service runs one replica short meanwhile
+	RolloutRolling RolloutStrategy = "rolling"
+	// RolloutRecreate stops all replicas before starting updated ones
+	RolloutRecreate RolloutStrategy = "recreate"
+	// RolloutSurge starts an updated replica before stopping an old one.
+	// As provider policy it is decided per lease, from spare capacity inventory has
+	RolloutSurge RolloutStrategy = "surge"
+	// RolloutBlueGreen starts all updated replicas before stopping old ones
+	RolloutBlueGreen RolloutStrategy = "bluegreen"
+)
+
+var ErrRolloutPolicyInvalid = errors.New("invalid rollout policy")
+
+// ParseRolloutPolicy parses rollout policy of the provider. Empty value keeps rolling update
+func ParseRolloutPolicy(val string) (RolloutStrategy, error) {
+	switch strategy := RolloutStrategy(val); strategy {
+	case "":
+		return RolloutRolling, nil
+	case RolloutRolling, RolloutRecreate, RolloutSurge:
+		return strategy, nil
+	}
+
+	return "", fmt.Errorf("%w: %q, expected one of rolling, recreate or surge", ErrRolloutPolicyInvalid, val)
+}
+
+// RolloutStatus is progress of service rollout to the latest manifest
+type RolloutStatus struct {
+	Strategy RolloutStrategy `json:"strategy"`
+	// Desired is the number of replicas service rolls out to
+	Desired int32 `json:"desired"`
+	// Updated is the number of replicas running the latest manifest
+	Updated int32 `json:"updated"`
+	// Complete is set once all replicas are updated and available and no old ones are left
+	Complete bool `json:"complete"`
+}
@@ -5,8 +5,10 @@ import (
 	"context"
 	"io"
 	"strings"
+	"time"
 
 	inventoryV1 "github.com/akash-network/akash-api/go/inventory/v1"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
@@ -51,8 +53,40 @@ type LeaseEvent struct {
 	Object              LeaseEventObject `json:"object" yaml:"object"`
 }
 
+// PlacementStrategy decides the order inventory tries to fit reservation onto cluster nodes
+type PlacementStrategy interface {
+	// Order returns indexes of nodes in the order they are tried for the resources
+	Order(nodes inventoryV1.Nodes, resources dtypes.ResourceUnits) []int
+}
+
+// ResourceHeadroom is capacity kept unallocated. CPU is in millicpu, memory and storage in bytes
+type ResourceHeadroom struct {
+	CPU              uint64 `json:"cpu"`
+	GPU              uint64 `json:"gpu"`
+	Memory           uint64 `json:"memory"`
+	StorageEphemeral uint64 `json:"storage_ephemeral"`
+}
+
+// Headroom decides capacity Adjust leaves unallocated for system overhead and bursts
+type Headroom interface {
+	// Node returns capacity to keep free on the node
+	Node(nd *inventoryV1.Node) ResourceHeadroom
+	// Cluster returns capacity to keep free across all nodes
+	Cluster(allocatable inventoryV1.MetricTotal) ResourceHeadroom
+}
+
+// HeadroomStatus is capacity currently kept free, per node and across the cluster
+type HeadroomStatus struct {
+	Cluster ResourceHeadroom            `json:"cluster"`
+	Nodes   map[string]ResourceHeadroom `json:"nodes,omitempty"`
+}
+
 type InventoryOptions struct {
-	DryRun bool
+	DryRun    bool
+	Placement PlacementStrategy
+	Headroom  Headroom
+	// Preemptible are reservations Adjust may release, in order, when the reservation does not fit otherwise
+	Preemptible []ReservationGroup
 }
 
 type InventoryOption func(*InventoryOptions) *InventoryOptions
@@ -64,6 +98,31 @@ func WithDryRun() InventoryOption {
 	}
 }
 
+// WithPlacementStrategy overrides first-fit order nodes are tried in
+func WithPlacementStrategy(strategy PlacementStrategy) InventoryOption {
+	return func(opts *InventoryOptions) *InventoryOptions {
+		opts.Placement = strategy
+		return opts
+	}
+}
+
+// WithHeadroom makes Adjust fail reservations which would eat into headroom
+func WithHeadroom(headroom Headroom) InventoryOption {
+	return func(opts *InventoryOptions) *InventoryOptions {
+		opts.Headroom = headroom
+		return opts
+	}
+}
+
+// WithPreemption lets Adjust evict given reservations to fit the one being adjusted.
+// Candidates need placement to be released and are tried in the order given
+func WithPreemption(candidates ...ReservationGroup) InventoryOption {
+	return func(opts *InventoryOptions) *InventoryOptions {
+		opts.Preemptible = candidates
+		return opts
+	}
+}
+
 type Inventory interface {
 	Adjust(ReservationGroup, ...InventoryOption) error
 	Metrics() inventoryV1.Metrics
@@ -124,6 +183,32 @@ type ExecResult interface {
 type Status struct {
 	Leases    uint32                       `json:"leases"`
 	Inventory inventoryV1.InventoryMetrics `json:"inventory"`
+	Headroom  *HeadroomStatus              `json:"headroom,omitempty"`
+	Forecast  *CapacityForecast            `json:"forecast,omitempty"`
+	// Maintenance lists nodes in maintenance or scheduled for it
+	Maintenance []NodeMaintenance `json:"maintenance,omitempty"`
+	// Clusters is status of each cluster backend when provider runs several clusters
+	Clusters []ClusterBackendStatus `json:"clusters,omitempty"`
+}
+
+// ResourceForecast is the usage trend of a resource. CPU is in millicpu, memory and storage in bytes
+type ResourceForecast struct {
+	Allocatable uint64 `json:"allocatable"`
+	// Used includes capacity of pending reservations
+	Used uint64 `json:"used"`
+	// Pending is capacity pending reservations hold
+	Pending uint64 `json:"pending"`
+	// Trend is change of used capacity per hour
+	Trend float64 `json:"trend"`
+	// Exhaustion is when used capacity reaches allocatable at current trend, nil when usage is not growing
+	Exhaustion *time.Time `json:"exhaustion,omitempty"`
+}
+
+// CapacityForecast is usage trend of every resource over the sampled window
+type CapacityForecast struct {
+	Samples   int                         `json:"samples"`
+	Since     time.Time                   `json:"since"`
+	Resources map[string]ResourceForecast `json:"resources"`
 }
 
 // ServiceStatus stores the current status of service
@@ -138,6 +223,12 @@ type ServiceStatus struct {
 	UpdatedReplicas    int32 `json:"updated_replicas"`
 	ReadyReplicas      int32 `json:"ready_replicas"`
 	AvailableReplicas  int32 `json:"available_replicas"`
+
+	// Failures of replicas not available, classified by reason
+	Failures []ServiceFailure `json:"failures,omitempty"`
+
+	// Rollout progress of the latest manifest update
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
 }
 
 type ForwardedPortStatus struct {
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/akash-network/node/app"
+	cmdcommon "github.com/akash-network/node/cmd/common"
+	cutils "github.com/akash-network/node/x/cert/utils"
+
+	aclient "github.com/akash-network/provider/client"
+	ctypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	gwrest "github.com/akash-network/provider/gateway/rest"
+)
+
+const (
+	FlagMaintenanceReason       = "reason"
+	FlagMaintenanceStart        = "start"
+	FlagMaintenanceEnd          = "end"
+	FlagMaintenanceNotifyLeases = "notify-leases"
+)
+
+// MaintenanceCmd manages maintenance of provider nodes. Only the provider account itself is allowed to
+func MaintenanceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Manage maintenance of provider nodes",
+	}
+
+	cmd.AddCommand(
+		maintenanceListCmd(),
+		maintenanceStartCmd(),
+		maintenanceEndCmd(),
+	)
+
+	return cmd
+}
+
+func addMaintenanceFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flags.FlagHome, app.DefaultHome, "the application home directory")
+	cmd.Flags().String(flags.FlagFrom, "", "name or address of provider key with which to sign")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "select keyring's backend (os|file|kwallet|pass|test)")
+
+	if err := cmd.MarkFlagRequired(flags.FlagFrom); err != nil {
+		panic(err.Error())
+	}
+}
+
+func maintenanceListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Args:         cobra.NoArgs,
+		Short:        "List nodes in maintenance or scheduled for it",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return doMaintenance(cmd, func(ctx context.Context, cctx sdkclient.Context, gclient gwrest.Client) error {
+				res, err := gclient.NodeMaintenance(ctx)
+				if err != nil {
+					return showErrorToUser(err)
+				}
+
+				return cmdcommon.PrintJSON(cctx, res)
+			})
+		},
+	}
+
+	addMaintenanceFlags(cmd)
+
+	return cmd
+}
+
+func maintenanceStartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "start <node>",
+		Args:         cobra.ExactArgs(1),
+		Short:        "Put node in maintenance, it takes no new reservations until maintenance ends",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			maintenance := ctypes.NodeMaintenance{
+				Node:   args[0],
+				Reason: cmd.Flag(FlagMaintenanceReason).Value.String(),
+			}
+
+			var err error
+
+			if maintenance.Start, err = parseMaintenanceTime(cmd, FlagMaintenanceStart); err != nil {
+				return err
+			}
+
+			if maintenance.End, err = parseMaintenanceTime(cmd, FlagMaintenanceEnd); err != nil {
+				return err
+			}
+
+			if maintenance.NotifyLeases, err = cmd.Flags().GetBool(FlagMaintenanceNotifyLeases); err != nil {
+				return err
+			}
+
+			return doMaintenance(cmd, func(ctx context.Context, _ sdkclient.Context, gclient gwrest.Client) error {
+				return showErrorToUser(gclient.SetNodeMaintenance(ctx, maintenance))
+			})
+		},
+	}
+
+	addMaintenanceFlags(cmd)
+
+	cmd.Flags().String(FlagMaintenanceReason, "", "reason of the maintenance shown in provider status")
+	cmd.Flags().String(FlagMaintenanceStart, "", "RFC3339 time maintenance window starts at")
+	cmd.Flags().String(FlagMaintenanceEnd, "", "RFC3339 time maintenance window ends at. node stays in maintenance until it is ended when not set")
+	cmd.Flags().Bool(FlagMaintenanceNotifyLeases, false, "mark leases running on the node with migration notice")
+
+	return cmd
+}
+
+func maintenanceEndCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "end <node>",
+		Args:         cobra.ExactArgs(1),
+		Short:        "Take node out of maintenance and remove migration notices of its leases",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doMaintenance(cmd, func(ctx context.Context, _ sdkclient.Context, gclient gwrest.Client) error {
+				return showErrorToUser(gclient.ClearNodeMaintenance(ctx, args[0]))
+			})
+		},
+	}
+
+	addMaintenanceFlags(cmd)
+
+	return cmd
+}
+
+func parseMaintenanceTime(cmd *cobra.Command, flag string) (*time.Time, error) {
+	val := cmd.Flag(flag).Value.String()
+	if val == "" {
+		return nil, nil
+	}
+
+	res, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// doMaintenance connects to gateway of the provider signing key belongs to
+func doMaintenance(cmd *cobra.Command, fn func(context.Context, sdkclient.Context, gwrest.Client) error) error {
+	cctx, err := sdkclient.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	cl, err := aclient.DiscoverQueryClient(ctx, cctx)
+	if err != nil {
+		return err
+	}
+
+	cert, err := cutils.LoadAndQueryCertificateForAccount(ctx, cctx, nil)
+	if err != nil {
+		return markRPCServerError(err)
+	}
+
+	gclient, err := gwrest.NewClient(ctx, cl, cctx.FromAddress, []tls.Certificate{cert})
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, cctx, gclient)
+}
@@ -9,5 +9,7 @@ func migrate() *cobra.Command {
 		Use: "migrate",
 	}
 
+	cmd.AddCommand(migrateCredentialsCmd())
+
 	return cmd
 }
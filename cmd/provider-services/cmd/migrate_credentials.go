@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/akash-network/provider/cluster/kube/clientcommon"
+	providerflags "github.com/akash-network/provider/cmd/provider-services/cmd/flags"
+	cmdutil "github.com/akash-network/provider/cmd/provider-services/cmd/util"
+	crd "github.com/akash-network/provider/pkg/apis/akash.network/v2beta2"
+	akashclientset "github.com/akash-network/provider/pkg/client/clientset/versioned"
+)
+
+// FlagDeploymentCredentialsKey is the file holding key registry credentials of tenants are sealed with
+const FlagDeploymentCredentialsKey = "deployment-credentials-key"
+
+// readCredentialsSealer reads base64 encoded credentials key from the file, nil sealer when path is empty
+func readCredentialsSealer(path string) (*crd.CredentialsSealer, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(os.ExpandEnv(path))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := crd.ParseCredentialsKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return crd.NewCredentialsSealer(key)
+}
+
+func migrateCredentialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "credentials",
+		Short:        "seal registry credentials stored in plain in lease manifests",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sealer, err := readCredentialsSealer(viper.GetString(FlagDeploymentCredentialsKey))
+			if err != nil {
+				return err
+			}
+
+			if sealer == nil {
+				return fmt.Errorf("%w: --%s required", errInvalidConfig, FlagDeploymentCredentialsKey)
+			}
+
+			log := cmdutil.OpenLogger().With("cmp", "migrate-credentials")
+
+			kubecfg, err := clientcommon.OpenKubeConfig(viper.GetString(providerflags.FlagKubeConfig), log)
+			if err != nil {
+				return err
+			}
+
+			ac, err := akashclientset.NewForConfig(kubecfg)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			ns := viper.GetString(providerflags.FlagK8sManifestNS)
+
+			manifests, err := ac.AkashV2beta2().Manifests(ns).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			sealed := 0
+
+			for idx := range manifests.Items {
+				manifest := &manifests.Items[idx]
+
+				changed, err := sealer.SealManifest(manifest, nil)
+				if err != nil {
+					return fmt.Errorf("manifest %s: %w", manifest.Name, err)
+				}
+
+				if !changed {
+					continue
+				}
+
+				if _, err = ac.AkashV2beta2().Manifests(ns).Update(ctx, manifest, metav1.UpdateOptions{}); err != nil {
+					return fmt.Errorf("manifest %s: %w", manifest.Name, err)
+				}
+
+				sealed++
+				log.Info("sealed manifest credentials", "manifest", manifest.Name)
+			}
+
+			log.Info("credentials migration complete", "manifests", len(manifests.Items), "sealed", sealed)
+
+			return nil
+		},
+	}
+
+	if err := providerflags.AddKubeConfigPathFlag(cmd); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(providerflags.FlagK8sManifestNS, "lease", "Cluster manifest namespace")
+	if err := viper.BindPFlag(providerflags.FlagK8sManifestNS, cmd.Flags().Lookup(providerflags.FlagK8sManifestNS)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagDeploymentCredentialsKey, "", "file with base64 encoded 32 bytes key registry credentials are sealed with")
+	if err := viper.BindPFlag(FlagDeploymentCredentialsKey, cmd.Flags().Lookup(FlagDeploymentCredentialsKey)); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
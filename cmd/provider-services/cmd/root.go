@@ -41,6 +41,8 @@ func NewRootCmd() *cobra.Command {
 
 	cmd.AddCommand(ManifestCmds()...)
 	cmd.AddCommand(statusCmd())
+	cmd.AddCommand(SimulateBidCmd())
+	cmd.AddCommand(MaintenanceCmd())
 	cmd.AddCommand(leaseStatusCmd())
 	cmd.AddCommand(leaseEventsCmd())
 	cmd.AddCommand(leaseLogsCmd())
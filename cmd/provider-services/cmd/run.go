@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -45,6 +46,8 @@ import (
 	kubehostname "github.com/akash-network/provider/cluster/kube/operators/clients/hostname"
 	kubeinventory "github.com/akash-network/provider/cluster/kube/operators/clients/inventory"
 	kubeip "github.com/akash-network/provider/cluster/kube/operators/clients/ip"
+	clustertypes "github.com/akash-network/provider/cluster/types/v1beta3"
+	cinventory "github.com/akash-network/provider/cluster/types/v1beta3/clients/inventory"
 	cip "github.com/akash-network/provider/cluster/types/v1beta3/clients/ip"
 	clfromctx "github.com/akash-network/provider/cluster/types/v1beta3/fromctx"
 	providerflags "github.com/akash-network/provider/cmd/provider-services/cmd/flags"
@@ -60,6 +63,8 @@ import (
 const (
 	// FlagClusterK8s informs the provider to scan and utilize localized kubernetes client configuration
 	FlagClusterK8s = "cluster-k8s"
+	// FlagClusterBackends lists additional kubernetes clusters leases are deployed into
+	FlagClusterBackends = "cluster-backends"
 
 	// FlagGatewayListenAddress determines listening address for Manifests
 	FlagGatewayListenAddress             = "gateway-listen-address"
@@ -67,17 +72,31 @@ const (
 	FlagBidPricingStrategy               = "bid-price-strategy"
 	FlagBidPriceCPUScale                 = "bid-price-cpu-scale"
 	FlagBidPriceMemoryScale              = "bid-price-memory-scale"
+	FlagBidPriceGPUScale                 = "bid-price-gpu-scale"
 	FlagBidPriceStorageScale             = "bid-price-storage-scale"
 	FlagBidPriceEndpointScale            = "bid-price-endpoint-scale"
 	FlagBidPriceScriptPath               = "bid-price-script-path"
 	FlagBidPriceScriptProcessLimit       = "bid-price-script-process-limit"
 	FlagBidPriceScriptTimeout            = "bid-price-script-process-timeout"
+	FlagBidPriceExpression               = "bid-price-expression"
+	FlagBidPriceExpressionPath           = "bid-price-expression-path"
+	FlagBidPriceExpressionTimeout        = "bid-price-expression-timeout"
+	FlagBidPriceRemoteEndpoint           = "bid-price-remote-endpoint"
+	FlagBidPriceRemoteTimeout            = "bid-price-remote-timeout"
+	FlagBidPriceRemoteRetries            = "bid-price-remote-retries"
+	FlagBidPriceRemoteRetryDelay         = "bid-price-remote-retry-delay"
+	FlagBidPriceRemoteConcurrencyLimit   = "bid-price-remote-concurrency-limit"
+	FlagBidPriceRemoteBreakerThreshold   = "bid-price-remote-breaker-threshold"
+	FlagBidPriceRemoteBreakerCooldown    = "bid-price-remote-breaker-cooldown"
+	FlagBidPriceRemoteFallback           = "bid-price-remote-fallback"
 	FlagBidDeposit                       = "bid-deposit"
 	FlagClusterPublicHostname            = "cluster-public-hostname"
 	FlagClusterNodePortQuantity          = "cluster-node-port-quantity"
 	FlagClusterWaitReadyDuration         = "cluster-wait-ready-duration"
 	FlagInventoryResourcePollPeriod      = "inventory-resource-poll-period"
 	FlagInventoryResourceDebugFrequency  = "inventory-resource-debug-frequency"
+	FlagInventoryPlacementStrategy       = "inventory-placement-strategy"
+	FlagInventoryPlacementPinning        = "inventory-placement-pinning"
 	FlagDeploymentIngressStaticHosts     = "deployment-ingress-static-hosts"
 	FlagDeploymentIngressDomain          = "deployment-ingress-domain"
 	FlagDeploymentIngressExposeLBHosts   = "deployment-ingress-expose-lb-hosts"
@@ -86,10 +105,37 @@ const (
 	FlagOvercommitPercentMemory          = "overcommit-pct-mem"
 	FlagOvercommitPercentCPU             = "overcommit-pct-cpu"
 	FlagOvercommitPercentStorage         = "overcommit-pct-storage"
+	FlagCommitLevelsPath                 = "commit-levels-path"
+	FlagInventoryHeadroomPath            = "inventory-headroom-path"
+	FlagInventoryForecastInterval        = "inventory-forecast-interval"
+	FlagInventoryForecastWindow          = "inventory-forecast-window"
+	FlagInventoryForecastAlert           = "inventory-forecast-alert"
 	FlagDeploymentBlockedHostnames       = "deployment-blocked-hostnames"
 	FlagAuthPem                          = "auth-pem"
 	FlagDeploymentRuntimeClass           = "deployment-runtime-class"
+	FlagDeploymentPreemptibleClass       = "deployment-preemptible-priority-class"
+	FlagDeploymentHealthProbes           = "deployment-health-probes"
+	FlagDeploymentProbeHTTPPath          = "deployment-health-probe-http-path"
+	FlagDeploymentProbePeriod            = "deployment-health-probe-period"
+	FlagDeploymentProbeTimeout           = "deployment-health-probe-timeout"
+	FlagDeploymentProbeFailureThreshold  = "deployment-health-probe-failure-threshold"
+	FlagDeploymentProbeStartupTimeout    = "deployment-health-probe-startup-timeout"
+	FlagDeploymentTerminationGracePeriod = "deployment-termination-grace-period"
+	FlagDeploymentMaxTerminationGrace    = "deployment-max-termination-grace-period"
+	FlagDeploymentTeardownTimeout        = "deployment-teardown-timeout"
+	FlagDeploymentRolloutPolicy          = "deployment-rollout-policy"
+	FlagPreemptibleAttribute             = "preemptible-attribute"
 	FlagBidTimeout                       = "bid-timeout"
+	FlagBidDecisionLogSize               = "bid-decision-log-size"
+	FlagBidAdmissionWindow               = "bid-admission-window"
+	FlagBidAdmissionObjective            = "bid-admission-objective"
+	FlagBidOwnerAllowlist                = "bid-owner-allowlist"
+	FlagBidOwnerDenylist                 = "bid-owner-denylist"
+	FlagBidOwnerQuotaLeases              = "bid-owner-quota-leases"
+	FlagBidOwnerQuotaCPU                 = "bid-owner-quota-cpu"
+	FlagBidOwnerQuotaGPU                 = "bid-owner-quota-gpu"
+	FlagBidOwnerQuotaMemory              = "bid-owner-quota-memory"
+	FlagBidOwnerPolicyPath               = "bid-owner-policy-path"
 	FlagManifestTimeout                  = "manifest-timeout"
 	FlagMetricsListener                  = "metrics-listener"
 	FlagWithdrawalPeriod                 = "withdrawal-period"
@@ -106,6 +152,8 @@ const (
 	FlagMonitorRetryPeriodJitter         = "monitor-retry-period-jitter"
 	FlagMonitorHealthcheckPeriod         = "monitor-healthcheck-period"
 	FlagMonitorHealthcheckPeriodJitter   = "monitor-healthcheck-period-jitter"
+	FlagMonitorFailurePolicy             = "monitor-failure-policy"
+	FlagReservationsStatePath            = "reservations-state-path"
 )
 
 const (
@@ -208,6 +256,11 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().StringToString(FlagClusterBackends, nil, fmt.Sprintf("additional kubernetes clusters leases are deployed into, as name=kubeconfig-path. cluster of --%s is named %s", providerflags.FlagKubeConfig, cluster.PrimaryBackend))
+	if err := viper.BindPFlag(FlagClusterBackends, cmd.Flags().Lookup(FlagClusterBackends)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().String(providerflags.FlagK8sManifestNS, "lease", "Cluster manifest namespace")
 	if err := viper.BindPFlag(providerflags.FlagK8sManifestNS, cmd.Flags().Lookup(providerflags.FlagK8sManifestNS)); err != nil {
 		panic(err)
@@ -223,7 +276,7 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
-	cmd.Flags().String(FlagBidPricingStrategy, "scale", "Pricing strategy to use")
+	cmd.Flags().String(FlagBidPricingStrategy, "scale", "Pricing strategy to use. comma separated list of strategies, e.g. remote,scale, is tried in order until one calculates price")
 	if err := viper.BindPFlag(FlagBidPricingStrategy, cmd.Flags().Lookup(FlagBidPricingStrategy)); err != nil {
 		panic(err)
 	}
@@ -238,6 +291,11 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().String(FlagBidPriceGPUScale, "0", "gpu pricing scale in uakt per gpu. comma separated list of <attribute key>=<price> pairs, e.g. vendor/nvidia/model/a100=100,vendor/nvidia/model/*=50. value without a key applies to any gpu")
+	if err := viper.BindPFlag(FlagBidPriceGPUScale, cmd.Flags().Lookup(FlagBidPriceGPUScale)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().String(FlagBidPriceStorageScale, "0", "storage pricing scale in uakt per megabyte")
 	if err := viper.BindPFlag(FlagBidPriceStorageScale, cmd.Flags().Lookup(FlagBidPriceStorageScale)); err != nil {
 		panic(err)
@@ -268,6 +326,61 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().String(FlagBidPriceExpression, "", "expression to evaluate for computing bid price")
+	if err := viper.BindPFlag(FlagBidPriceExpression, cmd.Flags().Lookup(FlagBidPriceExpression)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidPriceExpressionPath, "", "path to file with expression to evaluate for computing bid price")
+	if err := viper.BindPFlag(FlagBidPriceExpressionPath, cmd.Flags().Lookup(FlagBidPriceExpressionPath)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagBidPriceExpressionTimeout, time.Second, "execution timelimit for bid pricing expression as a duration")
+	if err := viper.BindPFlag(FlagBidPriceExpressionTimeout, cmd.Flags().Lookup(FlagBidPriceExpressionTimeout)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidPriceRemoteEndpoint, "", "url of the pricing service to query for computing bid price")
+	if err := viper.BindPFlag(FlagBidPriceRemoteEndpoint, cmd.Flags().Lookup(FlagBidPriceRemoteEndpoint)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagBidPriceRemoteTimeout, time.Second*5, "timeout of a single request to the pricing service")
+	if err := viper.BindPFlag(FlagBidPriceRemoteTimeout, cmd.Flags().Lookup(FlagBidPriceRemoteTimeout)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Uint(FlagBidPriceRemoteRetries, 2, "number of retries when the pricing service is unavailable")
+	if err := viper.BindPFlag(FlagBidPriceRemoteRetries, cmd.Flags().Lookup(FlagBidPriceRemoteRetries)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagBidPriceRemoteRetryDelay, time.Millisecond*500, "initial delay between retries to the pricing service")
+	if err := viper.BindPFlag(FlagBidPriceRemoteRetryDelay, cmd.Flags().Lookup(FlagBidPriceRemoteRetryDelay)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Uint(FlagBidPriceRemoteConcurrencyLimit, 32, "limit to the number of concurrent requests to the pricing service")
+	if err := viper.BindPFlag(FlagBidPriceRemoteConcurrencyLimit, cmd.Flags().Lookup(FlagBidPriceRemoteConcurrencyLimit)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Uint(FlagBidPriceRemoteBreakerThreshold, 5, "consecutive pricing service failures after which requests are suspended. 0 disables circuit breaker")
+	if err := viper.BindPFlag(FlagBidPriceRemoteBreakerThreshold, cmd.Flags().Lookup(FlagBidPriceRemoteBreakerThreshold)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagBidPriceRemoteBreakerCooldown, time.Second*30, "time requests to the pricing service are suspended for once circuit breaker opens")
+	if err := viper.BindPFlag(FlagBidPriceRemoteBreakerCooldown, cmd.Flags().Lookup(FlagBidPriceRemoteBreakerCooldown)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Bool(FlagBidPriceRemoteFallback, false, "fall back to scale pricing when the pricing service is unavailable")
+	if err := viper.BindPFlag(FlagBidPriceRemoteFallback, cmd.Flags().Lookup(FlagBidPriceRemoteFallback)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().String(FlagBidDeposit, cfg.BidDeposit.String(), "Bid deposit amount")
 	if err := viper.BindPFlag(FlagBidDeposit, cmd.Flags().Lookup(FlagBidDeposit)); err != nil {
 		panic(err)
@@ -293,11 +406,36 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().Duration(FlagInventoryForecastInterval, 5*time.Minute, "how often inventory usage is sampled for capacity forecasts. 0 disables forecasting")
+	if err := viper.BindPFlag(FlagInventoryForecastInterval, cmd.Flags().Lookup(FlagInventoryForecastInterval)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagInventoryForecastWindow, 7*24*time.Hour, "how far back inventory usage samples are kept to compute capacity trends")
+	if err := viper.BindPFlag(FlagInventoryForecastWindow, cmd.Flags().Lookup(FlagInventoryForecastWindow)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagInventoryForecastAlert, 14*24*time.Hour, "raise capacity exhaustion alert when a resource is forecast to run out within this duration. 0 disables alerts")
+	if err := viper.BindPFlag(FlagInventoryForecastAlert, cmd.Flags().Lookup(FlagInventoryForecastAlert)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().Uint(FlagInventoryResourceDebugFrequency, 10, "The rate at which to log all inventory resources")
 	if err := viper.BindPFlag(FlagInventoryResourceDebugFrequency, cmd.Flags().Lookup(FlagInventoryResourceDebugFrequency)); err != nil {
 		panic(err)
 	}
 
+	cmd.Flags().String(FlagInventoryPlacementStrategy, cinventory.PlacementFirstFit, fmt.Sprintf("order in which nodes are tried when reserving resources: %s", strings.Join(cinventory.PlacementStrategies, "|")))
+	if err := viper.BindPFlag(FlagInventoryPlacementStrategy, cmd.Flags().Lookup(FlagInventoryPlacementStrategy)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagInventoryPlacementPinning, cluster.PlacementPinningNone, fmt.Sprintf("pin workloads to nodes resources were reserved on: %s. required falls back to any node when pinned nodes are gone", strings.Join(cluster.PlacementPinningModes, "|")))
+	if err := viper.BindPFlag(FlagInventoryPlacementPinning, cmd.Flags().Lookup(FlagInventoryPlacementPinning)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().Bool(FlagDeploymentIngressStaticHosts, false, "")
 	if err := viper.BindPFlag(FlagDeploymentIngressStaticHosts, cmd.Flags().Lookup(FlagDeploymentIngressStaticHosts)); err != nil {
 		panic(err)
@@ -338,6 +476,16 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().String(FlagCommitLevelsPath, "", "path to yaml file with commit levels per node pool and per storage class. overcommit percentages apply to nodes of no pool")
+	if err := viper.BindPFlag(FlagCommitLevelsPath, cmd.Flags().Lookup(FlagCommitLevelsPath)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagInventoryHeadroomPath, "", "path to yaml file with capacity kept free per node and cluster-wide for system overhead. all allocatable capacity is biddable when not set")
+	if err := viper.BindPFlag(FlagInventoryHeadroomPath, cmd.Flags().Lookup(FlagInventoryHeadroomPath)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().StringSlice(FlagDeploymentBlockedHostnames, nil, "hostnames blocked for deployments")
 	if err := viper.BindPFlag(FlagDeploymentBlockedHostnames, cmd.Flags().Lookup(FlagDeploymentBlockedHostnames)); err != nil {
 		panic(err)
@@ -354,11 +502,126 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().String(FlagDeploymentPreemptibleClass, "", "kubernetes priority class for workloads of preemptible leases, default priority when empty")
+	if err := viper.BindPFlag(FlagDeploymentPreemptibleClass, cmd.Flags().Lookup(FlagDeploymentPreemptibleClass)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagDeploymentCredentialsKey, "", "file with base64 encoded 32 bytes key registry credentials of tenants are sealed with in lease manifests. credentials are stored in plain when empty")
+	if err := viper.BindPFlag(FlagDeploymentCredentialsKey, cmd.Flags().Lookup(FlagDeploymentCredentialsKey)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Bool(FlagDeploymentHealthProbes, false, "derive health probes of tenant services from ports they expose. services opt out with AKASH_HEALTH_PROBES=false")
+	if err := viper.BindPFlag(FlagDeploymentHealthProbes, cmd.Flags().Lookup(FlagDeploymentHealthProbes)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagDeploymentProbeHTTPPath, "", "path requested by health probes of ports exposed through ingress. tcp connection is probed when empty")
+	if err := viper.BindPFlag(FlagDeploymentProbeHTTPPath, cmd.Flags().Lookup(FlagDeploymentProbeHTTPPath)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentProbePeriod, 10*time.Second, "period of health probes")
+	if err := viper.BindPFlag(FlagDeploymentProbePeriod, cmd.Flags().Lookup(FlagDeploymentProbePeriod)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentProbeTimeout, 5*time.Second, "timeout of single health probe")
+	if err := viper.BindPFlag(FlagDeploymentProbeTimeout, cmd.Flags().Lookup(FlagDeploymentProbeTimeout)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Int32(FlagDeploymentProbeFailureThreshold, 3, "consecutive health probe failures container is marked unready and restarted after")
+	if err := viper.BindPFlag(FlagDeploymentProbeFailureThreshold, cmd.Flags().Lookup(FlagDeploymentProbeFailureThreshold)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentProbeStartupTimeout, 5*time.Minute, "time containers have to start before health probes restart them")
+	if err := viper.BindPFlag(FlagDeploymentProbeStartupTimeout, cmd.Flags().Lookup(FlagDeploymentProbeStartupTimeout)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentTerminationGracePeriod, 30*time.Second, "grace period containers of tenant services have to shut down. services request another with AKASH_TERMINATION_GRACE_PERIOD")
+	if err := viper.BindPFlag(FlagDeploymentTerminationGracePeriod, cmd.Flags().Lookup(FlagDeploymentTerminationGracePeriod)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentMaxTerminationGrace, 5*time.Minute, "upper bound of termination grace period tenant services can request")
+	if err := viper.BindPFlag(FlagDeploymentMaxTerminationGrace, cmd.Flags().Lookup(FlagDeploymentMaxTerminationGrace)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagDeploymentTeardownTimeout, 5*time.Minute, "time lease teardown waits for workloads scaled to zero to shut down before deleting lease namespace. must be at least max termination grace period, 0 deletes namespace right away")
+	if err := viper.BindPFlag(FlagDeploymentTeardownTimeout, cmd.Flags().Lookup(FlagDeploymentTeardownTimeout)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagDeploymentRolloutPolicy, string(clustertypes.RolloutRolling), "strategy services are updated with: rolling, recreate or surge. surge starts updated replicas before stopping old ones for leases inventory has spare capacity for, the rest keep rolling update")
+	if err := viper.BindPFlag(FlagDeploymentRolloutPolicy, cmd.Flags().Lookup(FlagDeploymentRolloutPolicy)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagPreemptibleAttribute, "", "provider attribute in key=value format orders opt into preemptible leases with. preemptible leases are disabled when empty")
+	if err := viper.BindPFlag(FlagPreemptibleAttribute, cmd.Flags().Lookup(FlagPreemptibleAttribute)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().Duration(FlagBidTimeout, 5*time.Minute, "time after which bids are cancelled if no lease is created")
 	if err := viper.BindPFlag(FlagBidTimeout, cmd.Flags().Lookup(FlagBidTimeout)); err != nil {
 		panic(err)
 	}
 
+	cmd.Flags().Int(FlagBidDecisionLogSize, bidengine.DefaultDecisionLogSize, "number of most recent bid decisions kept for inspection")
+	if err := viper.BindPFlag(FlagBidDecisionLogSize, cmd.Flags().Lookup(FlagBidDecisionLogSize)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Duration(FlagBidAdmissionWindow, 0, "window orders requesting GPUs are batched for before reserving them in the order chosen by admission objective. 0 disables batching")
+	if err := viper.BindPFlag(FlagBidAdmissionWindow, cmd.Flags().Lookup(FlagBidAdmissionWindow)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidAdmissionObjective, bidengine.AdmissionObjectiveFIFO, "objective batched orders are reserved by: fifo, revenue or utilization")
+	if err := viper.BindPFlag(FlagBidAdmissionObjective, cmd.Flags().Lookup(FlagBidAdmissionObjective)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().StringSlice(FlagBidOwnerAllowlist, nil, "owners to bid on orders of. empty list allows all owners which are not denied")
+	if err := viper.BindPFlag(FlagBidOwnerAllowlist, cmd.Flags().Lookup(FlagBidOwnerAllowlist)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().StringSlice(FlagBidOwnerDenylist, nil, "owners to never bid on orders of")
+	if err := viper.BindPFlag(FlagBidOwnerDenylist, cmd.Flags().Lookup(FlagBidOwnerDenylist)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Uint64(FlagBidOwnerQuotaLeases, 0, "maximum number of leases and pending reservations single owner may hold. 0 is unlimited")
+	if err := viper.BindPFlag(FlagBidOwnerQuotaLeases, cmd.Flags().Lookup(FlagBidOwnerQuotaLeases)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidOwnerQuotaCPU, "", "maximum cpu single owner may hold, e.g. 32 or 500m. empty is unlimited")
+	if err := viper.BindPFlag(FlagBidOwnerQuotaCPU, cmd.Flags().Lookup(FlagBidOwnerQuotaCPU)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().Uint64(FlagBidOwnerQuotaGPU, 0, "maximum number of gpus single owner may hold. 0 is unlimited")
+	if err := viper.BindPFlag(FlagBidOwnerQuotaGPU, cmd.Flags().Lookup(FlagBidOwnerQuotaGPU)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidOwnerQuotaMemory, "", "maximum memory single owner may hold, e.g. 64Gi. empty is unlimited")
+	if err := viper.BindPFlag(FlagBidOwnerQuotaMemory, cmd.Flags().Lookup(FlagBidOwnerQuotaMemory)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagBidOwnerPolicyPath, "", "path to yaml file with owner allow and deny lists and quotas. file is reloaded on change")
+	if err := viper.BindPFlag(FlagBidOwnerPolicyPath, cmd.Flags().Lookup(FlagBidOwnerPolicyPath)); err != nil {
+		panic(err)
+	}
+
 	cmd.Flags().Duration(FlagManifestTimeout, 5*time.Minute, "time after which bids are cancelled if no manifest is received")
 	if err := viper.BindPFlag(FlagManifestTimeout, cmd.Flags().Lookup(FlagManifestTimeout)); err != nil {
 		panic(err)
@@ -434,6 +697,18 @@ func RunCmd() *cobra.Command {
 		panic(err)
 	}
 
+	cmd.Flags().StringToString(FlagMonitorFailurePolicy, nil, fmt.Sprintf("policy applied once status retries of lease failing for a reason are exhausted, as reason=policy. reasons: %s, %s, %s, %s, %s, %s. policies: %s (default), %s, %s",
+		clustertypes.FailureImagePull, clustertypes.FailureCrashLoop, clustertypes.FailureOOMKilled, clustertypes.FailureUnschedulable, clustertypes.FailureVolumePending, clustertypes.FailureProbe,
+		clustertypes.FailurePolicyClose, clustertypes.FailurePolicyRetry, clustertypes.FailurePolicyNotify))
+	if err := viper.BindPFlag(FlagMonitorFailurePolicy, cmd.Flags().Lookup(FlagMonitorFailurePolicy)); err != nil {
+		panic(err)
+	}
+
+	cmd.Flags().String(FlagReservationsStatePath, "", "path to file pending reservations are persisted to across restarts. reservations are kept in memory only when not set")
+	if err := viper.BindPFlag(FlagReservationsStatePath, cmd.Flags().Lookup(FlagReservationsStatePath)); err != nil {
+		panic(err)
+	}
+
 	if err := providerflags.AddServiceEndpointFlag(cmd, serviceHostnameOperator); err != nil {
 		panic(err)
 	}
@@ -449,17 +724,23 @@ const (
 	bidPricingStrategyScale       = "scale"
 	bidPricingStrategyRandomRange = "randomRange"
 	bidPricingStrategyShellScript = "shellScript"
+	bidPricingStrategyExpression  = "expression"
+	bidPricingStrategyRemote      = "remote"
 )
 
 var allowedBidPricingStrategies = [...]string{
 	bidPricingStrategyScale,
 	bidPricingStrategyRandomRange,
 	bidPricingStrategyShellScript,
+	bidPricingStrategyExpression,
+	bidPricingStrategyRemote,
 }
 
 var errNoSuchBidPricingStrategy = fmt.Errorf("No such bid pricing strategy. Allowed: %v", allowedBidPricingStrategies)
 var errInvalidValueForBidPrice = errors.New("not a valid bid price")
 var errBidPriceNegative = errors.New("Bid price cannot be a negative number")
+var errBidPriceExpressionAmbiguous = fmt.Errorf("only one of --%s or --%s can be set", FlagBidPriceExpression, FlagBidPriceExpressionPath)
+var errBidOwnerPolicyAmbiguous = fmt.Errorf("owner lists and quotas flags cannot be used with --%s", FlagBidOwnerPolicyPath)
 
 func strToBidPriceScale(val string) (decimal.Decimal, error) {
 	v, err := decimal.NewFromString(val)
@@ -484,6 +765,26 @@ func createBidPricingStrategy(strategy string) (bidengine.BidPricingStrategy, er
 		if err != nil {
 			return nil, err
 		}
+		gpuScale := make(bidengine.GPU)
+
+		gpuScales := strings.Split(viper.GetString(FlagBidPriceGPUScale), ",")
+		for _, scalePair := range gpuScales {
+			vals := strings.Split(scalePair, "=")
+
+			name := bidengine.GPUModelWildcard
+			scaleVal := vals[0]
+
+			if len(vals) == 2 {
+				name = vals[0]
+				scaleVal = vals[1]
+			}
+
+			gpuScale[name], err = strToBidPriceScale(scaleVal)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		storageScale := make(bidengine.Storage)
 
 		storageScales := strings.Split(viper.GetString(FlagBidPriceStorageScale), ",")
@@ -514,7 +815,7 @@ func createBidPricingStrategy(strategy string) (bidengine.BidPricingStrategy, er
 			return nil, err
 		}
 
-		return bidengine.MakeScalePricing(cpuScale, memoryScale, storageScale, endpointScale, ipScale)
+		return bidengine.MakeScalePricing(cpuScale, memoryScale, gpuScale, storageScale, endpointScale, ipScale)
 	}
 
 	if strategy == bidPricingStrategyRandomRange {
@@ -528,9 +829,96 @@ func createBidPricingStrategy(strategy string) (bidengine.BidPricingStrategy, er
 		return bidengine.MakeShellScriptPricing(scriptPath, processLimit, runtimeLimit)
 	}
 
+	if strategy == bidPricingStrategyExpression {
+		expression := viper.GetString(FlagBidPriceExpression)
+
+		if exprPath := viper.GetString(FlagBidPriceExpressionPath); exprPath != "" {
+			if expression != "" {
+				return nil, errBidPriceExpressionAmbiguous
+			}
+
+			buf, err := os.ReadFile(exprPath)
+			if err != nil {
+				return nil, err
+			}
+
+			expression = string(buf)
+		}
+
+		return bidengine.MakeExpressionPricing(expression, viper.GetDuration(FlagBidPriceExpressionTimeout))
+	}
+
+	if strategy == bidPricingStrategyRemote {
+		cfg := bidengine.RemotePricingConfig{
+			Endpoint:         viper.GetString(FlagBidPriceRemoteEndpoint),
+			Timeout:          viper.GetDuration(FlagBidPriceRemoteTimeout),
+			Retries:          viper.GetUint(FlagBidPriceRemoteRetries),
+			RetryDelay:       viper.GetDuration(FlagBidPriceRemoteRetryDelay),
+			ConcurrencyLimit: viper.GetUint(FlagBidPriceRemoteConcurrencyLimit),
+			BreakerThreshold: viper.GetUint(FlagBidPriceRemoteBreakerThreshold),
+			BreakerCooldown:  viper.GetDuration(FlagBidPriceRemoteBreakerCooldown),
+		}
+
+		if viper.GetBool(FlagBidPriceRemoteFallback) {
+			fallback, err := createBidPricingStrategy(bidPricingStrategyScale)
+			if err != nil {
+				return nil, err
+			}
+
+			cfg.Fallback = fallback
+		}
+
+		return bidengine.MakeRemotePricing(cfg)
+	}
+
 	return nil, errNoSuchBidPricingStrategy
 }
 
+func createBidPricingChain(strategies string) (bidengine.BidPricingStrategy, error) {
+	var chain []bidengine.BidPricingStrategy
+
+	for _, strategy := range strings.Split(strategies, ",") {
+		pricing, err := createBidPricingStrategy(strings.TrimSpace(strategy))
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, pricing)
+	}
+
+	return bidengine.MakeChainPricing(chain...)
+}
+
+// createOwnerPolicy returns owner policy watched from file or assembled from flags, nil if none is configured
+func createOwnerPolicy(ctx context.Context) (bidengine.OwnerPolicySource, error) {
+	cfg := bidengine.OwnerPolicyConfig{
+		Allow: viper.GetStringSlice(FlagBidOwnerAllowlist),
+		Deny:  viper.GetStringSlice(FlagBidOwnerDenylist),
+		Quota: bidengine.OwnerQuotaConfig{
+			Leases: viper.GetUint64(FlagBidOwnerQuotaLeases),
+			CPU:    viper.GetString(FlagBidOwnerQuotaCPU),
+			GPU:    viper.GetUint64(FlagBidOwnerQuotaGPU),
+			Memory: viper.GetString(FlagBidOwnerQuotaMemory),
+		},
+	}
+
+	fromFlags := len(cfg.Allow) > 0 || len(cfg.Deny) > 0 || cfg.Quota != (bidengine.OwnerQuotaConfig{})
+
+	if path := viper.GetString(FlagBidOwnerPolicyPath); path != "" {
+		if fromFlags {
+			return nil, errBidOwnerPolicyAmbiguous
+		}
+
+		return bidengine.WatchOwnerPolicyPath(ctx, path)
+	}
+
+	if !fromFlags {
+		return nil, nil
+	}
+
+	return bidengine.NewOwnerPolicy(cfg)
+}
+
 // doRunCmd initializes all the Provider functionality, hangs, and awaits shutdown signals.
 func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	clusterPublicHostname := viper.GetString(FlagClusterPublicHostname)
@@ -553,6 +941,11 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	blockedHostnames := viper.GetStringSlice(FlagDeploymentBlockedHostnames)
 	deploymentRuntimeClass := viper.GetString(FlagDeploymentRuntimeClass)
 	bidTimeout := viper.GetDuration(FlagBidTimeout)
+	bidDecisionLogSize := viper.GetInt(FlagBidDecisionLogSize)
+	bidAdmission := bidengine.AdmissionConfig{
+		Window:    viper.GetDuration(FlagBidAdmissionWindow),
+		Objective: viper.GetString(FlagBidAdmissionObjective),
+	}
 	manifestTimeout := viper.GetDuration(FlagManifestTimeout)
 	metricsListener := viper.GetString(FlagMetricsListener)
 	providerConfig := viper.GetString(FlagProviderConfig)
@@ -565,7 +958,7 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	monitorHealthcheckPeriod := viper.GetDuration(FlagMonitorHealthcheckPeriod)
 	monitorHealthcheckPeriodJitter := viper.GetDuration(FlagMonitorHealthcheckPeriodJitter)
 
-	pricing, err := createBidPricingStrategy(strategy)
+	pricing, err := createBidPricingChain(strategy)
 	if err != nil {
 		return err
 	}
@@ -652,8 +1045,26 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	kubeSettings.MemoryCommitLevel = overcommitPercentMemory
 	kubeSettings.StorageCommitLevel = overcommitPercentStorage
 	kubeSettings.DeploymentRuntimeClass = deploymentRuntimeClass
+	kubeSettings.PreemptiblePriorityClass = viper.GetString(FlagDeploymentPreemptibleClass)
+	kubeSettings.HealthProbes = builder.ProbeSettings{
+		Enabled:          viper.GetBool(FlagDeploymentHealthProbes),
+		HTTPPath:         viper.GetString(FlagDeploymentProbeHTTPPath),
+		Period:           viper.GetDuration(FlagDeploymentProbePeriod),
+		Timeout:          viper.GetDuration(FlagDeploymentProbeTimeout),
+		FailureThreshold: viper.GetInt32(FlagDeploymentProbeFailureThreshold),
+		StartupTimeout:   viper.GetDuration(FlagDeploymentProbeStartupTimeout),
+	}
+	kubeSettings.Termination = builder.TerminationSettings{
+		GracePeriod:     viper.GetDuration(FlagDeploymentTerminationGracePeriod),
+		MaxGracePeriod:  viper.GetDuration(FlagDeploymentMaxTerminationGrace),
+		TeardownTimeout: viper.GetDuration(FlagDeploymentTeardownTimeout),
+	}
 	kubeSettings.DockerImagePullSecretsName = strings.TrimSpace(dockerImagePullSecretsName)
 
+	if kubeSettings.CredentialsSealer, err = readCredentialsSealer(viper.GetString(FlagDeploymentCredentialsKey)); err != nil {
+		return err
+	}
+
 	if err := builder.ValidateSettings(kubeSettings); err != nil {
 		return err
 	}
@@ -689,20 +1100,53 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	config.ClusterPublicHostname = clusterPublicHostname
 	config.ClusterExternalPortQuantity = nodePortQuantity
 	config.InventoryResourceDebugFrequency = inventoryResourceDebugFreq
+	config.InventoryPlacementStrategy = viper.GetString(FlagInventoryPlacementStrategy)
+	config.InventoryPlacementPinning = viper.GetString(FlagInventoryPlacementPinning)
 	config.InventoryResourcePollPeriod = inventoryResourcePollPeriod
+	config.InventoryForecastInterval = viper.GetDuration(FlagInventoryForecastInterval)
+	config.InventoryForecastWindow = viper.GetDuration(FlagInventoryForecastWindow)
+	config.InventoryForecastAlert = viper.GetDuration(FlagInventoryForecastAlert)
 	config.CPUCommitLevel = overcommitPercentCPU
+	config.GPUCommitLevel = overcommitPercentGPU
 	config.MemoryCommitLevel = overcommitPercentMemory
 	config.StorageCommitLevel = overcommitPercentStorage
 	config.BlockedHostnames = blockedHostnames
 	config.DeploymentIngressStaticHosts = deploymentIngressStaticHosts
 	config.DeploymentIngressDomain = deploymentIngressDomain
 	config.BidTimeout = bidTimeout
+	config.BidDecisionLogSize = bidDecisionLogSize
+	config.BidAdmission = bidAdmission
 	config.ManifestTimeout = manifestTimeout
 	config.MonitorMaxRetries = monitorMaxRetries
 	config.MonitorRetryPeriod = monitorRetryPeriod
 	config.MonitorRetryPeriodJitter = monitorRetryPeriodJitter
 	config.MonitorHealthcheckPeriod = monitorHealthcheckPeriod
 	config.MonitorHealthcheckPeriodJitter = monitorHealthcheckPeriodJitter
+	config.ReservationsStatePath = viper.GetString(FlagReservationsStatePath)
+
+	if config.Preemptible, err = clustertypes.ParsePreemptibleClass(viper.GetString(FlagPreemptibleAttribute)); err != nil {
+		return err
+	}
+
+	if config.RolloutPolicy, err = clustertypes.ParseRolloutPolicy(viper.GetString(FlagDeploymentRolloutPolicy)); err != nil {
+		return err
+	}
+
+	if config.MonitorFailurePolicies, err = clustertypes.ParseFailurePolicies(viper.GetStringMapString(FlagMonitorFailurePolicy)); err != nil {
+		return err
+	}
+
+	if path := viper.GetString(FlagCommitLevelsPath); path != "" {
+		if config.Commit, err = cluster.ReadCommitConfigPath(path); err != nil {
+			return err
+		}
+	}
+
+	if path := viper.GetString(FlagInventoryHeadroomPath); path != "" {
+		if config.Headroom, err = cluster.ReadHeadroomConfigPath(path); err != nil {
+			return err
+		}
+	}
 
 	if len(providerConfig) != 0 {
 		pConf, err := config2.ReadConfigPath(providerConfig)
@@ -713,6 +1157,15 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 		if err = config.Attributes.Validate(); err != nil {
 			return err
 		}
+
+		pricingConfig, err := bidengine.ReadPricingConfigPath(providerConfig)
+		if err != nil {
+			return err
+		}
+
+		if pricing, err = pricingConfig.Wrap(ctx, pricing); err != nil {
+			return err
+		}
 	}
 
 	config.BalanceCheckerCfg = provider.BalanceCheckerConfig{
@@ -721,6 +1174,10 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	}
 
 	config.BidPricingStrategy = pricing
+
+	if config.BidOwnerPolicy, err = createOwnerPolicy(ctx); err != nil {
+		return err
+	}
 	config.ClusterSettings = clusterSettings
 
 	bidDeposit, err := sdk.ParseCoinNormalized(viper.GetString(FlagBidDeposit))
@@ -760,6 +1217,16 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	if backends := viper.GetStringMapString(FlagClusterBackends); len(backends) != 0 {
+		var kubeClients map[string]kubernetes.Interface
+
+		if cclient, inventory, kubeClients, err = createClusterBackends(ctx, logger, backends, cclient, inventory); err != nil {
+			return err
+		}
+
+		ctx = context.WithValue(ctx, clfromctx.CtxKeyKubeClientBackends, kubeClients)
+	}
+
 	ctx = context.WithValue(ctx, clfromctx.CtxKeyClientInventory, inventory)
 
 	waitClients := make([]waiter.Waitable, 0)
@@ -793,7 +1260,7 @@ func doRunCmd(ctx context.Context, cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	err = gwgrpc.NewServer(ctx, grpcaddr, []tls.Certificate{tlsCert}, service)
+	err = gwgrpc.NewServer(ctx, grpcaddr, cctx.FromAddress, []tls.Certificate{tlsCert}, service)
 	if err != nil {
 		return err
 	}
@@ -863,6 +1330,87 @@ func createClusterClient(ctx context.Context, log log.Logger, _ *cobra.Command)
 	return kube.NewClient(ctx, log, ns)
 }
 
+// createClusterBackends aggregates primary cluster with clusters of given kubeconfig paths keyed by cluster name.
+// Kube client of every cluster is returned keyed by cluster name as well, nodes of all clusters are watched with them
+func createClusterBackends(
+	ctx context.Context,
+	log log.Logger,
+	paths map[string]string,
+	primary cluster.Client,
+	primaryInventory cinventory.Client,
+) (cluster.Client, cinventory.Client, map[string]kubernetes.Interface, error) {
+	if !viper.GetBool(FlagClusterK8s) {
+		return nil, nil, nil, fmt.Errorf("%w: --%s requires --%s", errInvalidConfig, FlagClusterBackends, FlagClusterK8s)
+	}
+
+	primaryKube, err := fromctx.KubeClientFromCtx(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ns := viper.GetString(providerflags.FlagK8sManifestNS)
+
+	backends := []cluster.Backend{{Name: cluster.PrimaryBackend, Client: primary}}
+	inventories := map[string]cinventory.Client{cluster.PrimaryBackend: primaryInventory}
+	kubeClients := map[string]kubernetes.Interface{cluster.PrimaryBackend: primaryKube}
+
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := os.ExpandEnv(paths[name])
+
+		// kube config falls back to in-cluster config when file is missing, which is the primary cluster
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: cluster %s: %w", errInvalidConfig, name, err)
+		}
+
+		kubecfg, err := clientcommon.OpenKubeConfig(path, log)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		kc, err := kubernetes.NewForConfig(kubecfg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		ac, err := akashclientset.NewForConfig(kubecfg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		bctx := context.WithValue(ctx, fromctx.CtxKeyKubeConfig, kubecfg)
+		bctx = context.WithValue(bctx, fromctx.CtxKeyKubeClientSet, kubernetes.Interface(kc))
+		bctx = context.WithValue(bctx, fromctx.CtxKeyAkashClientSet, akashclientset.Interface(ac))
+
+		client, err := kube.NewClient(bctx, log.With("cluster", name), ns)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("cluster %s: %w", name, err)
+		}
+
+		inventory, err := kubeinventory.NewClient(bctx)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("cluster %s: %w", name, err)
+		}
+
+		backends = append(backends, cluster.Backend{Name: name, Client: client})
+		inventories[name] = inventory
+		kubeClients[name] = kc
+	}
+
+	client, err := cluster.NewMultiClient(log, backends...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return client, cinventory.NewMultiClient(ctx, inventories), kubeClients, nil
+}
+
 func showErrorToUser(err error) error {
 	// If the error has a complete message associated with it then show it
 	terr := &gwrest.ClientResponseError{}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	"github.com/akash-network/node/app"
+	cmdcommon "github.com/akash-network/node/cmd/common"
+	"github.com/akash-network/node/sdl"
+	cutils "github.com/akash-network/node/x/cert/utils"
+
+	"github.com/akash-network/provider/bidengine"
+	aclient "github.com/akash-network/provider/client"
+	gwrest "github.com/akash-network/provider/gateway/rest"
+)
+
+// SimulateBidCmd asks provider how it would bid on groups of the SDL file or on a single group spec
+func SimulateBidCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "simulate-bid <sdl-path|group-spec.json>",
+		Args:         cobra.ExactArgs(1),
+		Short:        "Dry run provider bidding on deployment groups without reserving resources or placing a bid",
+		Long:         "Files with .json extension are read as a single GroupSpec, any other file is read as SDL",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return doSimulateBid(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().String(FlagProvider, "", "provider")
+	cmd.Flags().String(flags.FlagHome, app.DefaultHome, "the application home directory")
+	cmd.Flags().String(flags.FlagFrom, "", "name or address of private key with which to sign")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "select keyring's backend (os|file|kwallet|pass|test)")
+
+	if err := cmd.MarkFlagRequired(FlagProvider); err != nil {
+		panic(err.Error())
+	}
+
+	if err := cmd.MarkFlagRequired(flags.FlagFrom); err != nil {
+		panic(err.Error())
+	}
+
+	return cmd
+}
+
+func readGroupSpecs(path string) (dtypes.GroupSpecs, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var gspec dtypes.GroupSpec
+		if err = json.Unmarshal(buf, &gspec); err != nil {
+			return nil, err
+		}
+
+		return dtypes.GroupSpecs{&gspec}, nil
+	}
+
+	obj, err := sdl.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.DeploymentGroups()
+}
+
+func doSimulateBid(cmd *cobra.Command, path string) error {
+	cctx, err := sdkclient.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+
+	groups, err := readGroupSpecs(path)
+	if err != nil {
+		return err
+	}
+
+	prov, err := sdk.AccAddressFromBech32(cmd.Flag(FlagProvider).Value.String())
+	if err != nil {
+		return err
+	}
+
+	cl, err := aclient.DiscoverQueryClient(ctx, cctx)
+	if err != nil {
+		return err
+	}
+
+	cert, err := cutils.LoadAndQueryCertificateForAccount(ctx, cctx, nil)
+	if err != nil {
+		return markRPCServerError(err)
+	}
+
+	gclient, err := gwrest.NewClient(ctx, cl, prov, []tls.Certificate{cert})
+	if err != nil {
+		return err
+	}
+
+	type result struct {
+		Group      string               `json:"group"`
+		Simulation bidengine.Simulation `json:"simulation"`
+	}
+
+	results := make([]result, 0, len(groups))
+
+	for _, gspec := range groups {
+		res, err := gclient.Simulate(ctx, *gspec)
+		if err != nil {
+			return showErrorToUser(err)
+		}
+
+		results = append(results, result{
+			Group:      gspec.Name,
+			Simulation: res,
+		})
+	}
+
+	return cmdcommon.PrintJSON(cctx, results)
+}
@@ -24,6 +24,9 @@ type Config struct {
 	BalanceCheckerCfg           BalanceCheckerConfig
 	Attributes                  types.Attributes
 	MaxGroupVolumes             int
+	BidDecisionLogSize          int
+	BidOwnerPolicy              bidengine.OwnerPolicySource
+	BidAdmission                bidengine.AdmissionConfig
 	RPCQueryTimeout             time.Duration
 	CachedResultMaxAge          time.Duration
 	cluster.Config
@@ -37,7 +40,8 @@ func NewDefaultConfig() Config {
 			LeaseFundsCheckInterval: 1 * time.Minute,
 			WithdrawalPeriod:        24 * time.Hour,
 		},
-		MaxGroupVolumes: constants.DefaultMaxGroupVolumes,
-		Config:          cluster.NewDefaultConfig(),
+		MaxGroupVolumes:    constants.DefaultMaxGroupVolumes,
+		BidDecisionLogSize: bidengine.DefaultDecisionLogSize,
+		Config:             cluster.NewDefaultConfig(),
 	}
 }
@@ -63,3 +63,11 @@ type LeaseAddFundsMonitor struct {
 type LeaseRemoveFundsMonitor struct {
 	mtypes.LeaseID
 }
+
+// ReservationPreempted is published when inventory evicts reservation of preemptible order
+// to fit reservation of full price order. Lease of the evicted reservation is closed
+type ReservationPreempted struct {
+	OrderID     mtypes.OrderID
+	Group       string
+	PreemptedBy mtypes.OrderID
+}
@@ -0,0 +1,163 @@
+package grpc
+
+import (
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/akash-network/provider"
+	"github.com/akash-network/provider/bidengine"
+)
+
+const (
+	bidDecisionsServiceName = "akash.provider.bidengine.v1.BidDecisionsRPC"
+)
+
+// BidDecisionsRPCServer serves recent bid engine decisions.
+// Request accepts optional "dseq" and "limit" string values,
+// response holds the list of decisions under the "decisions" key.
+// Integers are carried as strings, same as protobuf json mapping of 64-bit integers
+type BidDecisionsRPCServer interface {
+	GetBidDecisions(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+var bidDecisionsServiceDesc = grpc.ServiceDesc{
+	ServiceName: bidDecisionsServiceName,
+	HandlerType: (*BidDecisionsRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBidDecisions",
+			Handler:    getBidDecisionsHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func RegisterBidDecisionsRPCServer(s grpc.ServiceRegistrar, srv BidDecisionsRPCServer) {
+	s.RegisterService(&bidDecisionsServiceDesc, srv)
+}
+
+func getBidDecisionsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BidDecisionsRPCServer).GetBidDecisions(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/" + bidDecisionsServiceName + "/GetBidDecisions",
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidDecisionsRPCServer).GetBidDecisions(ctx, req.(*structpb.Struct))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+type grpcBidDecisions struct {
+	addr   sdk.Address
+	client provider.BidDecisionsClient
+}
+
+var _ BidDecisionsRPCServer = (*grpcBidDecisions)(nil)
+
+func (gd *grpcBidDecisions) GetBidDecisions(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	owner := OwnerFromCtx(ctx)
+	if owner.Empty() {
+		return nil, status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	filter := bidengine.DecisionFilter{}
+
+	// provider owner is allowed to see decisions for all tenants
+	if !owner.Equals(gd.addr) {
+		filter.Owner = owner.String()
+	}
+
+	fields := req.GetFields()
+
+	if val, exists := fields["dseq"]; exists {
+		dseq, err := strconv.ParseUint(val.GetStringValue(), 10, 64)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid dseq")
+		}
+
+		filter.DSeq = dseq
+	}
+
+	if val, exists := fields["limit"]; exists {
+		limit, err := strconv.Atoi(val.GetStringValue())
+		if err != nil || limit < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid limit")
+		}
+
+		filter.Limit = limit
+	}
+
+	decisions, err := gd.client.BidDecisions(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return decisionsToStruct(decisions)
+}
+
+// decisionsToStruct converts decisions into the shape of their json representation served over REST
+func decisionsToStruct(decisions []bidengine.Decision) (*structpb.Struct, error) {
+	list := make([]interface{}, 0, len(decisions))
+
+	for _, d := range decisions {
+		val := map[string]interface{}{
+			"order_id": map[string]interface{}{
+				"owner": d.OrderID.Owner,
+				"dseq":  strconv.FormatUint(d.OrderID.DSeq, 10),
+				"gseq":  strconv.FormatUint(uint64(d.OrderID.GSeq), 10),
+				"oseq":  strconv.FormatUint(uint64(d.OrderID.OSeq), 10),
+			},
+			"timestamp": d.Timestamp.Format(time.RFC3339Nano),
+			"stage":     string(d.Stage),
+			"reason":    string(d.Reason),
+		}
+
+		if d.Message != "" {
+			val["message"] = d.Message
+		}
+
+		if d.Price != nil {
+			val["price"] = decCoinToMap(*d.Price)
+		}
+
+		if d.MaxPrice != nil {
+			val["max_price"] = decCoinToMap(*d.MaxPrice)
+		}
+
+		if d.Reservation != bidengine.ReservationOutcomeNone {
+			val["reservation"] = string(d.Reservation)
+		}
+
+		list = append(list, val)
+	}
+
+	return structpb.NewStruct(map[string]interface{}{
+		"decisions": list,
+	})
+}
+
+func decCoinToMap(coin sdk.DecCoin) map[string]interface{} {
+	return map[string]interface{}{
+		"denom":  coin.Denom,
+		"amount": coin.Amount.String(),
+	}
+}
@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	"github.com/akash-network/akash-api/go/testutil"
+
+	"github.com/akash-network/provider/bidengine"
+	"github.com/akash-network/provider/mocks"
+)
+
+func TestGetBidDecisions(t *testing.T) {
+	paddr := testutil.AccAddress(t)
+	taddr := testutil.AccAddress(t)
+
+	price := sdk.NewDecCoinFromDec("uakt", sdk.MustNewDecFromStr("1.5"))
+	decisions := []bidengine.Decision{
+		{
+			OrderID:     mtypes.OrderID{Owner: taddr.String(), DSeq: 9007199254740993, GSeq: 1, OSeq: 2},
+			Stage:       bidengine.DecisionStagePricing,
+			Reason:      bidengine.DecisionReasonPriced,
+			Price:       &price,
+			Reservation: bidengine.ReservationOutcomeReserved,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		owner  sdk.Address
+		req    map[string]interface{}
+		filter bidengine.DecisionFilter
+		code   codes.Code
+	}{
+		{
+			name:   "provider sees all tenants",
+			owner:  paddr,
+			req:    map[string]interface{}{"dseq": "9007199254740993", "limit": "5"},
+			filter: bidengine.DecisionFilter{DSeq: 9007199254740993, Limit: 5},
+		},
+		{
+			name:   "tenant sees own decisions",
+			owner:  taddr,
+			req:    map[string]interface{}{},
+			filter: bidengine.DecisionFilter{Owner: taddr.String()},
+		},
+		{
+			name: "no certificate",
+			req:  map[string]interface{}{},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:  "invalid dseq",
+			owner: taddr,
+			req:   map[string]interface{}{"dseq": 1.0},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "invalid limit",
+			owner: taddr,
+			req:   map[string]interface{}{"limit": "-1"},
+			code:  codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &mocks.Client{}
+			client.On("BidDecisions", mock.Anything, test.filter).Return(decisions, nil).Maybe()
+
+			ctx := context.Background()
+			if test.owner != nil {
+				ctx = ContextWithOwner(ctx, test.owner)
+			}
+
+			req, err := structpb.NewStruct(test.req)
+			require.NoError(t, err)
+
+			srv := &grpcBidDecisions{addr: paddr, client: client}
+
+			res, err := srv.GetBidDecisions(ctx, req)
+			if test.code != codes.OK {
+				require.Equal(t, test.code, status.Code(err))
+				client.AssertNotCalled(t, "BidDecisions")
+				return
+			}
+
+			require.NoError(t, err)
+			client.AssertExpectations(t)
+
+			list := res.GetFields()["decisions"].GetListValue().GetValues()
+			require.Len(t, list, 1)
+
+			fields := list[0].GetStructValue().GetFields()
+			orderID := fields["order_id"].GetStructValue().GetFields()
+			require.Equal(t, "9007199254740993", orderID["dseq"].GetStringValue())
+			require.Equal(t, "2", orderID["oseq"].GetStringValue())
+			require.Equal(t, "priced", fields["reason"].GetStringValue())
+			require.Equal(t, price.Amount.String(), fields["price"].GetStructValue().GetFields()["amount"].GetStringValue())
+			require.NotContains(t, fields, "max_price")
+		})
+	}
+}
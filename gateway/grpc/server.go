@@ -62,7 +62,15 @@ func OwnerFromCtx(ctx context.Context) sdk.Address {
 	return val.(sdk.Address)
 }
 
-func NewServer(ctx context.Context, endpoint string, certs []tls.Certificate, client provider.StatusClient) error {
+// Client is the set of provider services exposed over gRPC
+type Client interface {
+	provider.StatusClient
+	provider.BidDecisionsClient
+}
+
+// NewServer starts gRPC server on endpoint. addr is the provider address,
+// its certificate owner is allowed to query bid decisions of all tenants
+func NewServer(ctx context.Context, endpoint string, addr sdk.Address, certs []tls.Certificate, client Client) error {
 	// InsecureSkipVerify is set to true due to inability to use normal TLS verification
 	// certificate validation and authentication performed later in mtlsHandler
 	tlsConfig := &tls.Config{
@@ -90,6 +98,10 @@ func NewServer(ctx context.Context, endpoint string, certs []tls.Certificate, cl
 	}
 
 	providerv1.RegisterProviderRPCServer(grpcSrv, pRPC)
+	RegisterBidDecisionsRPCServer(grpcSrv, &grpcBidDecisions{
+		addr:   addr,
+		client: client,
+	})
 	gogoreflection.Register(grpcSrv)
 
 	group.Go(func() error {
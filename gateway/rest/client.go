@@ -35,6 +35,7 @@ import (
 	cutils "github.com/akash-network/node/x/cert/utils"
 
 	"github.com/akash-network/provider"
+	"github.com/akash-network/provider/bidengine"
 	cltypes "github.com/akash-network/provider/cluster/types/v1beta3"
 )
 
@@ -47,6 +48,11 @@ const (
 type Client interface {
 	Status(ctx context.Context) (*provider.Status, error)
 	Validate(ctx context.Context, gspec dtypes.GroupSpec) (provider.ValidateGroupSpecResult, error)
+	Simulate(ctx context.Context, gspec dtypes.GroupSpec) (bidengine.Simulation, error)
+	BidDecisions(ctx context.Context, dseq uint64, limit int) ([]bidengine.Decision, error)
+	NodeMaintenance(ctx context.Context) ([]cltypes.NodeMaintenance, error)
+	SetNodeMaintenance(ctx context.Context, maintenance cltypes.NodeMaintenance) error
+	ClearNodeMaintenance(ctx context.Context, node string) error
 	SubmitManifest(ctx context.Context, dseq uint64, mani manifest.Manifest) error
 	GetManifest(ctx context.Context, id mtypes.LeaseID) (manifest.Manifest, error)
 	LeaseStatus(ctx context.Context, id mtypes.LeaseID) (LeaseStatus, error)
@@ -339,6 +345,150 @@ func (c *client) Status(ctx context.Context) (*provider.Status, error) {
 	return &obj, nil
 }
 
+func (c *client) Simulate(ctx context.Context, gspec dtypes.GroupSpec) (bidengine.Simulation, error) {
+	uri, err := makeURI(c.host, simulatePath())
+	if err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	bgspec, err := json.Marshal(gspec)
+	if err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(bgspec))
+	if err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	rCl := c.newReqClient(ctx)
+	resp, err := rCl.hclient.Do(req)
+	if err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	buf := &bytes.Buffer{}
+	_, err = io.Copy(buf, resp.Body)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	if err = createClientResponseErrorIfNotOK(resp, buf); err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	var obj bidengine.Simulation
+	if err = json.NewDecoder(buf).Decode(&obj); err != nil {
+		return bidengine.Simulation{}, err
+	}
+
+	return obj, nil
+}
+
+func (c *client) BidDecisions(ctx context.Context, dseq uint64, limit int) ([]bidengine.Decision, error) {
+	uri, err := makeURI(c.host, bidDecisionsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if dseq != 0 {
+		query.Set("dseq", strconv.FormatUint(dseq, 10))
+	}
+
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	if len(query) > 0 {
+		uri = uri + "?" + query.Encode()
+	}
+
+	var obj []bidengine.Decision
+
+	if err := c.getStatus(ctx, uri, &obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (c *client) NodeMaintenance(ctx context.Context) ([]cltypes.NodeMaintenance, error) {
+	uri, err := makeURI(c.host, maintenancePath())
+	if err != nil {
+		return nil, err
+	}
+
+	var obj []cltypes.NodeMaintenance
+
+	if err := c.getStatus(ctx, uri, &obj); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+func (c *client) SetNodeMaintenance(ctx context.Context, maintenance cltypes.NodeMaintenance) error {
+	uri, err := makeURI(c.host, nodeMaintenancePath(maintenance.Node))
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(maintenance)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentTypeJSON)
+
+	return c.doMaintenanceRequest(ctx, req)
+}
+
+func (c *client) ClearNodeMaintenance(ctx context.Context, node string) error {
+	uri, err := makeURI(c.host, nodeMaintenancePath(node))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.doMaintenanceRequest(ctx, req)
+}
+
+func (c *client) doMaintenanceRequest(ctx context.Context, req *http.Request) error {
+	rCl := c.newReqClient(ctx)
+	resp, err := rCl.hclient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	responseBuf := &bytes.Buffer{}
+	_, err = io.Copy(responseBuf, resp.Body)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	return createClientResponseErrorIfNotOK(resp, responseBuf)
+}
+
 func (c *client) Validate(ctx context.Context, gspec dtypes.GroupSpec) (provider.ValidateGroupSpecResult, error) {
 	uri, err := makeURI(c.host, validatePath())
 	if err != nil {
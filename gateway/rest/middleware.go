@@ -87,6 +87,20 @@ func requireOwner() mux.MiddlewareFunc {
 	}
 }
 
+// requireProvider only lets the provider itself through, it must follow requireOwner
+func requireProvider() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !requestOwner(r).Equals(requestProvider(r)) {
+				http.Error(w, "", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func requireDeploymentID() mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"fmt"
+	"net/url"
 
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 )
@@ -12,6 +13,7 @@ const (
 	hostnamePrefix       = "/hostname"
 	endpointPrefix       = "/endpoint"
 	migratePathPrefix    = "/migrate"
+	maintenancePrefix    = "/maintenance"
 )
 
 func versionPath() string {
@@ -26,6 +28,22 @@ func validatePath() string {
 	return "validate"
 }
 
+func simulatePath() string {
+	return "simulate"
+}
+
+func bidDecisionsPath() string {
+	return "bid-decisions"
+}
+
+func maintenancePath() string {
+	return "maintenance"
+}
+
+func nodeMaintenancePath(node string) string {
+	return fmt.Sprintf("maintenance/%s", url.PathEscape(node))
+}
+
 func leasePath(id mtypes.LeaseID) string {
 	return fmt.Sprintf("lease/%d/%d/%d", id.DSeq, id.GSeq, id.OSeq)
 }
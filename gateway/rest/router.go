@@ -35,6 +35,7 @@ import (
 	"github.com/akash-network/node/util/wsutil"
 
 	"github.com/akash-network/provider"
+	"github.com/akash-network/provider/bidengine"
 	"github.com/akash-network/provider/cluster"
 	"github.com/akash-network/provider/cluster/kube/builder"
 	kubeclienterrors "github.com/akash-network/provider/cluster/kube/errors"
@@ -125,6 +126,40 @@ func newRouter(log log.Logger, addr sdk.Address, pclient provider.Client, ctxCon
 		validateHandler(log, pclient)).
 		Methods("GET")
 
+	// POST /simulate
+	// simulate endpoint runs bidding on given groupspec without reserving resources or placing a bid
+	vrouter.HandleFunc("/simulate",
+		simulateHandler(log, pclient)).
+		Methods(http.MethodPost)
+
+	// GET /bid-decisions
+	// recent bid engine decisions, tenants only see decisions on their own orders
+	vrouter.HandleFunc("/bid-decisions",
+		bidDecisionsHandler(log, pclient)).
+		Methods(http.MethodGet)
+
+	mrouter := router.PathPrefix(maintenancePrefix).Subrouter()
+	mrouter.Use(
+		requireOwner(),
+		requireProvider(),
+	)
+
+	// GET /maintenance
+	// nodes in maintenance or scheduled for it, provider only
+	mrouter.HandleFunc("",
+		listMaintenanceHandler(log, pclient)).
+		Methods(http.MethodGet)
+
+	// PUT /maintenance/<node>
+	mrouter.HandleFunc("/{node}",
+		setMaintenanceHandler(log, pclient.Cluster())).
+		Methods(http.MethodPut)
+
+	// DELETE /maintenance/<node>
+	mrouter.HandleFunc("/{node}",
+		clearMaintenanceHandler(log, pclient.Cluster())).
+		Methods(http.MethodDelete)
+
 	hostnameRouter := router.PathPrefix(hostnamePrefix).Subrouter()
 	hostnameRouter.Use(requireOwner())
 	hostnameRouter.HandleFunc(migratePathPrefix,
@@ -567,6 +602,139 @@ func validateHandler(log log.Logger, cl provider.ValidateClient) http.HandlerFun
 	}
 }
 
+func simulateHandler(log log.Logger, cl provider.SimulateClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var gspec dtypes.GroupSpec
+
+		decoder := json.NewDecoder(req.Body)
+		defer func() {
+			_ = req.Body.Close()
+		}()
+
+		if err := decoder.Decode(&gspec); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		res, err := cl.Simulate(req.Context(), requestOwner(req), gspec)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(log, w, res)
+	}
+}
+
+func listMaintenanceHandler(log log.Logger, cl provider.StatusClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		status, err := cl.Status(req.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		maintenance := make([]cltypes.NodeMaintenance, 0)
+		if status.Cluster != nil {
+			maintenance = append(maintenance, status.Cluster.Maintenance...)
+		}
+
+		writeJSON(log, w, maintenance)
+	}
+}
+
+func setMaintenanceHandler(log log.Logger, cl cluster.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		var maintenance cltypes.NodeMaintenance
+
+		decoder := json.NewDecoder(req.Body)
+		defer func() {
+			_ = req.Body.Close()
+		}()
+
+		if err := decoder.Decode(&maintenance); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+
+		if maintenance.Start != nil && maintenance.End != nil && maintenance.End.Before(*maintenance.Start) {
+			http.Error(w, "maintenance ends before it starts", http.StatusUnprocessableEntity)
+			return
+		}
+
+		maintenance.Node = mux.Vars(req)["node"]
+		// cordon status is read from the node
+		maintenance.Cordoned = false
+
+		if err := cl.SetNodeMaintenance(req.Context(), maintenance); err != nil {
+			if kubeErrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+
+			log.Error("set node maintenance", "node", maintenance.Node, "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func clearMaintenanceHandler(log log.Logger, cl cluster.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		node := mux.Vars(req)["node"]
+
+		if err := cl.ClearNodeMaintenance(req.Context(), node); err != nil {
+			if kubeErrors.IsNotFound(err) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+
+			log.Error("clear node maintenance", "node", node, "err", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func bidDecisionsHandler(log log.Logger, cl provider.BidDecisionsClient) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		filter := bidengine.DecisionFilter{}
+
+		// provider owner is allowed to see decisions for all tenants
+		if owner := requestOwner(req); !owner.Equals(requestProvider(req)) {
+			filter.Owner = owner.String()
+		}
+
+		var err error
+
+		if val := req.URL.Query().Get("dseq"); val != "" {
+			if filter.DSeq, err = strconv.ParseUint(val, 10, 64); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if val := req.URL.Query().Get("limit"); val != "" {
+			if filter.Limit, err = strconv.Atoi(val); err != nil || filter.Limit < 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest)
+				return
+			}
+		}
+
+		decisions, err := cl.BidDecisions(req.Context(), filter)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(log, w, decisions)
+	}
+}
+
 func createManifestHandler(log log.Logger, mclient pmanifest.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		var mani manifest.Manifest
@@ -29,6 +29,7 @@ import (
 	"github.com/akash-network/node/sdl"
 
 	"github.com/akash-network/provider"
+	"github.com/akash-network/provider/bidengine"
 	kubeclienterrors "github.com/akash-network/provider/cluster/kube/errors"
 	pcmock "github.com/akash-network/provider/cluster/mocks"
 	clustertypes "github.com/akash-network/provider/cluster/types/v1beta3"
@@ -514,6 +515,156 @@ func TestRouteValidateFails(t *testing.T) {
 	})
 }
 
+func TestRouteBidDecisionsOK(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		decisions := []bidengine.Decision{
+			{
+				OrderID: testutil.OrderID(t),
+				Stage:   bidengine.DecisionStagePricing,
+				Reason:  bidengine.DecisionReasonPriceTooHigh,
+			},
+		}
+
+		// tenant only sees decisions on own orders
+		filter := bidengine.DecisionFilter{
+			Owner: test.caddr.String(),
+			DSeq:  10,
+			Limit: 5,
+		}
+
+		test.pclient.On("BidDecisions", mock.Anything, filter).Return(decisions, nil)
+
+		res, err := test.gwclient.BidDecisions(context.Background(), 10, 5)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, decisions[0].OrderID, res[0].OrderID)
+		require.Equal(t, bidengine.DecisionReasonPriceTooHigh, res[0].Reason)
+	})
+}
+
+func TestRouteBidDecisionsInvalidQuery(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		uri, err := makeURI(test.host, bidDecisionsPath())
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", uri+"?limit=-1", nil)
+		require.NoError(t, err)
+
+		rCl := test.gwclient.newReqClient(context.Background())
+		resp, err := rCl.hclient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestRouteSimulateOK(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		price := testutil.AkashDecCoin(t, 200)
+		simulation := bidengine.Simulation{
+			Bid:    true,
+			Stage:  bidengine.DecisionStagePricing,
+			Reason: bidengine.DecisionReasonPriced,
+			Price:  &price,
+			Placements: []bidengine.SimulatedPlacement{
+				{
+					ResourceID: 1,
+					Nodes:      []string{"node1"},
+				},
+			},
+		}
+
+		test.pclient.On("Simulate", mock.Anything, test.caddr, mock.Anything).Return(simulation, nil)
+
+		res, err := test.gwclient.Simulate(context.Background(), testutil.GroupSpec(t))
+		require.NoError(t, err)
+		require.True(t, res.Bid)
+		require.Equal(t, bidengine.DecisionReasonPriced, res.Reason)
+		require.Equal(t, simulation.Placements, res.Placements)
+	})
+}
+
+func TestRouteSimulateFails(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		test.pclient.On("Simulate", mock.Anything, mock.Anything, mock.Anything).Return(bidengine.Simulation{}, errGeneric)
+
+		_, err := test.gwclient.Simulate(context.Background(), testutil.GroupSpec(t))
+		require.Error(t, err)
+	})
+}
+
+// providerGatewayClient connects to the gateway with certificate of the provider itself
+func providerGatewayClient(t *testing.T, test *routerTest) Client {
+	gclient, err := NewClient(context.Background(), test.qclient, test.paddr, test.pcert.Cert)
+	require.NoError(t, err)
+
+	return gclient
+}
+
+func TestRouteMaintenanceOK(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		maintenance := ctypes.NodeMaintenance{
+			Node:         "node1",
+			Reason:       "kernel upgrade",
+			End:          &end,
+			NotifyLeases: true,
+		}
+
+		test.pcclient.On("SetNodeMaintenance", mock.Anything, maintenance).Return(nil)
+		test.pcclient.On("ClearNodeMaintenance", mock.Anything, "node1").Return(nil)
+		test.pclient.On("Status", mock.Anything).Return(&provider.Status{
+			Cluster: &ctypes.Status{
+				Maintenance: []ctypes.NodeMaintenance{maintenance},
+			},
+		}, nil)
+
+		gclient := providerGatewayClient(t, test)
+
+		require.NoError(t, gclient.SetNodeMaintenance(context.Background(), maintenance))
+
+		res, err := gclient.NodeMaintenance(context.Background())
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, maintenance.Node, res[0].Node)
+		require.Equal(t, maintenance.Reason, res[0].Reason)
+		require.True(t, end.Equal(*res[0].End))
+
+		require.NoError(t, gclient.ClearNodeMaintenance(context.Background(), "node1"))
+	})
+}
+
+func TestRouteMaintenanceInvalidWindow(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		start := time.Now()
+		end := start.Add(-time.Hour)
+
+		err := providerGatewayClient(t, test).SetNodeMaintenance(context.Background(), ctypes.NodeMaintenance{
+			Node:  "node1",
+			Start: &start,
+			End:   &end,
+		})
+
+		var cerr ClientResponseError
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, http.StatusUnprocessableEntity, cerr.Status)
+	})
+}
+
+func TestRouteMaintenanceForbiddenForTenants(t *testing.T) {
+	runRouterTest(t, true, func(test *routerTest) {
+		err := test.gwclient.SetNodeMaintenance(context.Background(), ctypes.NodeMaintenance{Node: "node1"})
+
+		var cerr ClientResponseError
+		require.ErrorAs(t, err, &cerr)
+		require.Equal(t, http.StatusForbidden, cerr.Status)
+
+		_, err = test.gwclient.NodeMaintenance(context.Background())
+		require.Error(t, err)
+
+		test.pcclient.AssertNotCalled(t, "SetNodeMaintenance", mock.Anything, mock.Anything)
+	})
+}
+
 func TestRouteValidateFailsEmptyBody(t *testing.T) {
 	runRouterTest(t, true, func(test *routerTest) {
 		test.pclient.On("Validate", mock.Anything, mock.Anything).Return(provider.ValidateGroupSpecResult{}, errGeneric)
@@ -10,6 +10,7 @@ require (
 	github.com/boz/go-lifecycle v0.1.1
 	github.com/cosmos/cosmos-sdk v0.45.16
 	github.com/desertbit/timer v0.0.0-20180107155436-c41aec40b27f
+	github.com/expr-lang/expr v1.16.9
 	github.com/fsnotify/fsnotify v1.7.0
 	github.com/go-andiamo/splitter v1.2.5
 	github.com/go-kit/kit v0.12.0
@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	bidengine "github.com/akash-network/provider/bidengine"
+
 	cluster "github.com/akash-network/provider/cluster"
 
 	deploymentv1beta3 "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
@@ -35,6 +37,65 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
+// BidDecisions provides a mock function with given fields: _a0, _a1
+func (_m *Client) BidDecisions(_a0 context.Context, _a1 bidengine.DecisionFilter) ([]bidengine.Decision, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BidDecisions")
+	}
+
+	var r0 []bidengine.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bidengine.DecisionFilter) ([]bidengine.Decision, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bidengine.DecisionFilter) []bidengine.Decision); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]bidengine.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bidengine.DecisionFilter) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_BidDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BidDecisions'
+type Client_BidDecisions_Call struct {
+	*mock.Call
+}
+
+// BidDecisions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 bidengine.DecisionFilter
+func (_e *Client_Expecter) BidDecisions(_a0 interface{}, _a1 interface{}) *Client_BidDecisions_Call {
+	return &Client_BidDecisions_Call{Call: _e.mock.On("BidDecisions", _a0, _a1)}
+}
+
+func (_c *Client_BidDecisions_Call) Run(run func(_a0 context.Context, _a1 bidengine.DecisionFilter)) *Client_BidDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bidengine.DecisionFilter))
+	})
+	return _c
+}
+
+func (_c *Client_BidDecisions_Call) Return(_a0 []bidengine.Decision, _a1 error) *Client_BidDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_BidDecisions_Call) RunAndReturn(run func(context.Context, bidengine.DecisionFilter) ([]bidengine.Decision, error)) *Client_BidDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Cluster provides a mock function with given fields:
 func (_m *Client) Cluster() cluster.Client {
 	ret := _m.Called()
@@ -339,6 +400,64 @@ func (_c *Client_StatusV1_Call) RunAndReturn(run func(context.Context) (*v1.Stat
 	return _c
 }
 
+// Simulate provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Client) Simulate(_a0 context.Context, _a1 types.Address, _a2 deploymentv1beta3.GroupSpec) (bidengine.Simulation, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Simulate")
+	}
+
+	var r0 bidengine.Simulation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Address, deploymentv1beta3.GroupSpec) (bidengine.Simulation, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Address, deploymentv1beta3.GroupSpec) bidengine.Simulation); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(bidengine.Simulation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Address, deploymentv1beta3.GroupSpec) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_Simulate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Simulate'
+type Client_Simulate_Call struct {
+	*mock.Call
+}
+
+// Simulate is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 types.Address
+//   - _a2 deploymentv1beta3.GroupSpec
+func (_e *Client_Expecter) Simulate(_a0 interface{}, _a1 interface{}, _a2 interface{}) *Client_Simulate_Call {
+	return &Client_Simulate_Call{Call: _e.mock.On("Simulate", _a0, _a1, _a2)}
+}
+
+func (_c *Client_Simulate_Call) Run(run func(_a0 context.Context, _a1 types.Address, _a2 deploymentv1beta3.GroupSpec)) *Client_Simulate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(types.Address), args[2].(deploymentv1beta3.GroupSpec))
+	})
+	return _c
+}
+
+func (_c *Client_Simulate_Call) Return(_a0 bidengine.Simulation, _a1 error) *Client_Simulate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_Simulate_Call) RunAndReturn(run func(context.Context, types.Address, deploymentv1beta3.GroupSpec) (bidengine.Simulation, error)) *Client_Simulate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Validate provides a mock function with given fields: _a0, _a1, _a2
 func (_m *Client) Validate(_a0 context.Context, _a1 types.Address, _a2 deploymentv1beta3.GroupSpec) (provider.ValidateGroupSpecResult, error) {
 	ret := _m.Called(_a0, _a1, _a2)
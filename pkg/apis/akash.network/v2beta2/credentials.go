@@ -0,0 +1,169 @@
+package v2beta2
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CredentialsKeySize is the size of AES-256 key registry credentials are sealed with
+const CredentialsKeySize = 32
+
+var (
+	ErrCredentialsKey    = fmt.Errorf("credentials key must be %d bytes encoded in base64", CredentialsKeySize)
+	ErrCredentialsSealed = errors.New("credentials sealed with unknown key")
+)
+
+// sealedCredentials is the part of registry credentials kept sealed at rest
+type sealedCredentials struct {
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// CredentialsSealer seals registry credentials of manifest services with a key held by the provider.
+// Registry host is left readable and authenticates the sealed data
+type CredentialsSealer struct {
+	aead cipher.AEAD
+}
+
+// ParseCredentialsKey decodes base64 encoded key
+func ParseCredentialsKey(val string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(val))
+	if err != nil || len(key) != CredentialsKeySize {
+		return nil, ErrCredentialsKey
+	}
+
+	return key, nil
+}
+
+func NewCredentialsSealer(key []byte) (*CredentialsSealer, error) {
+	if len(key) != CredentialsKeySize {
+		return nil, ErrCredentialsKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CredentialsSealer{aead: aead}, nil
+}
+
+// IsSealed reports whether credentials are kept sealed
+func (c *ManifestServiceCredentials) IsSealed() bool {
+	return c.Sealed != ""
+}
+
+// Seal replaces email, username and password with their sealed form. Sealed credentials are left as is
+func (s *CredentialsSealer) Seal(creds *ManifestServiceCredentials) error {
+	if creds.IsSealed() {
+		return nil
+	}
+
+	data, err := json.Marshal(sealedCredentials{
+		Email:    creds.Email,
+		Username: creds.Username,
+		Password: creds.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	*creds = ManifestServiceCredentials{
+		Host:   creds.Host,
+		Sealed: base64.StdEncoding.EncodeToString(s.aead.Seal(nonce, nonce, data, []byte(creds.Host))),
+	}
+
+	return nil
+}
+
+// Unseal returns credentials in plain form. Credentials not sealed are returned as is
+func (s *CredentialsSealer) Unseal(creds ManifestServiceCredentials) (ManifestServiceCredentials, error) {
+	if !creds.IsSealed() {
+		return creds, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(creds.Sealed)
+	if err != nil || len(data) < s.aead.NonceSize() {
+		return ManifestServiceCredentials{}, ErrCredentialsSealed
+	}
+
+	nonce, data := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+
+	data, err = s.aead.Open(nil, nonce, data, []byte(creds.Host))
+	if err != nil {
+		return ManifestServiceCredentials{}, ErrCredentialsSealed
+	}
+
+	sealed := sealedCredentials{}
+	if err = json.Unmarshal(data, &sealed); err != nil {
+		return ManifestServiceCredentials{}, err
+	}
+
+	return ManifestServiceCredentials{
+		Host:     creds.Host,
+		Email:    sealed.Email,
+		Username: sealed.Username,
+		Password: sealed.Password,
+	}, nil
+}
+
+// SealManifest seals credentials of every manifest service, keeping those already sealed with
+// the same content so the manifest does not change on every update. Returns whether manifest changed
+func (s *CredentialsSealer) SealManifest(m *Manifest, prev *Manifest) (bool, error) {
+	changed := false
+
+	for idx := range m.Spec.Group.Services {
+		svc := &m.Spec.Group.Services[idx]
+		if svc.Credentials == nil || svc.Credentials.IsSealed() {
+			continue
+		}
+
+		if sealed := prevCredentials(prev, svc.Name); sealed != nil && sealed.IsSealed() {
+			if plain, err := s.Unseal(*sealed); err == nil && plain == *svc.Credentials {
+				svc.Credentials = sealed
+				continue
+			}
+		}
+
+		if err := s.Seal(svc.Credentials); err != nil {
+			return false, err
+		}
+
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// prevCredentials returns copy of credentials of named service in the manifest, nil if none
+func prevCredentials(m *Manifest, service string) *ManifestServiceCredentials {
+	if m == nil {
+		return nil
+	}
+
+	for _, svc := range m.Spec.Group.Services {
+		if svc.Name == service && svc.Credentials != nil {
+			return svc.Credentials.DeepCopy()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package v2beta2
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCredentialsSealer(t *testing.T, fill byte) *CredentialsSealer {
+	t.Helper()
+
+	key, err := ParseCredentialsKey(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, CredentialsKeySize)))
+	require.NoError(t, err)
+
+	sealer, err := NewCredentialsSealer(key)
+	require.NoError(t, err)
+
+	return sealer
+}
+
+func TestCredentialsSealUnseal(t *testing.T) {
+	sealer := testCredentialsSealer(t, 1)
+
+	plain := ManifestServiceCredentials{
+		Host:     "ghcr.io",
+		Email:    "foo@example.com",
+		Username: "foo",
+		Password: "bar",
+	}
+
+	creds := plain
+	require.NoError(t, sealer.Seal(&creds))
+	require.True(t, creds.IsSealed())
+	require.Equal(t, "ghcr.io", creds.Host)
+	require.Empty(t, creds.Username)
+	require.Empty(t, creds.Password)
+	require.Empty(t, creds.Email)
+
+	unsealed, err := sealer.Unseal(creds)
+	require.NoError(t, err)
+	require.Equal(t, plain, unsealed)
+
+	_, err = testCredentialsSealer(t, 2).Unseal(creds)
+	require.ErrorIs(t, err, ErrCredentialsSealed)
+
+	creds.Host = "docker.io"
+	_, err = sealer.Unseal(creds)
+	require.ErrorIs(t, err, ErrCredentialsSealed)
+
+	_, err = ParseCredentialsKey("c2hvcnQ=")
+	require.ErrorIs(t, err, ErrCredentialsKey)
+}
+
+func TestCredentialsSealManifest(t *testing.T) {
+	sealer := testCredentialsSealer(t, 1)
+
+	manifest := func(password string) *Manifest {
+		return &Manifest{
+			Spec: ManifestSpec{
+				Group: ManifestGroup{
+					Services: []ManifestService{
+						{Name: "web", Credentials: &ManifestServiceCredentials{Host: "ghcr.io", Username: "foo", Password: password}},
+						{Name: "db"},
+					},
+				},
+			},
+		}
+	}
+
+	prev := manifest("bar")
+	changed, err := sealer.SealManifest(prev, nil)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.True(t, prev.Spec.Group.Services[0].Credentials.IsSealed())
+	require.Nil(t, prev.Spec.Group.Services[1].Credentials)
+
+	changed, err = sealer.SealManifest(prev, nil)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	next := manifest("bar")
+	changed, err = sealer.SealManifest(next, prev)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, prev.Spec, next.Spec)
+
+	next = manifest("baz")
+	changed, err = sealer.SealManifest(next, prev)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.NotEqual(t, prev.Spec.Group.Services[0].Credentials, next.Spec.Group.Services[0].Credentials)
+
+	unsealed, err := sealer.Unseal(*next.Spec.Group.Services[0].Credentials)
+	require.NoError(t, err)
+	require.Equal(t, "baz", unsealed.Password)
+}
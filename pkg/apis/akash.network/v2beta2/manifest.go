@@ -34,9 +34,11 @@ type ManifestList struct {
 // ManifestServiceCredentials stores docker registry credentials
 type ManifestServiceCredentials struct {
 	Host     string `json:"host"`
-	Email    string `json:"email"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Sealed holds email, username and password sealed with provider key, those are empty then
+	Sealed string `json:"sealed,omitempty"`
 }
 
 // ManifestService stores name, image, args, env, unit, count and expose list of service
@@ -100,9 +102,40 @@ type SchedulerResources struct {
 	GPU *SchedulerResourceGPU `json:"gpu"`
 }
 
+// SchedulerPlacement lists nodes inventory reserved service replicas on
+type SchedulerPlacement struct {
+	Nodes []string `json:"nodes"`
+	// Required pins replicas to the nodes, otherwise the nodes are only preferred
+	Required bool `json:"required,omitempty"`
+	// Replicas lists node of every replica of preemptible service, so its capacity can be released after restart
+	Replicas []string `json:"replicas,omitempty"`
+}
+
+// SchedulerCommit is the node pool inventory committed service resources in and its commit levels
+type SchedulerCommit struct {
+	Pool        string            `json:"pool"`
+	MatchLabels map[string]string `json:"match_labels,omitempty"`
+	CPU         float64           `json:"cpu,omitempty"`
+	GPU         float64           `json:"gpu,omitempty"`
+	Memory      float64           `json:"memory,omitempty"`
+	Storage     float64           `json:"storage,omitempty"`
+	// StorageClasses are commit levels of volumes of the class, replacing Storage
+	StorageClasses map[string]float64 `json:"storage_classes,omitempty"`
+	// ExcludeLabels are match labels of node pools replicas of the default pool are kept off
+	ExcludeLabels []map[string]string `json:"exclude_labels,omitempty"`
+}
+
 type SchedulerParams struct {
 	RuntimeClass string              `json:"runtime_class"`
 	Resources    *SchedulerResources `json:"resources,omitempty"`
+	Placement    *SchedulerPlacement `json:"placement,omitempty"`
+	Commit       *SchedulerCommit    `json:"commit,omitempty"`
+	// Preemptible service runs with lower priority and may be evicted for full price leases
+	Preemptible bool `json:"preemptible,omitempty"`
+	// Cluster is the name of cluster backend service is deployed into when provider runs several clusters
+	Cluster string `json:"cluster,omitempty"`
+	// Rollout is the strategy service is updated with, rolling update when empty
+	Rollout ctypes.RolloutStrategy `json:"rollout,omitempty"`
 }
 
 type ClusterSettings struct {
@@ -312,18 +345,25 @@ func manifestServiceFromProvider(ams mani.Service, schedulerParams *SchedulerPar
 		}
 	}
 
-	if ams.Credentials != nil {
-		ms.Credentials = &ManifestServiceCredentials{
-			Host:     ams.Credentials.Host,
-			Email:    ams.Credentials.Email,
-			Username: ams.Credentials.Username,
-			Password: ams.Credentials.Password,
-		}
-	}
+	ms.Credentials = NewManifestServiceCredentials(ams.Credentials)
 
 	return ms, nil
 }
 
+// NewManifestServiceCredentials returns registry credentials of the service in plain form, nil if service has none
+func NewManifestServiceCredentials(creds *mani.ServiceImageCredentials) *ManifestServiceCredentials {
+	if creds == nil {
+		return nil
+	}
+
+	return &ManifestServiceCredentials{
+		Host:     creds.Host,
+		Email:    creds.Email,
+		Username: creds.Username,
+		Password: creds.Password,
+	}
+}
+
 func (mse ManifestServiceExpose) toAkash() (mani.ServiceExpose, error) {
 	proto, err := mani.ParseServiceProtocol(mse.Proto)
 	if err != nil {
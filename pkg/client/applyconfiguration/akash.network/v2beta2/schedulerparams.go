@@ -23,6 +23,8 @@ package v2beta2
 type SchedulerParamsApplyConfiguration struct {
 	RuntimeClass *string                               `json:"runtime_class,omitempty"`
 	Resources    *SchedulerResourcesApplyConfiguration `json:"resources,omitempty"`
+	Placement    *SchedulerPlacementApplyConfiguration `json:"placement,omitempty"`
+	Commit       *SchedulerCommitApplyConfiguration    `json:"commit,omitempty"`
 }
 
 // SchedulerParamsApplyConfiguration constructs a declarative configuration of the SchedulerParams type for use with
@@ -46,3 +48,19 @@ func (b *SchedulerParamsApplyConfiguration) WithResources(value *SchedulerResour
 	b.Resources = value
 	return b
 }
+
+// WithPlacement sets the Placement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Placement field is set to the value of the last call.
+func (b *SchedulerParamsApplyConfiguration) WithPlacement(value *SchedulerPlacementApplyConfiguration) *SchedulerParamsApplyConfiguration {
+	b.Placement = value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *SchedulerParamsApplyConfiguration) WithCommit(value *SchedulerCommitApplyConfiguration) *SchedulerParamsApplyConfiguration {
+	b.Commit = value
+	return b
+}
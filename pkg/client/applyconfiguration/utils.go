@@ -142,8 +142,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &akashnetworkv2beta2.ResourcesApplyConfiguration{}
 	case v2beta2.SchemeGroupVersion.WithKind("ResourceVolume"):
 		return &akashnetworkv2beta2.ResourceVolumeApplyConfiguration{}
+	case v2beta2.SchemeGroupVersion.WithKind("SchedulerCommit"):
+		return &akashnetworkv2beta2.SchedulerCommitApplyConfiguration{}
 	case v2beta2.SchemeGroupVersion.WithKind("SchedulerParams"):
 		return &akashnetworkv2beta2.SchedulerParamsApplyConfiguration{}
+	case v2beta2.SchemeGroupVersion.WithKind("SchedulerPlacement"):
+		return &akashnetworkv2beta2.SchedulerPlacementApplyConfiguration{}
 	case v2beta2.SchemeGroupVersion.WithKind("SchedulerResourceGPU"):
 		return &akashnetworkv2beta2.SchedulerResourceGPUApplyConfiguration{}
 	case v2beta2.SchemeGroupVersion.WithKind("SchedulerResources"):
@@ -40,10 +40,22 @@ type StatusClient interface {
 	StatusV1(ctx context.Context) (*provider.Status, error)
 }
 
+// SimulateClient is the interface to dry run bidding on given groupspec
+type SimulateClient interface {
+	Simulate(context.Context, sdktypes.Address, dtypes.GroupSpec) (bidengine.Simulation, error)
+}
+
+// BidDecisionsClient is the interface to query recent decisions of the bid engine
+type BidDecisionsClient interface {
+	BidDecisions(context.Context, bidengine.DecisionFilter) ([]bidengine.Decision, error)
+}
+
 //go:generate mockery --name Client
 type Client interface {
 	StatusClient
 	ValidateClient
+	SimulateClient
+	BidDecisionsClient
 	Manifest() manifest.Client
 	Cluster() cluster.Client
 	Hostname() ctypes.HostnameServiceClient
@@ -99,6 +111,10 @@ func NewService(ctx context.Context,
 		BidTimeout:      cfg.BidTimeout,
 		Attributes:      cfg.Attributes,
 		MaxGroupVolumes: cfg.MaxGroupVolumes,
+		DecisionLogSize: cfg.BidDecisionLogSize,
+		OwnerPolicy:     cfg.BidOwnerPolicy,
+		Admission:       cfg.BidAdmission,
+		Preemptible:     cfg.Preemptible,
 	})
 	if err != nil {
 		errmsg := "creating bidengine service"
@@ -233,6 +249,14 @@ func (s *service) StatusV1(ctx context.Context) (*provider.Status, error) {
 	}, nil
 }
 
+func (s *service) BidDecisions(ctx context.Context, filter bidengine.DecisionFilter) ([]bidengine.Decision, error) {
+	return s.bidengine.Decisions(ctx, filter)
+}
+
+func (s *service) Simulate(ctx context.Context, owner sdktypes.Address, gspec dtypes.GroupSpec) (bidengine.Simulation, error) {
+	return s.bidengine.Simulate(ctx, owner.String(), gspec)
+}
+
 func (s *service) Validate(ctx context.Context, owner sdktypes.Address, gspec dtypes.GroupSpec) (ValidateGroupSpecResult, error) {
 	// FUTURE - pass owner here
 	req := bidengine.Request{
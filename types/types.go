@@ -1,10 +1,11 @@
 package types
 
 const (
-	PubSubTopicLeasesStatus    = "leases-status"
-	PubSubTopicProviderStatus  = "provider-status"
-	PubSubTopicClusterStatus   = "cluster-status"
-	PubSubTopicBidengineStatus = "bidengine-status"
-	PubSubTopicManifestStatus  = "manifest-status"
-	PubSubTopicInventoryStatus = "inventory-status"
+	PubSubTopicLeasesStatus         = "leases-status"
+	PubSubTopicProviderStatus       = "provider-status"
+	PubSubTopicClusterStatus        = "cluster-status"
+	PubSubTopicBidengineStatus      = "bidengine-status"
+	PubSubTopicManifestStatus       = "manifest-status"
+	PubSubTopicInventoryStatus      = "inventory-status"
+	PubSubTopicReservationPreempted = "reservation-preempted"
 )